@@ -0,0 +1,235 @@
+// Command ledgerctl runs ledger maintenance tasks against the databases named
+// by POSTGRES_URI and MONGO_URI.
+//
+//	ledgerctl reconcile [-open-cases] [-output report.json]
+//	ledgerctl verify [-account id] [-output result.json]
+//	ledgerctl checkpoint [-output checkpoint.json]
+//
+// verify and checkpoint use the key in CHAIN_SIGNING_KEY to check and sign
+// checkpoints.
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/db"
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mongodb"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/postgres"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/google/uuid"
+)
+
+// Exit codes: a check that ran and found problems exits with exitProblems so
+// that scripts can tell it from a failure to run.
+const (
+	exitFailure  = 1
+	exitProblems = 2
+	exitUsage    = 64
+)
+
+const usage = `usage: ledgerctl <command> [flags]
+
+commands:
+  reconcile   compare account balances with their transaction history
+  verify      walk the transaction hash chains and report the first broken link
+  checkpoint  sign and export the current head of every transaction chain
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitUsage)
+	}
+
+	var code int
+	switch os.Args[1] {
+	case "reconcile":
+		code = reconcile(os.Args[2:])
+	case "verify":
+		code = verify(os.Args[2:])
+	case "checkpoint":
+		code = checkpoint(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		code = exitUsage
+	}
+	os.Exit(code)
+}
+
+// reconcile runs a reconciliation, which is stored like the scheduled ones,
+// and writes its report as JSON.
+func reconcile(args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	openCases := flags.Bool("open-cases", false, "open an investigation case for every account out of balance instead of correcting it")
+	output := flags.String("output", "-", "file to write the report to, - for standard output")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	postgresDB, err := db.InitPostgres()
+	if err != nil {
+		return fail(err)
+	}
+	mongoDB, _, err := db.InitMongo()
+	if err != nil {
+		return fail(err)
+	}
+	accountRepo := postgres.NewAccountRepository(postgresDB)
+	transactionRepo := mongodb.NewTransactionRepository(mongoDB)
+	// Corrections are queued through the outbox, which the server publishes,
+	// so no publisher is needed here.
+	limitService := service.NewLimitService(postgres.NewLimitRepository(postgresDB), transactionRepo, accountRepo)
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, nil, postgres.NewFXRateRepository(postgresDB), limitService)
+	reconciliationService := service.NewReconciliationService(
+		postgres.NewReconciliationRepository(postgresDB),
+		accountRepo,
+		transactionRepo,
+		postgres.NewLedgerRepository(postgresDB),
+		transactionService,
+	)
+
+	report, err := reconciliationService.Reconcile(context.Background(), *openCases, time.Now())
+	if err != nil {
+		return fail(err)
+	}
+	if err := writeJSON(*output, report); err != nil {
+		return fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "report %s: %d accounts checked, %d skipped, %d out of balance\n",
+		report.ID, report.AccountsChecked, report.AccountsSkipped, len(report.Discrepancies))
+	if len(report.Discrepancies) > 0 {
+		return exitProblems
+	}
+	return 0
+}
+
+// writeJSON writes value to the file at path, or to standard output if path
+// is "-".
+// verify verifies the chain of one account, or of every account, and writes
+// the result as JSON.
+func verify(args []string) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	accountID := flags.String("account", "", "account whose chain to verify, all accounts if empty")
+	output := flags.String("output", "-", "file to write the result to, - for standard output")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *accountID != "" {
+		if _, err := uuid.Parse(*accountID); err != nil {
+			fmt.Fprintln(os.Stderr, "ledgerctl: invalid account ID:", *accountID)
+			return exitUsage
+		}
+	}
+
+	chainService, err := newChainService()
+	if err != nil {
+		return fail(err)
+	}
+
+	var verifications []models.ChainVerification
+	if *accountID != "" {
+		verification, err := chainService.Verify(context.Background(), *accountID)
+		if err != nil {
+			return fail(err)
+		}
+		verifications = append(verifications, verification)
+	} else if verifications, err = chainService.VerifyAll(context.Background()); err != nil {
+		return fail(err)
+	}
+	if err := writeJSON(*output, verifications); err != nil {
+		return fail(err)
+	}
+
+	broken := 0
+	for _, verification := range verifications {
+		if verification.Valid {
+			continue
+		}
+		broken++
+		fmt.Fprintf(os.Stderr, "account %s: broken at link %d (transaction %s): %s\n",
+			verification.AccountID, verification.Break.Sequence, verification.Break.TransactionID, verification.Break.Reason)
+	}
+	fmt.Fprintf(os.Stderr, "%d chains verified, %d broken\n", len(verifications), broken)
+	if broken > 0 {
+		return exitProblems
+	}
+	return 0
+}
+
+// checkpoint signs and stores the current chain heads, like the scheduled
+// checkpoints, and writes the checkpoint as JSON.
+func checkpoint(args []string) int {
+	flags := flag.NewFlagSet("checkpoint", flag.ContinueOnError)
+	output := flags.String("output", "-", "file to write the checkpoint to, - for standard output")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	chainService, err := newChainService()
+	if err != nil {
+		return fail(err)
+	}
+	signed, err := chainService.Checkpoint(context.Background(), time.Now())
+	if err != nil {
+		return fail(err)
+	}
+	if err := writeJSON(*output, signed); err != nil {
+		return fail(err)
+	}
+	fmt.Fprintf(os.Stderr, "checkpoint %s: %d chain heads\n", signed.ID, len(signed.Heads))
+	return 0
+}
+
+func newChainService() (*service.ChainService, error) {
+	var signingKey ed25519.PrivateKey
+	if key := os.Getenv("CHAIN_SIGNING_KEY"); key != "" {
+		var err error
+		if signingKey, err = models.ParseChainSigningKey(key); err != nil {
+			return nil, err
+		}
+	}
+	postgresDB, err := db.InitPostgres()
+	if err != nil {
+		return nil, err
+	}
+	mongoDB, _, err := db.InitMongo()
+	if err != nil {
+		return nil, err
+	}
+	return service.NewChainService(postgres.NewChainRepository(postgresDB), mongodb.NewTransactionRepository(mongoDB), signingKey), nil
+}
+
+func writeJSON(path string, value interface{}) error {
+	if path == "-" {
+		return encodeJSON(os.Stdout, value)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(file, value); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func encodeJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, "ledgerctl:", err)
+	return exitFailure
+}
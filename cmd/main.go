@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/api/routes"
 	"github.com/RajVerma97/golang-banking-ledger/internal/db"
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mongodb"
 	"github.com/RajVerma97/golang-banking-ledger/internal/repository/postgres"
 	"github.com/RajVerma97/golang-banking-ledger/internal/service"
@@ -50,6 +55,19 @@ func main() {
 	mongoDB, _, _ := db.InitMongo()
 
 	accountRepo := postgres.NewAccountRepository(postgresDB)
+	ledgerRepo := postgres.NewLedgerRepository(postgresDB)
+	fxRateRepo := postgres.NewFXRateRepository(postgresDB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(postgresDB)
+	holdRepo := postgres.NewHoldRepository(postgresDB)
+	limitRepo := postgres.NewLimitRepository(postgresDB)
+	standingOrderRepo := postgres.NewStandingOrderRepository(postgresDB)
+	interestRepo := postgres.NewInterestRepository(postgresDB)
+	feeRuleRepo := postgres.NewFeeRuleRepository(postgresDB)
+	snapshotRepo := postgres.NewBalanceSnapshotRepository(postgresDB)
+	statementRepo := postgres.NewStatementRepository(postgresDB)
+	businessDayRepo := postgres.NewBusinessDayRepository(postgresDB)
+	reconciliationRepo := postgres.NewReconciliationRepository(postgresDB)
+	chainRepo := postgres.NewChainRepository(postgresDB)
 	transactionRepo := mongodb.NewTransactionRepository(mongoDB)
 
 	rabbitMQConn, rabbitMQChannel, err := queue.InitRabbitMQ()
@@ -60,14 +78,178 @@ func main() {
 	defer rabbitMQChannel.Close()
 
 	accountService := service.NewAccountService(accountRepo)
-	transactionService := service.NewTransactionService(transactionRepo, accountRepo, rabbitMQChannel)
+	limitService := service.NewLimitService(limitRepo, transactionRepo, accountRepo)
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, rabbitMQChannel, fxRateRepo, limitService)
+	ledgerService := service.NewLedgerService(ledgerRepo, accountRepo)
+	fxService := service.NewFXService(fxRateRepo)
+	holdService := service.NewHoldService(holdRepo, transactionRepo)
+	overdraftService := service.NewOverdraftService(accountRepo)
+	statusService := service.NewAccountStatusService(accountRepo)
+	closureService := service.NewClosureService(accountRepo, accountRepo, transactionRepo)
+
+	maxStandingOrderFailures := models.DefaultMaxStandingOrderFailures
+	if failures := os.Getenv("STANDING_ORDER_MAX_FAILURES"); failures != "" {
+		if maxStandingOrderFailures, err = strconv.Atoi(failures); err != nil {
+			log.Fatal("Invalid STANDING_ORDER_MAX_FAILURES:", err)
+		}
+	}
+	standingOrderService := service.NewStandingOrderService(standingOrderRepo, accountRepo, transactionService, maxStandingOrderFailures)
+	interestService := service.NewInterestService(interestRepo, accountRepo, businessDayRepo, snapshotRepo, transactionService)
+	feeService := service.NewFeeService(feeRuleRepo, accountRepo, transactionService)
+	balanceService := service.NewBalanceService(snapshotRepo, ledgerRepo, accountRepo)
+	statementService := service.NewStatementService(statementRepo, transactionRepo, accountRepo, ledgerRepo)
+	businessDayService := service.NewBusinessDayService(businessDayRepo, snapshotRepo, ledgerRepo, accountRepo, transactionRepo)
+	reconciliationService := service.NewReconciliationService(reconciliationRepo, accountRepo, transactionRepo, ledgerRepo, transactionService)
+
+	var chainSigningKey ed25519.PrivateKey
+	if key := os.Getenv("CHAIN_SIGNING_KEY"); key != "" {
+		if chainSigningKey, err = models.ParseChainSigningKey(key); err != nil {
+			log.Fatal("Invalid CHAIN_SIGNING_KEY:", err)
+		}
+	}
+	chainService := service.NewChainService(chainRepo, transactionRepo, chainSigningKey)
+
+	if ratesFile := os.Getenv("FX_RATES_FILE"); ratesFile != "" {
+		loaded, err := fxService.LoadFile(context.Background(), ratesFile)
+		if err != nil {
+			log.Fatal("Failed to load FX rates:", err)
+		}
+		logger.Info("FX rates loaded", zap.String("file", ratesFile), zap.Int("rates", loaded))
+	}
+
+	idempotencyTTL := middleware.DefaultIdempotencyKeyTTL
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		if idempotencyTTL, err = time.ParseDuration(ttl); err != nil {
+			log.Fatal("Invalid IDEMPOTENCY_TTL:", err)
+		}
+	}
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := idempotencyRepo.DeleteExpired(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to delete expired idempotency keys", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			if _, err := holdRepo.ExpireDue(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to expire holds", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := interestService.AccrueDue(context.Background()); err != nil {
+				logger.Error("Failed to accrue interest", zap.Error(err))
+				continue
+			}
+			if _, err := interestService.PostDue(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to post interest", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := feeService.ChargeMaintenance(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to charge maintenance fees", zap.Error(err))
+			}
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := businessDayService.CloseDue(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to close business day", zap.Error(err))
+			}
+		}
+	}()
+
+	reconciliationInterval := 24 * time.Hour
+	if interval := os.Getenv("RECONCILIATION_INTERVAL"); interval != "" {
+		if reconciliationInterval, err = time.ParseDuration(interval); err != nil {
+			log.Fatal("Invalid RECONCILIATION_INTERVAL:", err)
+		}
+	}
+	reconciliationOpenCases := false
+	if openCases := os.Getenv("RECONCILIATION_OPEN_CASES"); openCases != "" {
+		if reconciliationOpenCases, err = strconv.ParseBool(openCases); err != nil {
+			log.Fatal("Invalid RECONCILIATION_OPEN_CASES:", err)
+		}
+	}
+
+	go func() {
+		for range time.Tick(reconciliationInterval) {
+			report, err := reconciliationService.Reconcile(context.Background(), reconciliationOpenCases, time.Now())
+			if err != nil {
+				logger.Error("Failed to reconcile accounts", zap.Error(err))
+				continue
+			}
+			if len(report.Discrepancies) > 0 {
+				logger.Warn("Reconciliation found accounts out of balance",
+					zap.String("report", report.ID.String()),
+					zap.Int("discrepancies", len(report.Discrepancies)),
+				)
+			}
+		}
+	}()
+
+	chainCheckpointInterval := time.Hour
+	if interval := os.Getenv("CHAIN_CHECKPOINT_INTERVAL"); interval != "" {
+		if chainCheckpointInterval, err = time.ParseDuration(interval); err != nil {
+			log.Fatal("Invalid CHAIN_CHECKPOINT_INTERVAL:", err)
+		}
+	}
+	if chainSigningKey == nil {
+		logger.Warn("CHAIN_SIGNING_KEY is not set, transaction chains will not be checkpointed")
+	}
+
+	// Linking and checkpointing share a goroutine so that a checkpoint is
+	// taken with every final transaction linked.
+	go func() {
+		link := time.Tick(10 * time.Second)
+		checkpoint := time.Tick(chainCheckpointInterval)
+		for {
+			select {
+			case <-link:
+				if _, err := chainService.LinkPending(context.Background(), time.Now()); err != nil {
+					logger.Error("Failed to link transactions into chains", zap.Error(err))
+				}
+			case <-checkpoint:
+				if chainSigningKey == nil {
+					continue
+				}
+				if _, err := chainService.LinkPending(context.Background(), time.Now()); err != nil {
+					logger.Error("Failed to link transactions into chains", zap.Error(err))
+					continue
+				}
+				if _, err := chainService.Checkpoint(context.Background(), time.Now()); err != nil {
+					logger.Error("Failed to checkpoint transaction chains", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := statementService.GenerateDue(context.Background(), time.Now()); err != nil {
+				logger.Error("Failed to generate statements", zap.Error(err))
+			}
+		}
+	}()
+
+	go worker.NewOutboxRelay(transactionService, time.Second).Run(context.Background())
+	go worker.NewScheduler(transactionService, standingOrderService, 10*time.Second).Run(context.Background())
 
 	go func() {
-		worker := worker.NewTransactionWorker(rabbitMQChannel, accountRepo, transactionRepo)
+		worker := worker.NewTransactionWorker(rabbitMQChannel, accountRepo, transactionRepo, ledgerRepo, holdRepo, feeRuleRepo, interestRepo)
 		worker.ProcessTransactions()
 	}()
 
-	routes.Setup(router, accountService, transactionService)
+	routes.Setup(router, accountService, transactionService, ledgerService, fxService, holdService, overdraftService, limitService, statusService, closureService, standingOrderService, interestService, feeService, balanceService, statementService, businessDayService, reconciliationService, chainService, middleware.Idempotency(idempotencyRepo, idempotencyTTL))
 
 	fmt.Printf("Server Listening on Port testing new yes %s\n", PORT)
 	router.Run(":" + PORT)
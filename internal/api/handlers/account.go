@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"math/rand"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
@@ -43,6 +46,7 @@ func (accountHandler *AccountHandler) GetAccountByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
 		return
 	}
+	c.Header("ETag", accountETag(account.Version))
 	c.JSON(http.StatusOK, account)
 }
 func (accountHandler *AccountHandler) CreateAccount(c *gin.Context) {
@@ -53,16 +57,52 @@ func (accountHandler *AccountHandler) CreateAccount(c *gin.Context) {
 		return
 	}
 
+	currency := models.DefaultCurrency
+	if newAccountRequest.Currency != "" {
+		var err error
+		if currency, err = models.ParseCurrency(newAccountRequest.Currency); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid currency code"})
+			return
+		}
+	}
+
+	tier := models.TierStandard
+	if newAccountRequest.Tier != "" {
+		if tier = models.AccountTier(newAccountRequest.Tier); !tier.Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account tier"})
+			return
+		}
+	}
+
+	var product string
+	if newAccountRequest.Product != "" {
+		var err error
+		if product, err = models.ParseProduct(newAccountRequest.Product); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product"})
+			return
+		}
+	}
+
+	if !newAccountRequest.Balance.HasPrecision(currency.Decimals()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the balance has more decimal places than the currency allows"})
+		return
+	}
+
 	newAccount := models.Account{
-		ID:            uuid.New(),
-		AccountNumber: generateAccountNumber(),
-		FirstName:     newAccountRequest.FirstName,
-		LastName:      newAccountRequest.LastName,
-		Email:         newAccountRequest.Email,
-		Phone:         newAccountRequest.Phone,
-		Balance:       newAccountRequest.Balance,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		ID:               uuid.New(),
+		AccountNumber:    generateAccountNumber(),
+		FirstName:        newAccountRequest.FirstName,
+		LastName:         newAccountRequest.LastName,
+		Email:            newAccountRequest.Email,
+		Phone:            newAccountRequest.Phone,
+		Balance:          newAccountRequest.Balance,
+		AvailableBalance: newAccountRequest.Balance,
+		Currency:         currency,
+		Tier:             tier,
+		Product:          product,
+		Status:           models.AccountActive,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
 	}
 
 	if err := accountHandler.service.Create(c.Request.Context(), &newAccount); err != nil {
@@ -85,6 +125,11 @@ func (accountHandler *AccountHandler) UpdateAccount(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var updateData models.AccountUpdate
 	if err := c.ShouldBindJSON(&updateData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
@@ -96,24 +141,40 @@ func (accountHandler *AccountHandler) UpdateAccount(c *gin.Context) {
 		return
 	}
 
-	if err := accountHandler.service.Update(c.Request.Context(), id, updateData); err != nil {
+	if err := accountHandler.service.Update(c.Request.Context(), id, version, updateData); err != nil {
+		if errors.Is(err, models.ErrStaleVersion) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "account was modified since it was read"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update account"})
 		return
 	}
 
+	c.Header("ETag", accountETag(version+1))
 	c.JSON(http.StatusOK, gin.H{"message": "account updated successfully"})
 }
-func (accountHandler *AccountHandler) DeleteAccount(c *gin.Context) {
-	accountIDStr := c.Param("id")
-	id, err := uuid.Parse(accountIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
-		return
+
+func accountETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion reads the account version a change was based on from the
+// If-Match header, writing an error response if it is missing or malformed.
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header with the account's ETag is required"})
+		return 0, false
 	}
 
-	if err := accountHandler.service.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete account"})
-		return
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		tag = header
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return 0, false
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "account deleted successfully"})
+	return version, true
 }
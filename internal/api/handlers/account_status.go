@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AccountStatusHandler struct {
+	statusService *service.AccountStatusService
+}
+
+func NewAccountStatusHandler(statusService *service.AccountStatusService) *AccountStatusHandler {
+	return &AccountStatusHandler{statusService: statusService}
+}
+
+func (h *AccountStatusHandler) ChangeStatus(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	var request models.AccountStatusUpdate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	change, err := h.statusService.ChangeStatus(c.Request.Context(), accountID, request, c.GetHeader(OperatorIDHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrAccountNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+		case errors.Is(err, models.ErrInvalidStatusUpdate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change account status"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, change)
+}
+
+func (h *AccountStatusHandler) GetStatusChanges(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	changes, err := h.statusService.GetStatusChanges(c.Request.Context(), accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch account status changes"})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// writeAccountStatusError rejects a transaction the account's status does not
+// allow.
+func writeAccountStatusError(c *gin.Context, err error) {
+	c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+}
@@ -90,7 +90,7 @@ func TestAccountHandler_GetAccountByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		accountID := uuid.New()
-		expectedAccount := models.Account{ID: accountID, FirstName: "John"}
+		expectedAccount := models.Account{ID: accountID, FirstName: "John", Version: 3}
 		mockService.On("GetByID", mock.Anything, accountID).Return(expectedAccount, nil)
 
 		w := httptest.NewRecorder()
@@ -104,6 +104,7 @@ func TestAccountHandler_GetAccountByID(t *testing.T) {
 		var response models.Account
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.Equal(t, expectedAccount, response)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
 		mockService.AssertExpectations(t)
 	})
 }
@@ -137,6 +138,38 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("InvalidCurrency", func(t *testing.T) {
+		mockService := new(mocks.MockAccountService)
+		handler := NewAccountHandler(mockService)
+
+		body := []byte(`{"firstName": "John", "email": "john@example.com", "currency": "XYZ"}`)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/accounts", bytes.NewBuffer(body))
+
+		handler.CreateAccount(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TooManyDecimalPlaces", func(t *testing.T) {
+		mockService := new(mocks.MockAccountService)
+		handler := NewAccountHandler(mockService)
+
+		body := []byte(`{"firstName": "John", "email": "john@example.com", "balance": 10.005}`)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/accounts", bytes.NewBuffer(body))
+
+		handler.CreateAccount(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
 func TestAccountHandler_UpdateAccount(t *testing.T) {
 	accountID := uuid.New()
@@ -151,6 +184,7 @@ func TestAccountHandler_UpdateAccount(t *testing.T) {
 		mockService.On("Update",
 			mock.Anything,
 			accountID,
+			int64(1),
 			updateData,
 		).Return(assert.AnError)
 
@@ -158,6 +192,7 @@ func TestAccountHandler_UpdateAccount(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Params = []gin.Param{{Key: "id", Value: accountID.String()}}
 		c.Request = httptest.NewRequest("PUT", "/accounts/"+accountID.String(), bytes.NewBuffer(body))
+		c.Request.Header.Set("If-Match", `"1"`)
 
 		handler.UpdateAccount(c)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -174,6 +209,7 @@ func TestAccountHandler_UpdateAccount(t *testing.T) {
 		mockService.On("Update",
 			mock.Anything,
 			accountID,
+			int64(1),
 			updateData,
 		).Return(nil)
 
@@ -181,73 +217,51 @@ func TestAccountHandler_UpdateAccount(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Params = []gin.Param{{Key: "id", Value: accountID.String()}}
 		c.Request = httptest.NewRequest("PUT", "/accounts/"+accountID.String(), bytes.NewBuffer(body))
+		c.Request.Header.Set("If-Match", `"1"`)
 
 		handler.UpdateAccount(c)
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 		mockService.AssertExpectations(t)
 	})
-}
-func TestAccountHandler_DeleteAccount(t *testing.T) {
-	accountID := uuid.New()
-
-	t.Run("InvalidID", func(t *testing.T) {
-		mockService := new(mocks.MockAccountService)
-		handler := NewAccountHandler(mockService)
-
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Params = []gin.Param{{Key: "id", Value: "invalid"}}
-		c.Request = httptest.NewRequest("DELETE", "/accounts/invalid", nil)
-
-		handler.DeleteAccount(c)
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-	})
 
-	t.Run("ServiceError", func(t *testing.T) {
+	t.Run("MissingIfMatch", func(t *testing.T) {
 		mockService := new(mocks.MockAccountService)
 		handler := NewAccountHandler(mockService)
 
-		mockService.On("Delete", 
-			mock.Anything, 
-			accountID,
-		).Return(assert.AnError)
+		body, _ := json.Marshal(models.AccountUpdate{FirstName: stringPtr("NewName")})
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Params = []gin.Param{{Key: "id", Value: accountID.String()}}
-		c.Request = httptest.NewRequest("DELETE", "/accounts/"+accountID.String(), nil)
+		c.Request = httptest.NewRequest("PUT", "/accounts/"+accountID.String(), bytes.NewBuffer(body))
 
-		handler.DeleteAccount(c)
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		mockService.AssertExpectations(t)
+		handler.UpdateAccount(c)
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+		mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("Success", func(t *testing.T) {
+	t.Run("StaleVersion", func(t *testing.T) {
 		mockService := new(mocks.MockAccountService)
 		handler := NewAccountHandler(mockService)
 
-		mockService.On("Delete",
-			mock.Anything, 
-			accountID,
-		).Return(nil)
+		updateData := models.AccountUpdate{FirstName: stringPtr("NewName")}
+		body, _ := json.Marshal(updateData)
+
+		mockService.On("Update", mock.Anything, accountID, int64(1), updateData).
+			Return(models.ErrStaleVersion)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Params = []gin.Param{{Key: "id", Value: accountID.String()}}
-		c.Request = httptest.NewRequest("DELETE", "/accounts/"+accountID.String(), nil)
+		c.Request = httptest.NewRequest("PUT", "/accounts/"+accountID.String(), bytes.NewBuffer(body))
+		c.Request.Header.Set("If-Match", `"1"`)
 
-		handler.DeleteAccount(c)
-		assert.Equal(t, http.StatusOK, w.Code)
-		
-		
-		var response gin.H
-		json.Unmarshal(w.Body.Bytes(), &response)
-		assert.Equal(t, "account deleted successfully", response["message"])
-		
+		handler.UpdateAccount(c)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
-
 func stringPtr(s string) *string {
 	return &s
 }
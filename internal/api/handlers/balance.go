@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BalanceHandler struct {
+	balanceService *service.BalanceService
+}
+
+func NewBalanceHandler(balanceService *service.BalanceService) *BalanceHandler {
+	return &BalanceHandler{balanceService: balanceService}
+}
+
+// GetBalance returns the account's balance as of the RFC 3339 timestamp in
+// asOf, or as of now without one.
+func (h *BalanceHandler) GetBalance(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	asOf := time.Now()
+	if value := c.Query("asOf"); value != "" {
+		if asOf, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "asOf must be an RFC 3339 timestamp"})
+			return
+		}
+	}
+
+	balance, err := h.balanceService.GetBalanceAt(c.Request.Context(), accountID, asOf)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, balance)
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, service.ErrInvalidAsOf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute balance"})
+	}
+}
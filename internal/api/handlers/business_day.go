@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BusinessDayHandler struct {
+	businessDayService *service.BusinessDayService
+}
+
+func NewBusinessDayHandler(businessDayService *service.BusinessDayService) *BusinessDayHandler {
+	return &BusinessDayHandler{businessDayService: businessDayService}
+}
+
+func (h *BusinessDayHandler) GetBusinessDays(c *gin.Context) {
+	days, err := h.businessDayService.GetDays(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch business days"})
+		return
+	}
+	c.JSON(http.StatusOK, days)
+}
+
+func (h *BusinessDayHandler) GetBusinessDay(c *gin.Context) {
+	date, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+		return
+	}
+
+	day, err := h.businessDayService.GetDay(c.Request.Context(), date)
+	if err != nil {
+		writeBusinessDayError(c, err, "failed to fetch business day")
+		return
+	}
+	c.JSON(http.StatusOK, day)
+}
+
+// CloseBusinessDay closes the day, or finishes closing it if an earlier close
+// stopped partway.
+func (h *BusinessDayHandler) CloseBusinessDay(c *gin.Context) {
+	date, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+		return
+	}
+
+	day, err := h.businessDayService.CloseDay(c.Request.Context(), date, time.Now())
+	if err != nil {
+		writeBusinessDayError(c, err, "failed to close business day")
+		return
+	}
+	c.JSON(http.StatusOK, day)
+}
+
+// GetSnapshots returns the account's end-of-day snapshots for the days from
+// and to, given as YYYY-MM-DD, both included. Without them it returns the last
+// 30 days.
+func (h *BusinessDayHandler) GetSnapshots(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	to := models.BusinessDate(time.Now()).AddDate(0, 0, -1)
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.DateOnly, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be YYYY-MM-DD"})
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -29)
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.DateOnly, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	// The snapshot closing a day is taken when it ends.
+	snapshots, err := h.businessDayService.GetSnapshots(c.Request.Context(), accountID, from.AddDate(0, 0, 1), to.AddDate(0, 0, 2))
+	if err != nil {
+		writeBusinessDayError(c, err, "failed to fetch snapshots")
+		return
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
+
+func writeBusinessDayError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrBusinessDayNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "business day not found"})
+	case errors.Is(err, models.ErrInvalidBusinessDay):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrBusinessDayPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChainHandler struct {
+	chainService *service.ChainService
+}
+
+func NewChainHandler(chainService *service.ChainService) *ChainHandler {
+	return &ChainHandler{chainService: chainService}
+}
+
+// VerifyAccount walks the account's transaction chain and reports the first
+// broken link, if any.
+func (h *ChainHandler) VerifyAccount(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	verification, err := h.chainService.Verify(c.Request.Context(), accountID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify transaction chain"})
+		return
+	}
+	c.JSON(http.StatusOK, verification)
+}
+
+// VerifyAll verifies every account's chain and returns those that are broken.
+func (h *ChainHandler) VerifyAll(c *gin.Context) {
+	verifications, err := h.chainService.VerifyAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify transaction chains"})
+		return
+	}
+
+	broken := []models.ChainVerification{}
+	for _, verification := range verifications {
+		if !verification.Valid {
+			broken = append(broken, verification)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"chains": len(verifications),
+		"valid":  len(broken) == 0,
+		"broken": broken,
+	})
+}
+
+func (h *ChainHandler) Checkpoint(c *gin.Context) {
+	checkpoint, err := h.chainService.Checkpoint(c.Request.Context(), time.Now())
+	if err != nil {
+		writeChainError(c, err, "failed to take chain checkpoint")
+		return
+	}
+	c.JSON(http.StatusCreated, checkpoint)
+}
+
+func (h *ChainHandler) GetCheckpoints(c *gin.Context) {
+	checkpoints, err := h.chainService.GetCheckpoints(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch chain checkpoints"})
+		return
+	}
+	c.JSON(http.StatusOK, checkpoints)
+}
+
+// ExportCheckpoint downloads a signed checkpoint with every chain head it
+// records.
+func (h *ChainHandler) ExportCheckpoint(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid checkpoint ID"})
+		return
+	}
+
+	checkpoint, err := h.chainService.GetCheckpoint(c.Request.Context(), id)
+	if err != nil {
+		writeChainError(c, err, "failed to fetch chain checkpoint")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("checkpoint-%s.json", checkpoint.ID)))
+	c.JSON(http.StatusOK, checkpoint)
+}
+
+func writeChainError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrChainCheckpointNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "chain checkpoint not found"})
+	case errors.Is(err, models.ErrChainSigningKeyMissing):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
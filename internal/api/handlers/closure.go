@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ClosureHandler struct {
+	closureService *service.ClosureService
+}
+
+func NewClosureHandler(closureService *service.ClosureService) *ClosureHandler {
+	return &ClosureHandler{closureService: closureService}
+}
+
+// CloseAccount closes an empty account at once. An account with a balance is
+// closed when the CLOSURE transaction paying it out is posted, so the response
+// is 202 Accepted with that transaction.
+func (h *ClosureHandler) CloseAccount(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var request models.AccountClosureRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	tx, err := h.closureService.Close(c.Request.Context(), accountID, version, request, c.GetHeader(OperatorIDHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrAccountNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+		case errors.Is(err, models.ErrStaleVersion):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "account was modified since it was read"})
+		case errors.Is(err, models.ErrInvalidClosure):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrAccountClosed), errors.Is(err, models.ErrPendingTransactions),
+			errors.Is(err, models.ErrAccountOverdrawn), errors.Is(err, models.ErrAccountFrozen),
+			errors.Is(err, models.ErrInvalidStatusTransition), errors.Is(err, models.ErrAccountNotEmpty):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to close account"})
+		}
+		return
+	}
+
+	if tx == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "account closed"})
+		return
+	}
+	c.JSON(http.StatusAccepted, tx)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FeeHandler struct {
+	feeService *service.FeeService
+}
+
+func NewFeeHandler(feeService *service.FeeService) *FeeHandler {
+	return &FeeHandler{feeService: feeService}
+}
+
+func (h *FeeHandler) CreateFeeRule(c *gin.Context) {
+	var request models.FeeRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	rule, err := h.feeService.CreateRule(c.Request.Context(), request)
+	if err != nil {
+		writeFeeError(c, err, "failed to create fee rule")
+		return
+	}
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *FeeHandler) GetFeeRules(c *gin.Context) {
+	rules, err := h.feeService.GetRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch fee rules"})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *FeeHandler) GetFeeRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee rule ID"})
+		return
+	}
+
+	rule, err := h.feeService.GetRule(c.Request.Context(), id)
+	if err != nil {
+		writeFeeError(c, err, "failed to fetch fee rule")
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *FeeHandler) UpdateFeeRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee rule ID"})
+		return
+	}
+
+	var request models.FeeRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	rule, err := h.feeService.UpdateRule(c.Request.Context(), id, request)
+	if err != nil {
+		writeFeeError(c, err, "failed to update fee rule")
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *FeeHandler) DeactivateFeeRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee rule ID"})
+		return
+	}
+
+	rule, err := h.feeService.DeactivateRule(c.Request.Context(), id)
+	if err != nil {
+		writeFeeError(c, err, "failed to deactivate fee rule")
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+func writeFeeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrFeeRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "fee rule not found"})
+	case errors.Is(err, models.ErrInvalidFeeRule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type HoldHandler struct {
+	holdService *service.HoldService
+}
+
+func NewHoldHandler(holdService *service.HoldService) *HoldHandler {
+	return &HoldHandler{holdService: holdService}
+}
+
+func (h *HoldHandler) CaptureHold(c *gin.Context) {
+	var request models.HoldCapture
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	capture, err := h.holdService.Capture(c.Request.Context(), c.Param("id"), request.Amount)
+	if err != nil {
+		writeHoldError(c, err, "failed to capture hold")
+		return
+	}
+	c.JSON(http.StatusCreated, capture)
+}
+
+func (h *HoldHandler) VoidHold(c *gin.Context) {
+	hold, err := h.holdService.Void(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeHoldError(c, err, "failed to void hold")
+		return
+	}
+	c.JSON(http.StatusOK, hold)
+}
+
+func (h *HoldHandler) GetAccountHolds(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	holds, err := h.holdService.GetActiveHolds(c.Request.Context(), accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch holds"})
+		return
+	}
+	c.JSON(http.StatusOK, holds)
+}
+
+func writeHoldError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrHoldNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "no hold for this authorization"})
+	case errors.Is(err, models.ErrHoldNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidCaptureAmount):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
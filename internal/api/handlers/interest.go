@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InterestHandler struct {
+	interestService *service.InterestService
+}
+
+func NewInterestHandler(interestService *service.InterestService) *InterestHandler {
+	return &InterestHandler{interestService: interestService}
+}
+
+func (h *InterestHandler) GetAccountInterest(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	status, err := h.interestService.GetStatus(c.Request.Context(), accountID)
+	if err != nil {
+		writeInterestError(c, err, "failed to fetch interest")
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *InterestHandler) SetAccountInterest(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	var request models.InterestConfigUpdate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	config, err := h.interestService.SetAccountConfig(c.Request.Context(), accountID, request)
+	if err != nil {
+		writeInterestError(c, err, "failed to set interest")
+		return
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+func (h *InterestHandler) ClearAccountInterest(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	if err := h.interestService.ClearAccountConfig(c.Request.Context(), accountID); err != nil {
+		writeInterestError(c, err, "failed to clear interest")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "account interest cleared, product interest applies"})
+}
+
+func (h *InterestHandler) GetProductInterest(c *gin.Context) {
+	config, err := h.interestService.GetProductConfig(c.Request.Context(), c.Param("product"))
+	if err != nil {
+		writeInterestError(c, err, "failed to fetch interest")
+		return
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+func (h *InterestHandler) SetProductInterest(c *gin.Context) {
+	var request models.InterestConfigUpdate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	config, err := h.interestService.SetProductConfig(c.Request.Context(), c.Param("product"), request)
+	if err != nil {
+		writeInterestError(c, err, "failed to set interest")
+		return
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+// AccrueInterest accrues interest for a past day, such as one the scheduled
+// job missed. Days already accrued are left as they are.
+func (h *InterestHandler) AccrueInterest(c *gin.Context) {
+	var request struct {
+		Date string `json:"date" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	day, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be formatted as YYYY-MM-DD"})
+		return
+	}
+	if !day.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only days that have ended can be accrued"})
+		return
+	}
+
+	accrued, err := h.interestService.AccrueDay(c.Request.Context(), day)
+	if errors.Is(err, models.ErrBusinessDayNotClosed) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to accrue interest"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"date": request.Date, "accrued": accrued})
+}
+
+func writeInterestError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrInterestConfigNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidInterestConfig), errors.Is(err, models.ErrInvalidProduct):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
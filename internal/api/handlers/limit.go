@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LimitHandler struct {
+	limitService *service.LimitService
+}
+
+func NewLimitHandler(limitService *service.LimitService) *LimitHandler {
+	return &LimitHandler{limitService: limitService}
+}
+
+func (h *LimitHandler) GetAccountLimits(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	status, err := h.limitService.GetStatus(c.Request.Context(), accountID)
+	if err != nil {
+		writeLimitError(c, err, "failed to fetch limits")
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *LimitHandler) SetAccountLimits(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	var request models.TransactionLimits
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	limits, err := h.limitService.SetAccountLimits(c.Request.Context(), accountID, request)
+	if err != nil {
+		writeLimitError(c, err, "failed to set limits")
+		return
+	}
+	c.JSON(http.StatusOK, limits)
+}
+
+func (h *LimitHandler) ClearAccountLimits(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	if err := h.limitService.ClearAccountLimits(c.Request.Context(), accountID); err != nil {
+		writeLimitError(c, err, "failed to clear limits")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "account limits cleared, tier limits apply"})
+}
+
+func (h *LimitHandler) GetTierLimits(c *gin.Context) {
+	limits, err := h.limitService.GetTierLimits(c.Request.Context(), models.AccountTier(c.Param("tier")))
+	if err != nil {
+		writeLimitError(c, err, "failed to fetch limits")
+		return
+	}
+	c.JSON(http.StatusOK, limits)
+}
+
+func (h *LimitHandler) SetTierLimits(c *gin.Context) {
+	var request models.TransactionLimits
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	limits, err := h.limitService.SetTierLimits(c.Request.Context(), models.AccountTier(c.Param("tier")), request)
+	if err != nil {
+		writeLimitError(c, err, "failed to set limits")
+		return
+	}
+	c.JSON(http.StatusOK, limits)
+}
+
+func writeLimitError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrLimitsNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "the account has no limits of its own"})
+	case errors.Is(err, models.ErrInvalidLimits):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// writeLimitExceeded rejects a transaction over one of its account's limits,
+// naming the limit in the response's code.
+func writeLimitExceeded(c *gin.Context, err *models.LimitExceededError) {
+	status := http.StatusUnprocessableEntity
+	if err.Code == models.LimitVelocity {
+		status = http.StatusTooManyRequests
+	}
+	c.JSON(status, gin.H{"error": err.Error(), "code": err.Code})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OverdraftHandler struct {
+	overdraftService *service.OverdraftService
+}
+
+func NewOverdraftHandler(overdraftService *service.OverdraftService) *OverdraftHandler {
+	return &OverdraftHandler{overdraftService: overdraftService}
+}
+
+func (h *OverdraftHandler) SetOverdraftLimit(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	var request models.OverdraftLimitUpdate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	change, err := h.overdraftService.SetLimit(c.Request.Context(), accountID, request, c.GetHeader(OperatorIDHeader))
+	if err != nil {
+		writeOverdraftError(c, err, "failed to set overdraft limit")
+		return
+	}
+	c.JSON(http.StatusOK, change)
+}
+
+func (h *OverdraftHandler) GetOverdraftLimitChanges(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	changes, err := h.overdraftService.GetLimitChanges(c.Request.Context(), accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch overdraft limit changes"})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+func (h *OverdraftHandler) GetOverdraftUsage(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	usage, err := h.overdraftService.GetUsage(c.Request.Context(), accountID)
+	if err != nil {
+		writeOverdraftError(c, err, "failed to fetch overdraft usage")
+		return
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
+func writeOverdraftError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrInvalidOverdraftLimit):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService *service.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService *service.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{reconciliationService: reconciliationService}
+}
+
+type reconcileRequest struct {
+	OpenCases bool `json:"openCases"`
+}
+
+// Reconcile runs a reconciliation now and returns its report. Accounts out of
+// balance are corrected, or with openCases set, get an investigation case
+// instead.
+func (h *ReconciliationHandler) Reconcile(c *gin.Context) {
+	var request reconcileRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	report, err := h.reconciliationService.Reconcile(c.Request.Context(), request.OpenCases, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reconcile accounts"})
+		return
+	}
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *ReconciliationHandler) GetReports(c *gin.Context) {
+	reports, err := h.reconciliationService.GetReports(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch reconciliation reports"})
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+func (h *ReconciliationHandler) GetReport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		return
+	}
+
+	report, err := h.reconciliationService.GetReport(c.Request.Context(), id)
+	if err != nil {
+		writeReconciliationError(c, err, "failed to fetch reconciliation report")
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetCases lists investigation cases, only those with the given status if
+// there is one.
+func (h *ReconciliationHandler) GetCases(c *gin.Context) {
+	cases, err := h.reconciliationService.GetCases(c.Request.Context(), models.CaseStatus(c.Query("status")))
+	if err != nil {
+		writeReconciliationError(c, err, "failed to fetch investigation cases")
+		return
+	}
+	c.JSON(http.StatusOK, cases)
+}
+
+func (h *ReconciliationHandler) GetCase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid case ID"})
+		return
+	}
+
+	investigation, err := h.reconciliationService.GetCase(c.Request.Context(), id)
+	if err != nil {
+		writeReconciliationError(c, err, "failed to fetch investigation case")
+		return
+	}
+	c.JSON(http.StatusOK, investigation)
+}
+
+func (h *ReconciliationHandler) ResolveCase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid case ID"})
+		return
+	}
+
+	var request models.CaseResolution
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	investigation, err := h.reconciliationService.ResolveCase(c.Request.Context(), id, request, time.Now())
+	if err != nil {
+		writeReconciliationError(c, err, "failed to resolve investigation case")
+		return
+	}
+	c.JSON(http.StatusOK, investigation)
+}
+
+func writeReconciliationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrReconciliationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "reconciliation report not found"})
+	case errors.Is(err, models.ErrCaseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "investigation case not found"})
+	case errors.Is(err, models.ErrInvalidCaseResolution):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrCaseResolved):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StandingOrderHandler struct {
+	standingOrderService *service.StandingOrderService
+}
+
+func NewStandingOrderHandler(standingOrderService *service.StandingOrderService) *StandingOrderHandler {
+	return &StandingOrderHandler{standingOrderService: standingOrderService}
+}
+
+func (h *StandingOrderHandler) CreateStandingOrder(c *gin.Context) {
+	var request models.StandingOrderCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	order, err := h.standingOrderService.Create(c.Request.Context(), request)
+	if err != nil {
+		writeStandingOrderError(c, err, "failed to create standing order")
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+func (h *StandingOrderHandler) GetStandingOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid standing order ID"})
+		return
+	}
+
+	order, err := h.standingOrderService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		writeStandingOrderError(c, err, "failed to fetch standing order")
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *StandingOrderHandler) GetAccountStandingOrders(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	orders, err := h.standingOrderService.GetByAccountID(c.Request.Context(), accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch standing orders"})
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+func (h *StandingOrderHandler) UpdateStandingOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid standing order ID"})
+		return
+	}
+
+	var update models.StandingOrderUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	if update.Amount == nil && update.EndAt == nil && update.MaxRuns == nil && update.Status == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one valid field must be provided"})
+		return
+	}
+
+	order, err := h.standingOrderService.Update(c.Request.Context(), id, update)
+	if err != nil {
+		writeStandingOrderError(c, err, "failed to update standing order")
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *StandingOrderHandler) CancelStandingOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid standing order ID"})
+		return
+	}
+
+	order, err := h.standingOrderService.Cancel(c.Request.Context(), id)
+	if err != nil {
+		writeStandingOrderError(c, err, "failed to cancel standing order")
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *StandingOrderHandler) GetStandingOrderRuns(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid standing order ID"})
+		return
+	}
+
+	runs, err := h.standingOrderService.GetRuns(c.Request.Context(), id)
+	if err != nil {
+		writeStandingOrderError(c, err, "failed to fetch standing order runs")
+		return
+	}
+	c.JSON(http.StatusOK, runs)
+}
+
+func writeStandingOrderError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrStandingOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "standing order not found"})
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrInvalidStandingOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrStandingOrderEnded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAccountFrozen), errors.Is(err, models.ErrAccountClosed):
+		writeAccountStatusError(c, err)
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
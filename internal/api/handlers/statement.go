@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StatementHandler struct {
+	statementService *service.StatementService
+}
+
+func NewStatementHandler(statementService *service.StatementService) *StatementHandler {
+	return &StatementHandler{statementService: statementService}
+}
+
+type generateStatementRequest struct {
+	Period string `json:"period" binding:"required"`
+}
+
+func (h *StatementHandler) GetStatements(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	statements, err := h.statementService.GetStatements(c.Request.Context(), accountID)
+	if err != nil {
+		writeStatementError(c, err, "failed to fetch statements")
+		return
+	}
+	c.JSON(http.StatusOK, statements)
+}
+
+// GenerateStatement returns the account's statement for the month in period,
+// given as YYYY-MM, generating it if it has not been generated yet.
+func (h *StatementHandler) GenerateStatement(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+
+	var request generateStatementRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period is required"})
+		return
+	}
+
+	statement, err := h.statementService.Generate(c.Request.Context(), accountID, request.Period, time.Now())
+	if err != nil {
+		writeStatementError(c, err, "failed to generate statement")
+		return
+	}
+	c.JSON(http.StatusOK, statement)
+}
+
+// DownloadStatement serves the statement as it was rendered when generated,
+// as JSON or, with format=csv, as CSV.
+func (h *StatementHandler) DownloadStatement(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account ID"})
+		return
+	}
+	statementID, err := uuid.Parse(c.Param("statementID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement ID"})
+		return
+	}
+	format, err := models.ParseStatementFormat(c.DefaultQuery("format", string(models.StatementJSON)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statement, document, err := h.statementService.GetDocument(c.Request.Context(), accountID, statementID, format)
+	if err != nil {
+		writeStatementError(c, err, "failed to fetch statement")
+		return
+	}
+	filename := fmt.Sprintf("statement-%s-%s.%s", statement.AccountID, statement.Period, format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("ETag", fmt.Sprintf("%q", document.Checksum))
+	c.Data(http.StatusOK, format.ContentType(), document.Content)
+}
+
+func writeStatementError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	case errors.Is(err, models.ErrStatementNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "statement not found"})
+	case errors.Is(err, models.ErrInvalidStatementPeriod):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
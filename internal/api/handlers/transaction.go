@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -10,6 +12,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// OperatorIDHeader identifies the back-office operator making a request, for
+// actions that must record who made them.
+const OperatorIDHeader = "X-Operator-ID"
+
 type TransactionHandler struct {
 	transactionService *service.TransactionService
 	accountService     *service.AccountService
@@ -37,6 +43,32 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
+	newTransaction.FX = nil
+	newTransaction.ReversalOf = ""
+	newTransaction.ReversedBy = ""
+	newTransaction.ProcessedAt = time.Time{}
+	newTransaction.HoldID = ""
+	newTransaction.Closure = nil
+	newTransaction.Interest = nil
+	newTransaction.Fee = nil
+	newTransaction.FeeTransactionID = ""
+
+	if newTransaction.Type == models.REVERSAL {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reversals are created with POST /transaction/:id/reverse"})
+		return
+	}
+	if newTransaction.Type == models.CAPTURE {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "captures are created with POST /transaction/:id/capture"})
+		return
+	}
+	if newTransaction.Type == models.CLOSURE {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "closures are created with POST /account/:id/close"})
+		return
+	}
+	if newTransaction.Type == models.FEE {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fees are charged by the fee rules"})
+		return
+	}
 
 	if newTransaction.Amount <= 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "the amount should be greater than 0 "})
@@ -55,22 +87,163 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	if newTransaction.Type == models.WITHDRAWL && account.Balance < newTransaction.Amount {
+	if err := account.CheckTransaction(&newTransaction); err != nil {
+		writeAccountStatusError(c, err)
+		return
+	}
+
+	if newTransaction.Currency == "" {
+		newTransaction.Currency = account.Currency
+	} else if newTransaction.Currency, err = models.ParseCurrency(string(newTransaction.Currency)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid currency code"})
+		return
+	}
+	if newTransaction.Currency != account.Currency {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "transaction currency does not match account currency"})
+		return
+	}
+	if !newTransaction.Amount.HasPrecision(newTransaction.Currency.Decimals()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the amount has more decimal places than the currency allows"})
+		return
+	}
+
+	if newTransaction.Type == models.TRANSFER {
+		destinationUUID, err := uuid.Parse(newTransaction.DestinationAccountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid destination account ID format"})
+			return
+		}
+		if destinationUUID == accountUUID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "source and destination accounts must differ"})
+			return
+		}
+		destination, err := h.accountService.GetByID(c.Request.Context(), destinationUUID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "destination account not found"})
+			return
+		}
+		if err := destination.CheckIncoming(); err != nil {
+			writeAccountStatusError(c, fmt.Errorf("destination %w", err))
+			return
+		}
+		newTransaction.DestinationAccountID = destinationUUID.String()
+	} else if newTransaction.DestinationAccountID != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "destination account is only allowed for transfers"})
+		return
+	}
+
+	if newTransaction.Type == models.ADJUSTMENT {
+		if newTransaction.Adjustment == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "adjustment details are required"})
+			return
+		}
+		newTransaction.Adjustment.OperatorID = c.GetHeader(OperatorIDHeader)
+		if err := newTransaction.Adjustment.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else if newTransaction.Adjustment != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "adjustment details are only allowed for adjustments"})
+		return
+	}
+
+	if newTransaction.Type == models.AUTHORIZATION {
+		if expiresAt := newTransaction.HoldExpiresAt; expiresAt != nil {
+			if now := time.Now(); !expiresAt.After(now) || expiresAt.Sub(now) > models.MaxHoldDuration {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "the hold must expire in the future and within 30 days"})
+				return
+			}
+		}
+	} else if newTransaction.HoldExpiresAt != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a hold expiry is only allowed for authorizations"})
+		return
+	}
+
+	if err := newTransaction.ValidateSchedule(time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The funds of a scheduled transaction are checked when it runs.
+	if !newTransaction.IsScheduled() && isDebit(&newTransaction) && account.AvailableBalance < newTransaction.Amount {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "insufficient funds"})
 		return
 	}
+
 	h.initializeTransaction(&newTransaction, accountUUID)
 
 	if err := h.transactionService.Create(c.Request.Context(), &newTransaction); err != nil {
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			writeLimitExceeded(c, limitErr)
+			return
+		}
+		if errors.Is(err, service.ErrConversionFailed) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create transaction"})
 		return
 	}
+	c.JSON(http.StatusCreated, newTransaction)
+}
 
-	if err := h.transactionService.PublishTransactionEvent(c.Request.Context(), &newTransaction); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish transaction event"})
+func (h *TransactionHandler) ReverseTransaction(c *gin.Context) {
+	reversal, err := h.transactionService.Reverse(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrTransactionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+		case errors.Is(err, service.ErrAlreadyReversed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrTransactionNotReversible):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reverse transaction"})
+		}
 		return
 	}
-	c.JSON(http.StatusCreated, newTransaction)
+
+	c.JSON(http.StatusCreated, reversal)
+}
+
+// CancelScheduledTransaction cancels a scheduled transaction that has not run
+// yet.
+func (h *TransactionHandler) CancelScheduledTransaction(c *gin.Context) {
+	tx, err := h.transactionService.CancelScheduled(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrTransactionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
+		case errors.Is(err, models.ErrTransactionNotScheduled):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel transaction"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tx)
+}
+
+func (h *TransactionHandler) GetScheduledTransactions(c *gin.Context) {
+	transactions, err := h.transactionService.GetScheduled(c.Request.Context(), c.Param("accountID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve scheduled transactions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+func isDebit(transaction *models.Transaction) bool {
+	switch transaction.Type {
+	case models.WITHDRAWL, models.TRANSFER, models.AUTHORIZATION:
+		return true
+	case models.ADJUSTMENT:
+		return transaction.Adjustment.Direction == models.DEBIT
+	}
+	return false
 }
 
 func (h *TransactionHandler) initializeTransaction(transaction *models.Transaction, accountUUID uuid.UUID) {
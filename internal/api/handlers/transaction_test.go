@@ -21,14 +21,14 @@ func TestTransactionService_Create(t *testing.T) {
 	validTx := &models.Transaction{
 		AccountID: validAccountID.String(),
 		Type:      models.DEPOSIT,
-		Amount:    100.0,
+		Amount:    models.NewMoney(100),
 	}
 
 	t.Run("Invalid Account ID", func(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		invalidTx := &models.Transaction{AccountID: "invalid"}
 		err := service.Create(context.Background(), invalidTx)
@@ -40,7 +40,7 @@ func TestTransactionService_Create(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		mockAccRepo.On("GetByID", mock.Anything, validAccountID).
 			Return(models.Account{}, assert.AnError)
@@ -55,7 +55,7 @@ func TestTransactionService_Create(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		mockAccRepo.On("GetByID", mock.Anything, validAccountID).
 			Return(models.Account{}, nil)
@@ -71,18 +71,17 @@ func TestTransactionService_Create(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		mockAccRepo.On("GetByID", mock.Anything, validAccountID).
 			Return(models.Account{ID: validAccountID}, nil)
 		mockTxRepo.On("Create", mock.Anything, validTx).
 			Return(nil)
-		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).
-			Return(nil)
 
 		err := service.Create(context.Background(), validTx)
 		assert.NoError(t, err)
-		mockPublisher.AssertExpectations(t)
+		mockTxRepo.AssertExpectations(t)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
 func TestTransactionService_GetByID(t *testing.T) {
@@ -95,6 +94,8 @@ func TestTransactionService_GetByID(t *testing.T) {
 			mockTxRepo,
 			new(repo.MockAccountRepository),
 			new(queue_mocks.MockPublisher),
+			new(repo.MockFXRateRepository),
+			withoutLimits(mockTxRepo),
 		)
 
 		
@@ -112,6 +113,8 @@ func TestTransactionService_GetByID(t *testing.T) {
 			mockTxRepo,
 			new(repo.MockAccountRepository),
 			new(queue_mocks.MockPublisher),
+			new(repo.MockFXRateRepository),
+			withoutLimits(mockTxRepo),
 		)
 
 		mockTxRepo.On("GetByID", mock.Anything, txID).
@@ -136,7 +139,7 @@ func TestTransactionService_GetByAccountID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 		mockTxRepo.On("GetByAccountID", ctx, accountID).Return(expectedTxs, nil)
 
 		txs, err := service.GetByAccountID(ctx, accountID)
@@ -147,7 +150,7 @@ func TestTransactionService_GetByAccountID(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 		mockTxRepo.On("GetByAccountID", ctx, accountID).Return([]models.Transaction{}, assert.AnError)
 
 		txs, err := service.GetByAccountID(ctx, accountID)
@@ -160,7 +163,7 @@ func TestTransactionService_PublishTransactionEvent(t *testing.T) {
 	tx := &models.Transaction{
 		ID:        uuid.New().String(),
 		AccountID: uuid.New().String(),
-		Amount:    100.0,
+		Amount:    models.NewMoney(100),
 		CreatedAt: time.Now(),
 	}
 
@@ -169,7 +172,7 @@ func TestTransactionService_PublishTransactionEvent(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(nil)
 
@@ -183,7 +186,7 @@ func TestTransactionService_PublishTransactionEvent(t *testing.T) {
 		mockTxRepo := new(repo.MockTransactionRepository)
 		mockAccRepo := new(repo.MockAccountRepository)
 		mockPublisher := new(queue_mocks.MockPublisher)
-		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher)
+		service := service.NewTransactionService(mockTxRepo, mockAccRepo, mockPublisher, new(repo.MockFXRateRepository), withoutLimits(mockTxRepo))
 
 		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(assert.AnError)
 
@@ -192,3 +195,12 @@ func TestTransactionService_PublishTransactionEvent(t *testing.T) {
 		mockPublisher.AssertExpectations(t)
 	})
 }
+
+// withoutLimits returns a limit service with the default tier limits and no
+// usage, which the transactions in these tests stay well within.
+func withoutLimits(mockTxRepo *repo.MockTransactionRepository) *service.LimitService {
+	mockLimitRepo := new(repo.MockLimitRepository)
+	mockLimitRepo.On("Get", mock.Anything, mock.Anything).Return(models.TransactionLimits{}, models.ErrLimitsNotFound)
+	mockTxRepo.On("GetLimitUsage", mock.Anything, mock.Anything, mock.Anything).Return(models.LimitUsage{}, nil).Maybe()
+	return service.NewLimitService(mockLimitRepo, mockTxRepo, nil)
+}
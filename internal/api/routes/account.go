@@ -5,11 +5,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AccountRoutes(r *gin.Engine, accountHandler *handlers.AccountHandler, transactionHandler *handlers.TransactionHandler) {
+func AccountRoutes(r *gin.Engine, accountHandler *handlers.AccountHandler, transactionHandler *handlers.TransactionHandler, ledgerHandler *handlers.LedgerHandler) {
 	r.GET("/account", accountHandler.GetAccounts)
 	r.GET("/account/:id", accountHandler.GetAccountByID)
 	r.POST("/account", accountHandler.CreateAccount)
 	r.PATCH("/account/:id", accountHandler.UpdateAccount)
-	r.DELETE("/account/:id", accountHandler.DeleteAccount)
 	r.GET("/accounts/:accountID/transactions", transactionHandler.GetTransactionHistory)
+	r.GET("/accounts/:accountID/scheduled", transactionHandler.GetScheduledTransactions)
+	r.GET("/accounts/:accountID/ledger", ledgerHandler.GetAccountLedger)
 }
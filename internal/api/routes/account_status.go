@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func AccountStatusRoutes(r *gin.Engine, statusHandler *handlers.AccountStatusHandler) {
+	r.PUT("/admin/accounts/:id/status", statusHandler.ChangeStatus)
+	r.GET("/admin/accounts/:id/status/changes", statusHandler.GetStatusChanges)
+}
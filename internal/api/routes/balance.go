@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func BalanceRoutes(r *gin.Engine, balanceHandler *handlers.BalanceHandler) {
+	r.GET("/accounts/:accountID/balance", balanceHandler.GetBalance)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func BusinessDayRoutes(r *gin.Engine, businessDayHandler *handlers.BusinessDayHandler) {
+	r.GET("/accounts/:accountID/snapshots", businessDayHandler.GetSnapshots)
+	r.GET("/admin/business-days", businessDayHandler.GetBusinessDays)
+	r.GET("/admin/business-days/:date", businessDayHandler.GetBusinessDay)
+	r.POST("/admin/business-days/:date/close", businessDayHandler.CloseBusinessDay)
+}
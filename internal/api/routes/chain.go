@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func ChainRoutes(r *gin.Engine, chainHandler *handlers.ChainHandler) {
+	r.GET("/accounts/:accountID/chain/verify", chainHandler.VerifyAccount)
+	r.GET("/admin/chain/verify", chainHandler.VerifyAll)
+	r.POST("/admin/chain/checkpoints", chainHandler.Checkpoint)
+	r.GET("/admin/chain/checkpoints", chainHandler.GetCheckpoints)
+	r.GET("/admin/chain/checkpoints/:id", chainHandler.ExportCheckpoint)
+}
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func ClosureRoutes(r *gin.Engine, closureHandler *handlers.ClosureHandler) {
+	r.POST("/account/:id/close", closureHandler.CloseAccount)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func FeeRoutes(r *gin.Engine, feeHandler *handlers.FeeHandler) {
+	r.POST("/admin/fee-rules", feeHandler.CreateFeeRule)
+	r.GET("/admin/fee-rules", feeHandler.GetFeeRules)
+	r.GET("/admin/fee-rules/:id", feeHandler.GetFeeRule)
+	r.PUT("/admin/fee-rules/:id", feeHandler.UpdateFeeRule)
+	r.DELETE("/admin/fee-rules/:id", feeHandler.DeactivateFeeRule)
+}
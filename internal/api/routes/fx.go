@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func FXRoutes(r *gin.Engine, fxHandler *handlers.FXHandler) {
+	r.GET("/admin/fx-rates", fxHandler.GetRates)
+	r.POST("/admin/fx-rates", fxHandler.CreateRate)
+}
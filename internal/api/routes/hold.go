@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func HoldRoutes(r *gin.Engine, holdHandler *handlers.HoldHandler, idempotency gin.HandlerFunc) {
+	r.POST("/transaction/:id/capture", idempotency, holdHandler.CaptureHold)
+	r.POST("/transaction/:id/void", idempotency, holdHandler.VoidHold)
+	r.GET("/accounts/:accountID/holds", holdHandler.GetAccountHolds)
+}
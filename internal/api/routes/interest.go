@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func InterestRoutes(r *gin.Engine, interestHandler *handlers.InterestHandler) {
+	r.GET("/accounts/:accountID/interest", interestHandler.GetAccountInterest)
+	r.PUT("/admin/accounts/:id/interest", interestHandler.SetAccountInterest)
+	r.DELETE("/admin/accounts/:id/interest", interestHandler.ClearAccountInterest)
+	r.GET("/admin/products/:product/interest", interestHandler.GetProductInterest)
+	r.PUT("/admin/products/:product/interest", interestHandler.SetProductInterest)
+	r.POST("/admin/interest/accrue", interestHandler.AccrueInterest)
+}
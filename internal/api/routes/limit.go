@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func LimitRoutes(r *gin.Engine, limitHandler *handlers.LimitHandler) {
+	r.GET("/accounts/:accountID/limits", limitHandler.GetAccountLimits)
+	r.PUT("/admin/accounts/:id/limits", limitHandler.SetAccountLimits)
+	r.DELETE("/admin/accounts/:id/limits", limitHandler.ClearAccountLimits)
+	r.GET("/admin/tiers/:tier/limits", limitHandler.GetTierLimits)
+	r.PUT("/admin/tiers/:tier/limits", limitHandler.SetTierLimits)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func OverdraftRoutes(r *gin.Engine, overdraftHandler *handlers.OverdraftHandler) {
+	r.PUT("/admin/accounts/:id/overdraft", overdraftHandler.SetOverdraftLimit)
+	r.GET("/admin/accounts/:id/overdraft/changes", overdraftHandler.GetOverdraftLimitChanges)
+	r.GET("/accounts/:accountID/overdraft", overdraftHandler.GetOverdraftUsage)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func ReconciliationRoutes(r *gin.Engine, reconciliationHandler *handlers.ReconciliationHandler) {
+	r.POST("/admin/reconciliations", reconciliationHandler.Reconcile)
+	r.GET("/admin/reconciliations", reconciliationHandler.GetReports)
+	r.GET("/admin/reconciliations/:id", reconciliationHandler.GetReport)
+	r.GET("/admin/investigation-cases", reconciliationHandler.GetCases)
+	r.GET("/admin/investigation-cases/:id", reconciliationHandler.GetCase)
+	r.POST("/admin/investigation-cases/:id/resolve", reconciliationHandler.ResolveCase)
+}
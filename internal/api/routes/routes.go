@@ -6,9 +6,24 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(r *gin.Engine, accountService *service.AccountService, transactionService *service.TransactionService) {
+func Setup(r *gin.Engine, accountService *service.AccountService, transactionService *service.TransactionService, ledgerService *service.LedgerService, fxService *service.FXService, holdService *service.HoldService, overdraftService *service.OverdraftService, limitService *service.LimitService, statusService *service.AccountStatusService, closureService *service.ClosureService, standingOrderService *service.StandingOrderService, interestService *service.InterestService, feeService *service.FeeService, balanceService *service.BalanceService, statementService *service.StatementService, businessDayService *service.BusinessDayService, reconciliationService *service.ReconciliationService, chainService *service.ChainService, idempotency gin.HandlerFunc) {
 	accountHandler := handlers.NewAccountHandler(accountService)
 	transactionHandler := handlers.NewTransactionHandler(transactionService, accountService)
-	AccountRoutes(r, accountHandler, transactionHandler)
-	TransactionRoutes(r, transactionHandler)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	AccountRoutes(r, accountHandler, transactionHandler, ledgerHandler)
+	TransactionRoutes(r, transactionHandler, idempotency)
+	FXRoutes(r, handlers.NewFXHandler(fxService))
+	HoldRoutes(r, handlers.NewHoldHandler(holdService), idempotency)
+	OverdraftRoutes(r, handlers.NewOverdraftHandler(overdraftService))
+	LimitRoutes(r, handlers.NewLimitHandler(limitService))
+	AccountStatusRoutes(r, handlers.NewAccountStatusHandler(statusService))
+	ClosureRoutes(r, handlers.NewClosureHandler(closureService))
+	StandingOrderRoutes(r, handlers.NewStandingOrderHandler(standingOrderService))
+	InterestRoutes(r, handlers.NewInterestHandler(interestService))
+	FeeRoutes(r, handlers.NewFeeHandler(feeService))
+	BalanceRoutes(r, handlers.NewBalanceHandler(balanceService))
+	StatementRoutes(r, handlers.NewStatementHandler(statementService))
+	BusinessDayRoutes(r, handlers.NewBusinessDayHandler(businessDayService))
+	ReconciliationRoutes(r, handlers.NewReconciliationHandler(reconciliationService))
+	ChainRoutes(r, handlers.NewChainHandler(chainService))
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func StandingOrderRoutes(r *gin.Engine, standingOrderHandler *handlers.StandingOrderHandler) {
+	r.POST("/standing-orders", standingOrderHandler.CreateStandingOrder)
+	r.GET("/standing-orders/:id", standingOrderHandler.GetStandingOrder)
+	r.PATCH("/standing-orders/:id", standingOrderHandler.UpdateStandingOrder)
+	r.DELETE("/standing-orders/:id", standingOrderHandler.CancelStandingOrder)
+	r.GET("/standing-orders/:id/runs", standingOrderHandler.GetStandingOrderRuns)
+	r.GET("/accounts/:accountID/standing-orders", standingOrderHandler.GetAccountStandingOrders)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/RajVerma97/golang-banking-ledger/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func StatementRoutes(r *gin.Engine, statementHandler *handlers.StatementHandler) {
+	r.GET("/accounts/:accountID/statements", statementHandler.GetStatements)
+	r.POST("/accounts/:accountID/statements", statementHandler.GenerateStatement)
+	r.GET("/accounts/:accountID/statements/:statementID", statementHandler.DownloadStatement)
+}
@@ -5,8 +5,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func TransactionRoutes(r *gin.Engine, transactionHandler *handlers.TransactionHandler) {
+func TransactionRoutes(r *gin.Engine, transactionHandler *handlers.TransactionHandler, idempotency gin.HandlerFunc) {
 	r.GET("/transaction/:id", transactionHandler.GetTransactionByID)
-	r.POST("/transaction", transactionHandler.CreateTransaction)
+	r.POST("/transaction", idempotency, transactionHandler.CreateTransaction)
+	r.POST("/transaction/:id/reverse", idempotency, transactionHandler.ReverseTransaction)
+	r.POST("/transaction/:id/cancel", transactionHandler.CancelScheduledTransaction)
 
 }
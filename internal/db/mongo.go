@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"time"
 
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -59,5 +62,31 @@ func InitMongo() (*mongo.Database, *mongo.Collection, error) {
 	db := client.Database("banking_ledger")
 	collection := db.Collection("transactions")
 
+	if err := migrateMoneyFields(collection); err != nil {
+		return nil, nil, fmt.Errorf("migration failed: %w", err)
+	}
+
 	return db, collection, nil
 }
+
+// migrateMoneyFields rewrites the floating point amounts of transactions
+// stored before amounts became fixed-point models.Money values, scaling them
+// to match. Amounts summed on the server, such as limit usage, would
+// otherwise mix the two.
+func migrateMoneyFields(collection *mongo.Collection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	scaled := bson.M{"$multiply": bson.A{"$amount", int64(math.Pow10(models.MoneyScale))}}
+	result, err := collection.UpdateMany(ctx,
+		bson.M{"amount": bson.M{"$type": "double"}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"amount": bson.M{"$toLong": bson.M{"$round": bson.A{scaled, 0}}}}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("Converted %d transaction amounts to fixed-point money", result.ModifiedCount)
+	}
+	return nil
+}
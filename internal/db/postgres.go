@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
@@ -31,10 +32,100 @@ func InitPostgres() (*gorm.DB, error) {
 	}
 
 	log.Println("Running Migrations...")
-	if err := db.AutoMigrate(&models.Account{}); err != nil {
+	if err := migrateMoneyColumns(db); err != nil {
+		return nil, fmt.Errorf("migration failed: %w", err)
+	}
+	if err := Migrate(db); err != nil {
+		return nil, fmt.Errorf("migration failed: %w", err)
+	}
+	if err := migrateInterestAccrualKey(db); err != nil {
 		return nil, fmt.Errorf("migration failed: %w", err)
 	}
 
 	log.Println("Connected to PostgreSQL")
 	return db, nil
 }
+
+// moneyColumns were stored as double precision before amounts became
+// fixed-point models.Money values.
+var moneyColumns = []struct{ table, column string }{
+	{"accounts", "balance"},
+	{"postings", "amount"},
+}
+
+// migrateMoneyColumns converts floating point money columns to the bigint
+// representation of models.Money, scaling the stored values to match.
+func migrateMoneyColumns(db *gorm.DB) error {
+	for _, c := range moneyColumns {
+		var dataType string
+		err := db.Raw(
+			"SELECT data_type FROM information_schema.columns WHERE table_name = ? AND column_name = ?",
+			c.table, c.column,
+		).Scan(&dataType).Error
+		if err != nil {
+			return err
+		}
+		if dataType != "double precision" {
+			continue
+		}
+
+		log.Printf("Converting %s.%s to fixed-point money", c.table, c.column)
+		err = db.Exec(fmt.Sprintf(
+			"ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE bigint USING round(%[2]s * %[3]d)::bigint",
+			c.table, c.column, int64(math.Pow10(models.MoneyScale)),
+		)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateInterestAccrualKey adds carried to the primary key of
+// interest_accruals, which was keyed by account and date alone before the
+// remainders of interest postings were carried between periods.
+func migrateInterestAccrualKey(db *gorm.DB) error {
+	var columns int
+	err := db.Raw(
+		`SELECT count(*) FROM information_schema.key_column_usage
+		WHERE table_name = 'interest_accruals' AND constraint_name = 'interest_accruals_pkey'`,
+	).Scan(&columns).Error
+	if err != nil {
+		return err
+	}
+	if columns != 2 {
+		return nil
+	}
+
+	log.Println("Adding carried to the primary key of interest_accruals")
+	return db.Exec(
+		"ALTER TABLE interest_accruals DROP CONSTRAINT interest_accruals_pkey, ADD PRIMARY KEY (account_id, date, carried)",
+	).Error
+}
+
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&models.Account{},
+		&models.Journal{},
+		&models.Posting{},
+		&models.FXRate{},
+		&models.IdempotencyKey{},
+		&models.Hold{},
+		&models.OverdraftLimitChange{},
+		&models.TransactionLimits{},
+		&models.AccountStatusChange{},
+		&models.StandingOrder{},
+		&models.StandingOrderRun{},
+		&models.InterestConfig{},
+		&models.InterestAccrual{},
+		&models.FeeRule{},
+		&models.BalanceSnapshot{},
+		&models.BusinessDay{},
+		&models.Statement{},
+		&models.StatementDocument{},
+		&models.ReconciliationReport{},
+		&models.InvestigationCase{},
+		&models.ChainLink{},
+		&models.ChainCheckpoint{},
+	)
+}
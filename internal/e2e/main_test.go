@@ -159,7 +159,7 @@ func performTransactions(t *testing.T, client *APIClient, accounts []string, txP
 				defer func() { <-sem }()
 
 				txType := models.DEPOSIT
-				amount := 100.0
+				amount := models.NewMoney(100)
 
 				_, err := client.CreateTransaction(t, models.Transaction{
 					AccountID: accID,
@@ -1,34 +1,76 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// ErrStaleVersion is returned when an account is changed by a request that
+// expected an older version of it.
+var (
+	ErrStaleVersion    = errors.New("account version is stale")
+	ErrAccountNotFound = errors.New("account not found")
+)
+
+// Account.Version counts edits made through the API and is sent as the ETag of
+// the account. Balance changes posted by the ledger and overdraft limits set
+// by an admin do not bump it, nor do status changes. OverdraftLimit is how far
+// below zero the balance may go, Tier selects the account's default
+// transaction limits, and Product its default interest terms.
 type Account struct {
-	ID            uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	AccountNumber int       `json:"accountNumber" gorm:"unique;not null"`
-	FirstName     string    `json:"firstName" gorm:"not null"`
-	LastName      string    `json:"lastName"`
-	Email         string    `json:"email" gorm:"unique;not null"`
-	Phone         string    `json:"phone"`
-	Balance       float64   `json:"balance" gorm:"not null;default:0"`
-	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt     time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	ID             uuid.UUID     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AccountNumber  int           `json:"accountNumber" gorm:"unique;not null"`
+	FirstName      string        `json:"firstName" gorm:"not null"`
+	LastName       string        `json:"lastName"`
+	Email          string        `json:"email" gorm:"unique;not null"`
+	Phone          string        `json:"phone"`
+	Balance        Money         `json:"balance" gorm:"not null;default:0"`
+	HeldBalance    Money         `json:"heldBalance" gorm:"not null;default:0"`
+	OverdraftLimit Money         `json:"overdraftLimit" gorm:"not null;default:0"`
+	Currency       Currency      `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Tier           AccountTier   `json:"tier" gorm:"not null;default:'STANDARD'"`
+	Product        string        `json:"product,omitempty" gorm:"not null;default:''"`
+	Status         AccountStatus `json:"status" gorm:"not null;default:'ACTIVE';index"`
+	Version        int64         `json:"version" gorm:"not null;default:1"`
+	CreatedAt      time.Time     `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time     `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+
+	// AvailableBalance is what the account can spend: its ledger balance less
+	// the funds reserved by active holds, plus its overdraft limit.
+	AvailableBalance Money `json:"availableBalance" gorm:"-"`
+}
+
+func (a *Account) AfterFind(tx *gorm.DB) error {
+	a.AvailableBalance = a.Balance - a.HeldBalance + a.OverdraftLimit
+	return nil
+}
+
+// OverdraftUsed is how much of the overdraft limit the account is using,
+// counting funds reserved by holds.
+func (a *Account) OverdraftUsed() Money {
+	if used := a.HeldBalance - a.Balance; used > 0 {
+		return used
+	}
+	return 0
 }
+
 type AccountCreate struct {
-	FirstName string  `json:"firstName" validate:"required"`
-	LastName  string  `json:"lastName,omitempty"`
-	Email     string  `json:"email" validate:"required,email"`
-	Phone     string  `json:"phone,omitempty"`
-	Balance   float64 `json:"balance,omitempty"`
+	FirstName string `json:"firstName" validate:"required"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email" validate:"required,email"`
+	Phone     string `json:"phone,omitempty"`
+	Balance   Money  `json:"balance,omitempty"`
+	Currency  string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Tier      string `json:"tier,omitempty" validate:"omitempty,oneof=STANDARD PREMIUM BUSINESS"`
+	Product   string `json:"product,omitempty"`
 }
 type AccountUpdate struct {
-	FirstName *string  `json:"firstName,omitempty"`
-	LastName  *string  `json:"lastName,omitempty"`
-	Phone     *string  `json:"phone,omitempty"`
-	Balance   *float64 `json:"balance"`
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
 }
 
 type Accounts []Account
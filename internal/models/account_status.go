@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountStatus string
+
+// A DORMANT account has had no activity for a long time; it is flagged for
+// follow-up but still transacts. A FROZEN account can receive money but not
+// pay it out, and a CLOSED account takes part in no transactions at all.
+const (
+	AccountActive  AccountStatus = "ACTIVE"
+	AccountFrozen  AccountStatus = "FROZEN"
+	AccountDormant AccountStatus = "DORMANT"
+	AccountClosed  AccountStatus = "CLOSED"
+)
+
+var (
+	ErrAccountFrozen           = errors.New("account is frozen")
+	ErrAccountClosed           = errors.New("account is closed")
+	ErrAccountNotEmpty         = errors.New("account still holds funds")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	ErrInvalidStatusUpdate     = errors.New("invalid account status update")
+)
+
+// accountStatusTransitions lists the statuses each status may move to.
+// CLOSED is final.
+var accountStatusTransitions = map[AccountStatus][]AccountStatus{
+	AccountActive:  {AccountFrozen, AccountDormant, AccountClosed},
+	AccountFrozen:  {AccountActive, AccountClosed},
+	AccountDormant: {AccountActive, AccountFrozen, AccountClosed},
+	AccountClosed:  {},
+}
+
+func (s AccountStatus) Valid() bool {
+	_, ok := accountStatusTransitions[s]
+	return ok
+}
+
+func (s AccountStatus) CanTransitionTo(next AccountStatus) bool {
+	for _, allowed := range accountStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountStatusChange is the audit record of an account's status changing.
+type AccountStatusChange struct {
+	ID         uuid.UUID     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AccountID  uuid.UUID     `json:"accountID" gorm:"type:uuid;not null;index"`
+	FromStatus AccountStatus `json:"fromStatus" gorm:"not null"`
+	ToStatus   AccountStatus `json:"toStatus" gorm:"not null"`
+	Reason     string        `json:"reason" gorm:"not null"`
+	OperatorID string        `json:"operatorID" gorm:"not null"`
+	CreatedAt  time.Time     `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+type AccountStatusUpdate struct {
+	Status AccountStatus `json:"status" validate:"required,oneof=ACTIVE FROZEN DORMANT"`
+	Reason string        `json:"reason" validate:"required"`
+}
+
+func (u *AccountStatusUpdate) Validate(operatorID string) error {
+	if !u.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusUpdate, u.Status)
+	}
+	if u.Status == AccountClosed {
+		// Closing pays out the balance once nothing is pending, and stops the
+		// account's scheduled transactions and standing orders.
+		return fmt.Errorf("%w: accounts are closed with POST /account/:id/close", ErrInvalidStatusUpdate)
+	}
+	if u.Reason == "" {
+		return fmt.Errorf("%w: a reason is required", ErrInvalidStatusUpdate)
+	}
+	if operatorID == "" {
+		return fmt.Errorf("%w: an operator is required", ErrInvalidStatusUpdate)
+	}
+	return nil
+}
+
+// CheckTransaction returns why the account cannot originate the transaction,
+// or nil if it can.
+func (a *Account) CheckTransaction(tx *Transaction) error {
+	switch a.Status {
+	case AccountClosed:
+		return ErrAccountClosed
+	case AccountFrozen:
+		switch tx.Type {
+		case WITHDRAWL, TRANSFER, AUTHORIZATION, CAPTURE:
+			return ErrAccountFrozen
+		case CLOSURE:
+			// A frozen account's balance may only go to suspense.
+			if tx.Closure != nil && tx.Closure.PayoutAccountID != "" {
+				return ErrAccountFrozen
+			}
+		}
+	}
+	return nil
+}
+
+// CheckIncoming returns why the account cannot receive a transfer, or nil if
+// it can.
+func (a *Account) CheckIncoming() error {
+	if a.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to AccountStatus
+		allowed  bool
+	}{
+		{AccountActive, AccountFrozen, true},
+		{AccountActive, AccountDormant, true},
+		{AccountActive, AccountClosed, true},
+		{AccountFrozen, AccountActive, true},
+		{AccountFrozen, AccountDormant, false},
+		{AccountDormant, AccountActive, true},
+		{AccountDormant, AccountFrozen, true},
+		{AccountClosed, AccountActive, false},
+		{AccountActive, AccountActive, false},
+		{AccountActive, "DELETED", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestAccountCheckTransaction(t *testing.T) {
+	frozen := &Account{Status: AccountFrozen}
+	closed := &Account{Status: AccountClosed}
+	dormant := &Account{Status: AccountDormant}
+
+	for _, txType := range []TransactionType{WITHDRAWL, TRANSFER, AUTHORIZATION, CAPTURE} {
+		assert.ErrorIs(t, frozen.CheckTransaction(&Transaction{Type: txType}), ErrAccountFrozen, txType)
+	}
+	assert.NoError(t, frozen.CheckTransaction(&Transaction{Type: DEPOSIT}))
+	assert.NoError(t, frozen.CheckIncoming())
+
+	assert.ErrorIs(t, closed.CheckTransaction(&Transaction{Type: DEPOSIT}), ErrAccountClosed)
+	assert.ErrorIs(t, closed.CheckIncoming(), ErrAccountClosed)
+
+	assert.NoError(t, dormant.CheckTransaction(&Transaction{Type: WITHDRAWL}))
+}
+
+func TestAccountStatusUpdateValidate(t *testing.T) {
+	update := AccountStatusUpdate{Status: AccountFrozen, Reason: "suspected fraud"}
+	assert.NoError(t, update.Validate("ops-1"))
+	assert.ErrorIs(t, update.Validate(""), ErrInvalidStatusUpdate)
+
+	update.Reason = ""
+	assert.ErrorIs(t, update.Validate("ops-1"), ErrInvalidStatusUpdate)
+
+	update = AccountStatusUpdate{Status: "DELETED", Reason: "typo"}
+	assert.ErrorIs(t, update.Validate("ops-1"), ErrInvalidStatusUpdate)
+
+	update = AccountStatusUpdate{Status: AccountClosed, Reason: "customer request"}
+	assert.ErrorIs(t, update.Validate("ops-1"), ErrInvalidStatusUpdate)
+}
@@ -41,12 +41,31 @@ func TestAccountModelValidation(t *testing.T) {
 				shouldError: true,
 				errMsg:      "Key: 'AccountCreate.Email' Error:Field validation for 'Email' failed on the 'email' tag",
 			},
+			{
+				name: "Valid Currency",
+				input: AccountCreate{
+					FirstName: "John",
+					Email:     "john@example.com",
+					Currency:  "EUR",
+				},
+				shouldError: false,
+			},
+			{
+				name: "Invalid Currency",
+				input: AccountCreate{
+					FirstName: "John",
+					Email:     "john@example.com",
+					Currency:  "XYZ",
+				},
+				shouldError: true,
+				errMsg:      "Key: 'AccountCreate.Currency' Error:Field validation for 'Currency' failed on the 'iso4217' tag",
+			},
 			{
 				name: "Negative Balance",
 				input: AccountCreate{
 					FirstName: "John",
 					Email:     "john@example.com",
-					Balance:   -100.0,
+					Balance:   NewMoney(-100),
 				},
 				shouldError: false,
 			},
@@ -91,13 +110,6 @@ func TestAccountModelValidation(t *testing.T) {
 				},
 				expectFields: []string{"FirstName"},
 			},
-			{
-				name: "Update Balance",
-				input: AccountUpdate{
-					Balance: float64Ptr(1500.0),
-				},
-				expectFields: []string{"Balance"},
-			},
 			{
 				name: "Clear LastName",
 				input: AccountUpdate{
@@ -114,9 +126,6 @@ func TestAccountModelValidation(t *testing.T) {
 					case "FirstName":
 						assert.NotNil(t, tt.input.FirstName)
 						assert.Equal(t, "Jane", *tt.input.FirstName)
-					case "Balance":
-						assert.NotNil(t, tt.input.Balance)
-						assert.Equal(t, 1500.0, *tt.input.Balance)
 					case "LastName":
 						assert.NotNil(t, tt.input.LastName)
 						assert.Equal(t, "", *tt.input.LastName)
@@ -130,7 +139,3 @@ func TestAccountModelValidation(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
-
-func float64Ptr(f float64) *float64 {
-	return &f
-}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AdjustmentsLedgerAccount is the bank's side of manual balance corrections.
+const AdjustmentsLedgerAccount = SystemLedgerAccountPrefix + "adjustments"
+
+type AdjustmentReason string
+
+const (
+	AdjustmentBankError  AdjustmentReason = "BANK_ERROR"
+	AdjustmentFeeRefund  AdjustmentReason = "FEE_REFUND"
+	AdjustmentGoodwill   AdjustmentReason = "GOODWILL"
+	AdjustmentChargeback AdjustmentReason = "CHARGEBACK"
+	AdjustmentWriteOff   AdjustmentReason = "WRITE_OFF"
+	AdjustmentMigration  AdjustmentReason = "MIGRATION"
+
+	// AdjustmentReconciliation corrects an account's balance to what its
+	// history adds up to. Reconciliation leaves these adjustments out of that
+	// history, since they correct it rather than add to it, so only
+	// Discrepancy.Correction sets it and operators cannot submit it.
+	AdjustmentReconciliation AdjustmentReason = "RECONCILIATION"
+)
+
+// adjustmentReasons are the reasons an operator can give for an adjustment.
+var adjustmentReasons = map[AdjustmentReason]bool{
+	AdjustmentBankError:  true,
+	AdjustmentFeeRefund:  true,
+	AdjustmentGoodwill:   true,
+	AdjustmentChargeback: true,
+	AdjustmentWriteOff:   true,
+	AdjustmentMigration:  true,
+}
+
+// MinJustificationLength keeps adjustments from being waved through with a
+// one-word justification.
+const MinJustificationLength = 10
+
+// Adjustment describes a manual correction to an account's balance: which way
+// it moves the balance, why, and which operator made it. A CREDIT adjustment
+// increases the balance and a DEBIT adjustment decreases it.
+type Adjustment struct {
+	Direction     PostingDirection `json:"direction" bson:"direction"`
+	ReasonCode    AdjustmentReason `json:"reasonCode" bson:"reasonCode"`
+	Justification string           `json:"justification" bson:"justification"`
+	OperatorID    string           `json:"operatorID" bson:"operatorID"`
+}
+
+// Validate checks an adjustment an operator submitted.
+func (a *Adjustment) Validate() error {
+	if !adjustmentReasons[a.ReasonCode] {
+		return fmt.Errorf("invalid adjustment reason code: %q", a.ReasonCode)
+	}
+	return a.validate()
+}
+
+// ValidateCorrection checks an adjustment reconciliation posts to correct a
+// balance.
+func (a *Adjustment) ValidateCorrection() error {
+	if a.ReasonCode != AdjustmentReconciliation {
+		return fmt.Errorf("reconciliation corrections must have reason code %s", AdjustmentReconciliation)
+	}
+	if a.OperatorID != ReconciliationOperatorID {
+		return fmt.Errorf("reconciliation corrections must be made by operator %q", ReconciliationOperatorID)
+	}
+	return a.validate()
+}
+
+func (a *Adjustment) validate() error {
+	if a.Direction != CREDIT && a.Direction != DEBIT {
+		return fmt.Errorf("adjustment direction must be %s or %s", CREDIT, DEBIT)
+	}
+	if len(strings.TrimSpace(a.Justification)) < MinJustificationLength {
+		return fmt.Errorf("adjustment justification must be at least %d characters", MinJustificationLength)
+	}
+	if strings.TrimSpace(a.OperatorID) == "" {
+		return errors.New("adjustment operator is required")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustmentValidate(t *testing.T) {
+	valid := func() Adjustment {
+		return Adjustment{
+			Direction:     CREDIT,
+			ReasonCode:    AdjustmentGoodwill,
+			Justification: "compensation for outage",
+			OperatorID:    "ops-17",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(a *Adjustment)
+		errContains string
+	}{
+		{name: "Valid", modify: func(a *Adjustment) {}},
+		{name: "Invalid Direction", modify: func(a *Adjustment) { a.Direction = "UP" }, errContains: "direction"},
+		{name: "Unknown Reason", modify: func(a *Adjustment) { a.ReasonCode = "BECAUSE" }, errContains: "reason code"},
+		{name: "Reconciliation Reason", modify: func(a *Adjustment) { a.ReasonCode = AdjustmentReconciliation }, errContains: "reason code"},
+		{name: "Short Justification", modify: func(a *Adjustment) { a.Justification = " fix  " }, errContains: "justification"},
+		{name: "Missing Operator", modify: func(a *Adjustment) { a.OperatorID = "" }, errContains: "operator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adjustment := valid()
+			tt.modify(&adjustment)
+
+			err := adjustment.Validate()
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
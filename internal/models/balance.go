@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrBalanceSnapshotNotFound = errors.New("balance snapshot not found")
+
+// BalanceSnapshot is an account's ledger balance from every posting made
+// before TakenAt, with the totals of the business day ending then. Snapshots
+// are taken when each business day is closed, at the following UTC midnight,
+// so that a balance at some moment can be worked out from the last snapshot
+// before it rather than from the account's whole history.
+type BalanceSnapshot struct {
+	AccountID uuid.UUID `json:"accountID" gorm:"type:uuid;primaryKey"`
+	TakenAt   time.Time `json:"takenAt" gorm:"primaryKey"`
+	Balance   Money     `json:"balance" gorm:"not null"`
+	Currency  Currency  `json:"currency" gorm:"type:char(3);not null"`
+	DayTotals `gorm:"embedded"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// PointInTimeBalance is an account's balance as of a moment: the balance of
+// the snapshot it started from, or zero without one, plus the postings made
+// from then until just before AsOf. Only successful transactions post to the
+// ledger.
+type PointInTimeBalance struct {
+	AccountID       uuid.UUID        `json:"accountID"`
+	Currency        Currency         `json:"currency"`
+	AsOf            time.Time        `json:"asOf"`
+	Balance         Money            `json:"balance"`
+	Snapshot        *BalanceSnapshot `json:"snapshot"`
+	PostingsApplied int              `json:"postingsApplied"`
+}
+
+// SnapshotTime returns the UTC midnight at or before t, when the latest
+// snapshot before t was due.
+func SnapshotTime(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotTime(t *testing.T) {
+	ist := time.FixedZone("IST", 19800)
+	at := time.Date(2025, time.March, 11, 2, 0, 0, 0, ist)
+
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), SnapshotTime(at))
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), SnapshotTime(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type BusinessDayStatus string
+
+const (
+	// BusinessDayClosing is a close that has started but not finished. Running
+	// the close again picks up where it stopped.
+	BusinessDayClosing BusinessDayStatus = "CLOSING"
+	BusinessDayClosed  BusinessDayStatus = "CLOSED"
+)
+
+var (
+	ErrBusinessDayNotFound  = errors.New("business day not found")
+	ErrInvalidBusinessDay   = errors.New("invalid business day")
+	ErrBusinessDayPending   = errors.New("business day has pending transactions")
+	ErrBusinessDayNotClosed = errors.New("business day is not closed")
+)
+
+// DayTotals sums the successful transactions processed on a business day.
+// Credits and Debits cover every transaction, including deposits and
+// withdrawals.
+type DayTotals struct {
+	Deposits         Money `json:"deposits" gorm:"not null;default:0"`
+	DepositCount     int   `json:"depositCount" gorm:"not null;default:0"`
+	Withdrawals      Money `json:"withdrawals" gorm:"not null;default:0"`
+	WithdrawalCount  int   `json:"withdrawalCount" gorm:"not null;default:0"`
+	Credits          Money `json:"credits" gorm:"not null;default:0"`
+	Debits           Money `json:"debits" gorm:"not null;default:0"`
+	TransactionCount int   `json:"transactionCount" gorm:"not null;default:0"`
+}
+
+// Add counts a transaction towards an account's totals.
+func (t *DayTotals) Add(settled SettledTransaction) {
+	switch settled.Transaction.Type {
+	case DEPOSIT:
+		t.Deposits += settled.Change
+		t.DepositCount++
+	case WITHDRAWL:
+		t.Withdrawals -= settled.Change
+		t.WithdrawalCount++
+	}
+	if settled.Change >= 0 {
+		t.Credits += settled.Change
+	} else {
+		t.Debits -= settled.Change
+	}
+	t.TransactionCount++
+}
+
+// Merge adds another account's totals to these. A transfer between two
+// customers counts once for each of them.
+func (t *DayTotals) Merge(other DayTotals) {
+	t.Deposits += other.Deposits
+	t.DepositCount += other.DepositCount
+	t.Withdrawals += other.Withdrawals
+	t.WithdrawalCount += other.WithdrawalCount
+	t.Credits += other.Credits
+	t.Debits += other.Debits
+	t.TransactionCount += other.TransactionCount
+}
+
+// BusinessDay is a UTC day being or having been closed. Closing a day
+// snapshots the closing balance and totals of every account open on it, and
+// the day's totals are the sum of theirs.
+type BusinessDay struct {
+	Date      time.Time         `json:"date" gorm:"primaryKey"`
+	Status    BusinessDayStatus `json:"status" gorm:"not null"`
+	Accounts  int               `json:"accounts" gorm:"not null;default:0"`
+	DayTotals `gorm:"embedded"`
+	StartedAt time.Time  `json:"startedAt" gorm:"not null"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+}
+
+// BusinessDate returns the business day t falls on.
+func BusinessDate(t time.Time) time.Time {
+	return SnapshotTime(t)
+}
+
+// End is when the day ends, which is when its snapshots are taken.
+func (d *BusinessDay) End() time.Time {
+	return d.Date.AddDate(0, 0, 1)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayTotals(t *testing.T) {
+	var totals DayTotals
+	totals.Add(SettledTransaction{Transaction: &Transaction{Type: DEPOSIT}, Change: NewMoney(100)})
+	totals.Add(SettledTransaction{Transaction: &Transaction{Type: WITHDRAWL}, Change: -NewMoney(30)})
+	totals.Add(SettledTransaction{Transaction: &Transaction{Type: TRANSFER}, Change: -NewMoney(20)})
+
+	assert.Equal(t, DayTotals{
+		Deposits:         NewMoney(100),
+		DepositCount:     1,
+		Withdrawals:      NewMoney(30),
+		WithdrawalCount:  1,
+		Credits:          NewMoney(100),
+		Debits:           NewMoney(50),
+		TransactionCount: 3,
+	}, totals)
+
+	totals.Merge(DayTotals{Credits: NewMoney(20), TransactionCount: 1})
+	assert.Equal(t, NewMoney(120), totals.Credits)
+	assert.Equal(t, 4, totals.TransactionCount)
+}
+
+func TestBusinessDay_End(t *testing.T) {
+	day := BusinessDay{Date: BusinessDate(time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC))}
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), day.Date)
+	assert.Equal(t, time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC), day.End())
+}
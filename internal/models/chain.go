@@ -0,0 +1,207 @@
+package models
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChainLinkNotFound       = errors.New("chain link not found")
+	ErrChainCheckpointNotFound = errors.New("chain checkpoint not found")
+	ErrChainSigningKeyMissing  = errors.New("no chain signing key is configured")
+	ErrInvalidChainSigningKey  = errors.New("chain signing key must be a base64-encoded 32-byte Ed25519 seed")
+)
+
+// Reasons a chain fails verification at a link.
+const (
+	BreakSequenceGap         = "SEQUENCE_GAP"
+	BreakPreviousHash        = "PREVIOUS_HASH_MISMATCH"
+	BreakLinkHash            = "LINK_HASH_MISMATCH"
+	BreakTransactionMissing  = "TRANSACTION_MISSING"
+	BreakContentHash         = "CONTENT_HASH_MISMATCH"
+	BreakCheckpoint          = "CHECKPOINT_MISMATCH"
+	BreakCheckpointSignature = "CHECKPOINT_SIGNATURE_INVALID"
+)
+
+// ChainedTransactionStatuses are the final statuses. A transaction is linked
+// into the chains of the accounts it involves once it has one of them.
+var ChainedTransactionStatuses = []TransactionStatus{SUCCESS, FAILED, CANCELLED}
+
+// ChainLink is one transaction in an account's hash chain. Hash covers the
+// link's fields, including the hash of the transaction's content and the hash
+// of the link before it, so a transaction edited, removed or reordered after
+// it was linked no longer matches its link, and a link edited no longer
+// matches the next one. A transaction that involves several accounts, such as
+// a transfer, is linked into the chain of each.
+type ChainLink struct {
+	AccountID     string    `json:"accountID" gorm:"primaryKey;uniqueIndex:idx_chain_link_transaction"`
+	Sequence      int64     `json:"sequence" gorm:"primaryKey;autoIncrement:false"`
+	TransactionID string    `json:"transactionID" gorm:"not null;uniqueIndex:idx_chain_link_transaction"`
+	ContentHash   string    `json:"contentHash" gorm:"not null"`
+	PreviousHash  string    `json:"previousHash" gorm:"not null"`
+	Hash          string    `json:"hash" gorm:"not null"`
+	LinkedAt      time.Time `json:"linkedAt" gorm:"not null"`
+}
+
+// NewChainLink links tx to the end of the account's chain, after previous, or
+// as the first link if previous is nil.
+func NewChainLink(previous *ChainLink, accountID string, tx *Transaction, now time.Time) (ChainLink, error) {
+	contentHash, err := TransactionContentHash(tx)
+	if err != nil {
+		return ChainLink{}, err
+	}
+	link := ChainLink{
+		AccountID:     accountID,
+		Sequence:      1,
+		TransactionID: tx.ID,
+		ContentHash:   contentHash,
+		LinkedAt:      now,
+	}
+	if previous != nil {
+		link.Sequence = previous.Sequence + 1
+		link.PreviousHash = previous.Hash
+	}
+	link.Hash = link.ComputeHash()
+	return link, nil
+}
+
+// ComputeHash returns the SHA-256 of the link's fields, hex-encoded.
+func (l *ChainLink) ComputeHash() string {
+	sum := sha256.Sum256([]byte(l.AccountID + "\n" +
+		strconv.FormatInt(l.Sequence, 10) + "\n" +
+		l.TransactionID + "\n" +
+		l.ContentHash + "\n" +
+		l.PreviousHash))
+	return hex.EncodeToString(sum[:])
+}
+
+// TransactionContentHash returns the SHA-256 of the transaction's JSON,
+// hex-encoded. Fields that legitimately change after a transaction is final,
+// its reversal link and delivery and chaining state, are left out. Fields
+// added to Transaction must be omitempty so that the hashes of transactions
+// linked before still verify.
+func TransactionContentHash(tx *Transaction) (string, error) {
+	content := *tx
+	content.ReversedBy = ""
+	content.Outbox = nil
+	content.ChainedAt = nil
+	data, err := json.Marshal(&content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction %s: %w", tx.ID, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ChainHead is the last link of an account's chain.
+type ChainHead struct {
+	AccountID string `json:"accountID"`
+	Sequence  int64  `json:"sequence"`
+	Hash      string `json:"hash"`
+}
+
+// ChainCheckpoint is a signed record of every account's chain head at a
+// moment. Once exported, it proves what the chains held then: rewriting a
+// chain changes the hash of its link at the checkpointed sequence, and the
+// checkpoint cannot be re-signed without the signing key. The signature is
+// the Ed25519 signature of Message.
+type ChainCheckpoint struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	CreatedAt time.Time   `json:"createdAt" gorm:"not null;index"`
+	Heads     []ChainHead `json:"heads,omitempty" gorm:"serializer:json;not null"`
+	PublicKey string      `json:"publicKey" gorm:"not null"`
+	Signature string      `json:"signature" gorm:"not null"`
+}
+
+// NewChainCheckpoint signs the heads with the key.
+func NewChainCheckpoint(heads []ChainHead, key ed25519.PrivateKey, now time.Time) ChainCheckpoint {
+	sorted := append([]ChainHead{}, heads...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].AccountID < sorted[j].AccountID })
+
+	checkpoint := ChainCheckpoint{
+		ID: uuid.New(),
+		// Stored with microsecond precision, which the signed message must match.
+		CreatedAt: now.UTC().Truncate(time.Microsecond),
+		Heads:     sorted,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, checkpoint.Message()))
+	return checkpoint
+}
+
+// Message is what the checkpoint's signature covers: a header line, the ID
+// and the time, then one line per head with the account, sequence and hash,
+// ordered by account.
+func (c *ChainCheckpoint) Message() []byte {
+	var message bytes.Buffer
+	message.WriteString("ledger-chain-checkpoint\n")
+	fmt.Fprintf(&message, "%s %s\n", c.ID, c.CreatedAt.UTC().Format(time.RFC3339Nano))
+	for _, head := range c.Heads {
+		fmt.Fprintf(&message, "%s %d %s\n", head.AccountID, head.Sequence, head.Hash)
+	}
+	return message.Bytes()
+}
+
+// Verify reports whether the checkpoint was signed with the private half of
+// publicKey.
+func (c *ChainCheckpoint) Verify(publicKey ed25519.PublicKey) bool {
+	if c.PublicKey != base64.StdEncoding.EncodeToString(publicKey) {
+		return false
+	}
+	signature, err := base64.StdEncoding.DecodeString(c.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(publicKey, c.Message(), signature)
+}
+
+// Head returns the checkpointed head of the account's chain.
+func (c *ChainCheckpoint) Head(accountID string) (ChainHead, bool) {
+	i := sort.Search(len(c.Heads), func(i int) bool { return c.Heads[i].AccountID >= accountID })
+	if i < len(c.Heads) && c.Heads[i].AccountID == accountID {
+		return c.Heads[i], true
+	}
+	return ChainHead{}, false
+}
+
+// ParseChainSigningKey decodes a checkpoint signing key given as a base64
+// Ed25519 seed.
+func ParseChainSigningKey(encoded string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidChainSigningKey
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ChainVerification is the result of walking an account's chain. Break is the
+// first link that does not verify, if any.
+type ChainVerification struct {
+	AccountID    string      `json:"accountID"`
+	Links        int         `json:"links"`
+	Head         *ChainHead  `json:"head,omitempty"`
+	CheckpointID *uuid.UUID  `json:"checkpointID,omitempty"`
+	Valid        bool        `json:"valid"`
+	Break        *ChainBreak `json:"break,omitempty"`
+}
+
+// ChainBreak is where a chain stops verifying: the link and what was expected
+// there.
+type ChainBreak struct {
+	Sequence      int64  `json:"sequence"`
+	TransactionID string `json:"transactionID,omitempty"`
+	Reason        string `json:"reason"`
+	Expected      string `json:"expected,omitempty"`
+	Actual        string `json:"actual,omitempty"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainLink(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	deposit := &Transaction{ID: "deposit", Type: DEPOSIT, Amount: NewMoney(40), AccountID: "account", Status: SUCCESS, ProcessedAt: now}
+	withdrawal := &Transaction{ID: "withdrawal", Type: WITHDRAWL, Amount: NewMoney(10), AccountID: "account", Status: SUCCESS, ProcessedAt: now}
+
+	first, err := NewChainLink(nil, "account", deposit, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Empty(t, first.PreviousHash)
+	assert.Equal(t, first.ComputeHash(), first.Hash)
+
+	second, err := NewChainLink(&first, "account", withdrawal, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.Equal(t, first.Hash, second.PreviousHash)
+	assert.NotEqual(t, first.Hash, second.Hash)
+
+	edited := second
+	edited.TransactionID = "other"
+	assert.NotEqual(t, second.Hash, edited.ComputeHash())
+}
+
+func TestTransactionContentHash(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	tx := &Transaction{ID: "deposit", Type: DEPOSIT, Amount: NewMoney(40), AccountID: "account", Status: SUCCESS, ProcessedAt: now}
+	hash, err := TransactionContentHash(tx)
+	require.NoError(t, err)
+
+	unchanged := *tx
+	unchanged.ReversedBy = "reversal"
+	unchanged.Outbox = NewOutbox(now)
+	unchanged.ChainedAt = &now
+	unchangedHash, err := TransactionContentHash(&unchanged)
+	require.NoError(t, err)
+	assert.Equal(t, hash, unchangedHash)
+
+	edited := *tx
+	edited.Amount = NewMoney(400)
+	editedHash, err := TransactionContentHash(&edited)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, editedHash)
+}
+
+func TestChainCheckpoint(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	key, err := ParseChainSigningKey(base64.StdEncoding.EncodeToString(seed))
+	require.NoError(t, err)
+	_, err = ParseChainSigningKey("c2hvcnQ=")
+	assert.ErrorIs(t, err, ErrInvalidChainSigningKey)
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 123456789, time.UTC)
+	heads := []ChainHead{{AccountID: "b", Sequence: 2, Hash: "bb"}, {AccountID: "a", Sequence: 5, Hash: "aa"}}
+	checkpoint := NewChainCheckpoint(heads, key, now)
+	assert.Equal(t, now.Truncate(time.Microsecond), checkpoint.CreatedAt)
+	assert.Equal(t, "a", checkpoint.Heads[0].AccountID)
+	assert.True(t, checkpoint.Verify(key.Public().(ed25519.PublicKey)))
+
+	head, ok := checkpoint.Head("b")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), head.Sequence)
+	_, ok = checkpoint.Head("c")
+	assert.False(t, ok)
+
+	tampered := checkpoint
+	tampered.Heads = []ChainHead{{AccountID: "a", Sequence: 5, Hash: "aa"}, {AccountID: "b", Sequence: 2, Hash: "cc"}}
+	assert.False(t, tampered.Verify(key.Public().(ed25519.PublicKey)))
+
+	_, other, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	assert.False(t, checkpoint.Verify(other.Public().(ed25519.PublicKey)))
+}
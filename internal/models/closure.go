@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SuspenseLedgerAccount holds the balances of closed accounts that named no
+// account to pay out to, until they are claimed.
+const SuspenseLedgerAccount = SystemLedgerAccountPrefix + "suspense"
+
+var (
+	ErrPendingTransactions   = errors.New("account has pending transactions")
+	ErrAccountOverdrawn      = errors.New("account is overdrawn")
+	ErrInvalidClosure        = errors.New("invalid account closure")
+	ErrClosureBalanceChanged = errors.New("account balance changed since the closure was requested")
+)
+
+// AccountClosureRequest asks for an account to be closed. Its remaining
+// balance is paid out to PayoutAccountID, or to the suspense account if it is
+// empty.
+type AccountClosureRequest struct {
+	PayoutAccountID string `json:"payoutAccountID,omitempty" validate:"omitempty,uuid"`
+	Reason          string `json:"reason" validate:"required"`
+}
+
+func (r *AccountClosureRequest) Validate() error {
+	if r.Reason == "" {
+		return fmt.Errorf("%w: a reason is required", ErrInvalidClosure)
+	}
+	return nil
+}
+
+// AccountClosure is carried by the CLOSURE transaction that pays out an
+// account's balance and closes it.
+type AccountClosure struct {
+	PayoutAccountID string `json:"payoutAccountID,omitempty" bson:"payoutAccountID,omitempty"`
+	Reason          string `json:"reason" bson:"reason"`
+	OperatorID      string `json:"operatorID,omitempty" bson:"operatorID,omitempty"`
+}
+
+// PayoutLedgerAccount is the ledger account the closing balance is paid to.
+func (c *AccountClosure) PayoutLedgerAccount() (string, error) {
+	if c.PayoutAccountID == "" {
+		return SuspenseLedgerAccount, nil
+	}
+	payoutID, err := uuid.Parse(c.PayoutAccountID)
+	if err != nil {
+		return "", fmt.Errorf("invalid payout account ID: %w", err)
+	}
+	return CustomerLedgerAccount(payoutID), nil
+}
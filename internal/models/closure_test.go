@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountClosureRequestValidate(t *testing.T) {
+	request := AccountClosureRequest{Reason: "customer request"}
+	assert.NoError(t, request.Validate())
+
+	request.Reason = ""
+	assert.ErrorIs(t, request.Validate(), ErrInvalidClosure)
+}
+
+func TestAccountClosurePayoutLedgerAccount(t *testing.T) {
+	account, err := (&AccountClosure{}).PayoutLedgerAccount()
+	require.NoError(t, err)
+	assert.Equal(t, SuspenseLedgerAccount, account)
+
+	payoutID := uuid.New()
+	account, err = (&AccountClosure{PayoutAccountID: payoutID.String()}).PayoutLedgerAccount()
+	require.NoError(t, err)
+	assert.Equal(t, CustomerLedgerAccount(payoutID), account)
+
+	_, err = (&AccountClosure{PayoutAccountID: "not-a-uuid"}).PayoutLedgerAccount()
+	assert.Error(t, err)
+}
+
+func TestAccountCheckClosure(t *testing.T) {
+	frozen := &Account{Status: AccountFrozen}
+
+	toSuspense := &Transaction{Type: CLOSURE, Closure: &AccountClosure{Reason: "fraud"}}
+	assert.NoError(t, frozen.CheckTransaction(toSuspense))
+
+	toAccount := &Transaction{Type: CLOSURE, Closure: &AccountClosure{PayoutAccountID: uuid.NewString(), Reason: "fraud"}}
+	assert.ErrorIs(t, frozen.CheckTransaction(toAccount), ErrAccountFrozen)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Currency is an ISO 4217 alphabetic currency code such as "USD".
+type Currency string
+
+// DefaultCurrency is used for accounts opened without a currency and for
+// records written before the ledger tracked currencies.
+const DefaultCurrency Currency = "USD"
+
+// currencyDecimals lists the ISO 4217 currencies whose minor unit is not two
+// decimal places.
+var currencyDecimals = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+var currencyValidator = validator.New()
+
+// ParseCurrency normalises and validates an ISO 4217 currency code.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if err := currencyValidator.Var(code, "required,iso4217"); err != nil {
+		return "", fmt.Errorf("invalid currency code: %q", code)
+	}
+	return Currency(code), nil
+}
+
+// Decimals returns the number of decimal places in the currency's minor unit.
+func (c Currency) Decimals() int {
+	if decimals, ok := currencyDecimals[c]; ok {
+		return decimals
+	}
+	return DefaultCurrencyDecimals
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			input       string
+			expected    Currency
+			expectError bool
+		}{
+			{input: "USD", expected: "USD"},
+			{input: " eur ", expected: "EUR"},
+			{input: "JPY", expected: "JPY"},
+			{input: "ABC", expectError: true},
+			{input: "US", expectError: true},
+			{input: "", expectError: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				currency, err := ParseCurrency(tt.input)
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, currency)
+			})
+		}
+	})
+
+	t.Run("Decimals", func(t *testing.T) {
+		assert.Equal(t, 2, Currency("USD").Decimals())
+		assert.Equal(t, 0, Currency("JPY").Decimals())
+		assert.Equal(t, 3, Currency("KWD").Decimals())
+		assert.Equal(t, 4, Currency("CLF").Decimals())
+	})
+
+	t.Run("Amount Precision Per Currency", func(t *testing.T) {
+		assert.True(t, MustParseMoney("100").HasPrecision(Currency("JPY").Decimals()))
+		assert.False(t, MustParseMoney("100.5").HasPrecision(Currency("JPY").Decimals()))
+		assert.True(t, MustParseMoney("1.125").HasPrecision(Currency("KWD").Decimals()))
+		assert.False(t, MustParseMoney("1.125").HasPrecision(Currency("USD").Decimals()))
+	})
+}
@@ -0,0 +1,334 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeeKind string
+type FeeMethod string
+
+// TRANSACTION rules charge a fee for each transaction they match;
+// MAINTENANCE rules charge each account they match once a month.
+const (
+	FeeKindTransaction FeeKind = "TRANSACTION"
+	FeeKindMaintenance FeeKind = "MAINTENANCE"
+)
+
+// FLAT charges FlatAmount. PERCENTAGE adds Rate times the transaction amount
+// to it, and TIERED adds each tier's rate times the part of the amount that
+// falls in the tier. MinFee and MaxFee cap the result either way.
+const (
+	FeeFlat       FeeMethod = "FLAT"
+	FeePercentage FeeMethod = "PERCENTAGE"
+	FeeTiered     FeeMethod = "TIERED"
+)
+
+// FeeIncomeLedgerAccount receives the fees charged to customers.
+const FeeIncomeLedgerAccount = SystemLedgerAccountPrefix + "fee-income"
+
+var (
+	ErrFeeRuleNotFound = errors.New("fee rule not found")
+	ErrInvalidFeeRule  = errors.New("invalid fee rule")
+)
+
+// Feeable reports whether transaction fee rules may charge for transactions
+// of this type.
+func (t TransactionType) Feeable() bool {
+	return t == DEPOSIT || t == WITHDRAWL || t == TRANSFER
+}
+
+// FeeTier charges Rate on the part of an amount above the previous tier's
+// UpTo and up to its own. The last tier may leave UpTo out to cover the rest.
+type FeeTier struct {
+	UpTo *Money `json:"upTo,omitempty"`
+	Rate Rate   `json:"rate"`
+}
+
+// FeeRule prices a fee and selects what it is charged on: transactions of
+// TransactionType whose amount is at least MinAmount and below MaxAmount, or
+// for maintenance fees accounts, in either case limited to accounts of Tier
+// and Product when they are set. Where several rules match, the one with the
+// highest Priority applies, and between equal priorities the oldest. Rules are
+// deactivated rather than deleted, so every fee can be traced to its rule.
+type FeeRule struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	Name            string          `json:"name" gorm:"not null"`
+	Kind            FeeKind         `json:"kind" gorm:"not null;index"`
+	Method          FeeMethod       `json:"method" gorm:"not null"`
+	TransactionType TransactionType `json:"transactionType,omitempty"`
+	Tier            AccountTier     `json:"tier,omitempty"`
+	Product         string          `json:"product,omitempty"`
+	Currency        Currency        `json:"currency" gorm:"type:char(3);not null"`
+	MinAmount       Money           `json:"minAmount" gorm:"not null;default:0"`
+	MaxAmount       *Money          `json:"maxAmount,omitempty"`
+	FlatAmount      Money           `json:"flatAmount" gorm:"not null;default:0"`
+	Rate            Rate            `json:"rate" gorm:"not null;default:0"`
+	Tiers           []FeeTier       `json:"tiers,omitempty" gorm:"serializer:json"`
+	MinFee          Money           `json:"minFee" gorm:"not null;default:0"`
+	MaxFee          *Money          `json:"maxFee,omitempty"`
+	Priority        int             `json:"priority" gorm:"not null;default:0"`
+	Active          bool            `json:"active" gorm:"not null"`
+	CreatedAt       time.Time       `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time       `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// FeeRuleRequest creates a fee rule or replaces one's terms.
+type FeeRuleRequest struct {
+	Name            string          `json:"name" binding:"required"`
+	Kind            FeeKind         `json:"kind" binding:"required"`
+	Method          FeeMethod       `json:"method" binding:"required"`
+	TransactionType TransactionType `json:"transactionType,omitempty"`
+	Tier            AccountTier     `json:"tier,omitempty"`
+	Product         string          `json:"product,omitempty"`
+	Currency        Currency        `json:"currency" binding:"required"`
+	MinAmount       Money           `json:"minAmount,omitempty"`
+	MaxAmount       *Money          `json:"maxAmount,omitempty"`
+	FlatAmount      Money           `json:"flatAmount,omitempty"`
+	Rate            Rate            `json:"rate,omitempty"`
+	Tiers           []FeeTier       `json:"tiers,omitempty"`
+	MinFee          Money           `json:"minFee,omitempty"`
+	MaxFee          *Money          `json:"maxFee,omitempty"`
+	Priority        int             `json:"priority,omitempty"`
+}
+
+func NewFeeRule(request FeeRuleRequest) (FeeRule, error) {
+	rule := FeeRule{ID: uuid.New(), Active: true}
+	if err := rule.Apply(request); err != nil {
+		return FeeRule{}, err
+	}
+	return rule, nil
+}
+
+// Apply replaces the rule's terms with the request's, leaving the rule
+// unchanged if they are invalid.
+func (r *FeeRule) Apply(request FeeRuleRequest) error {
+	updated := *r
+	updated.Name = request.Name
+	updated.Kind = request.Kind
+	updated.Method = request.Method
+	updated.TransactionType = request.TransactionType
+	updated.Tier = request.Tier
+	updated.MinAmount = request.MinAmount
+	updated.MaxAmount = request.MaxAmount
+	updated.FlatAmount = request.FlatAmount
+	updated.Rate = request.Rate
+	updated.Tiers = request.Tiers
+	updated.MinFee = request.MinFee
+	updated.MaxFee = request.MaxFee
+	updated.Priority = request.Priority
+
+	var err error
+	if updated.Currency, err = ParseCurrency(string(request.Currency)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFeeRule, err)
+	}
+	updated.Product = ""
+	if request.Product != "" {
+		if updated.Product, err = ParseProduct(request.Product); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFeeRule, err)
+		}
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	*r = updated
+	return nil
+}
+
+func (r *FeeRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: a name is required", ErrInvalidFeeRule)
+	}
+	switch r.Kind {
+	case FeeKindTransaction:
+		if !r.TransactionType.Feeable() {
+			return fmt.Errorf("%w: fees can only be charged on deposits, withdrawals and transfers", ErrInvalidFeeRule)
+		}
+	case FeeKindMaintenance:
+		if r.TransactionType != "" || r.MinAmount != 0 || r.MaxAmount != nil {
+			return fmt.Errorf("%w: maintenance fees do not select transactions", ErrInvalidFeeRule)
+		}
+		if r.Method != FeeFlat {
+			return fmt.Errorf("%w: maintenance fees must be flat", ErrInvalidFeeRule)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidFeeRule, r.Kind)
+	}
+	if r.Tier != "" && !r.Tier.Valid() {
+		return fmt.Errorf("%w: unknown tier %q", ErrInvalidFeeRule, r.Tier)
+	}
+	if r.MinAmount < 0 || r.MaxAmount != nil && *r.MaxAmount <= r.MinAmount {
+		return fmt.Errorf("%w: the amount band must not be empty", ErrInvalidFeeRule)
+	}
+
+	switch r.Method {
+	case FeeFlat:
+		if r.FlatAmount <= 0 || r.Rate != 0 || len(r.Tiers) > 0 {
+			return fmt.Errorf("%w: a flat fee takes only a positive flat amount", ErrInvalidFeeRule)
+		}
+	case FeePercentage:
+		if r.Rate <= 0 || r.Rate > rateFactor || len(r.Tiers) > 0 {
+			return fmt.Errorf("%w: a percentage fee takes a rate above 0 and at most 1", ErrInvalidFeeRule)
+		}
+	case FeeTiered:
+		if r.Rate != 0 || len(r.Tiers) == 0 {
+			return fmt.Errorf("%w: a tiered fee takes tiers instead of a rate", ErrInvalidFeeRule)
+		}
+		var previous Money
+		for i, tier := range r.Tiers {
+			if tier.Rate < 0 || tier.Rate > rateFactor {
+				return fmt.Errorf("%w: tier rates must be between 0 and 1", ErrInvalidFeeRule)
+			}
+			if tier.UpTo == nil {
+				if i != len(r.Tiers)-1 {
+					return fmt.Errorf("%w: only the last tier may be open-ended", ErrInvalidFeeRule)
+				}
+				break
+			}
+			if *tier.UpTo <= previous {
+				return fmt.Errorf("%w: tiers must rise in order", ErrInvalidFeeRule)
+			}
+			previous = *tier.UpTo
+		}
+	default:
+		return fmt.Errorf("%w: unknown method %q", ErrInvalidFeeRule, r.Method)
+	}
+	if r.FlatAmount < 0 || r.MinFee < 0 || r.MaxFee != nil && *r.MaxFee < r.MinFee {
+		return fmt.Errorf("%w: the fee caps must not be negative or crossed", ErrInvalidFeeRule)
+	}
+
+	places := r.Currency.Decimals()
+	for _, amount := range []*Money{&r.MinAmount, r.MaxAmount, &r.FlatAmount, &r.MinFee, r.MaxFee} {
+		if amount != nil && !amount.HasPrecision(places) {
+			return fmt.Errorf("%w: amounts have more decimal places than %s allows", ErrInvalidFeeRule, r.Currency)
+		}
+	}
+	return nil
+}
+
+// Compute returns the fee on an amount, rounded half-to-even to the rule
+// currency's minor unit and then capped.
+func (r *FeeRule) Compute(amount Money) Money {
+	fee := r.FlatAmount
+	switch r.Method {
+	case FeePercentage:
+		fee += applyRate(amount, r.Rate)
+	case FeeTiered:
+		var lower Money
+		for _, tier := range r.Tiers {
+			upper := amount
+			if tier.UpTo != nil && *tier.UpTo < amount {
+				upper = *tier.UpTo
+			}
+			if upper > lower {
+				fee += applyRate(upper-lower, tier.Rate)
+			}
+			if tier.UpTo == nil || *tier.UpTo >= amount {
+				break
+			}
+			lower = *tier.UpTo
+		}
+	}
+
+	fee = fee.Round(r.Currency.Decimals())
+	if fee < r.MinFee {
+		fee = r.MinFee
+	}
+	if r.MaxFee != nil && fee > *r.MaxFee {
+		fee = *r.MaxFee
+	}
+	return fee
+}
+
+func applyRate(amount Money, rate Rate) Money {
+	numerator := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(int64(rate)))
+	return Money(divRoundHalfEven(numerator, big.NewInt(rateFactor)).Int64())
+}
+
+func (r *FeeRule) appliesTo(account *Account, currency Currency) bool {
+	return r.Active && r.Currency == currency &&
+		(r.Tier == "" || r.Tier == account.Tier) &&
+		(r.Product == "" || r.Product == account.Product)
+}
+
+// TransactionFeeRule returns the rule that sets the fee for a transaction on
+// the account, or nil if no rule charges one.
+func TransactionFeeRule(rules []FeeRule, tx *Transaction, account *Account) *FeeRule {
+	return selectFeeRule(rules, func(r *FeeRule) bool {
+		return r.Kind == FeeKindTransaction && r.TransactionType == tx.Type &&
+			r.appliesTo(account, tx.Currency) &&
+			tx.Amount >= r.MinAmount && (r.MaxAmount == nil || tx.Amount < *r.MaxAmount)
+	})
+}
+
+// MaintenanceFeeRule returns the rule that sets the account's monthly
+// maintenance fee, or nil if it is charged none.
+func MaintenanceFeeRule(rules []FeeRule, account *Account) *FeeRule {
+	return selectFeeRule(rules, func(r *FeeRule) bool {
+		return r.Kind == FeeKindMaintenance && r.appliesTo(account, account.Currency)
+	})
+}
+
+func selectFeeRule(rules []FeeRule, matches func(*FeeRule) bool) *FeeRule {
+	var selected *FeeRule
+	for i := range rules {
+		rule := &rules[i]
+		if !matches(rule) {
+			continue
+		}
+		if selected == nil || rule.Priority > selected.Priority ||
+			rule.Priority == selected.Priority && rule.CreatedAt.Before(selected.CreatedAt) {
+			selected = rule
+		}
+	}
+	return selected
+}
+
+// FeeCharge is carried by a FEE transaction: the rule that set it and either
+// the transaction it was charged for or the month (as YYYY-MM) of maintenance
+// it pays for.
+type FeeCharge struct {
+	RuleID        uuid.UUID `json:"ruleID" bson:"ruleID"`
+	TransactionID string    `json:"transactionID,omitempty" bson:"transactionID,omitempty"`
+	Period        string    `json:"period,omitempty" bson:"period,omitempty"`
+}
+
+// NewFeeTransaction builds the pending FEE transaction that charges amount to
+// the account.
+func NewFeeTransaction(id string, account *Account, amount Money, charge FeeCharge, now time.Time) *Transaction {
+	return &Transaction{
+		ID:        id,
+		Type:      FEE,
+		Amount:    amount,
+		Currency:  account.Currency,
+		AccountID: account.ID.String(),
+		Status:    PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Fee:       &charge,
+	}
+}
+
+// TransactionFeeID is the ID of the fee charged for a transaction, so that
+// processing the transaction again finds the fee already created.
+func TransactionFeeID(transactionID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("fee:"+transactionID)).String()
+}
+
+// MaintenanceFeeID is the ID of an account's maintenance fee for a month, so
+// that it is charged at most once.
+func MaintenanceFeeID(accountID uuid.UUID, period string) string {
+	return uuid.NewSHA1(accountID, []byte("maintenance-fee:"+period)).String()
+}
+
+// MaintenancePeriod returns the last month that has ended by now, as YYYY-MM,
+// and when it ended.
+func MaintenancePeriod(now time.Time) (string, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, -1, 0).Format("2006-01"), end
+}
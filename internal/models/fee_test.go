@@ -0,0 +1,145 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRuleCompute(t *testing.T) {
+	upTo := func(units int64) *Money {
+		m := NewMoney(units)
+		return &m
+	}
+	maxFee := NewMoney(5)
+
+	tests := []struct {
+		name   string
+		rule   FeeRule
+		amount Money
+		want   Money
+	}{
+		{"Flat", FeeRule{Method: FeeFlat, FlatAmount: NewMoney(2)}, NewMoney(500), NewMoney(2)},
+		// 1.5% of 123.45 = 1.85175, rounded to the cent
+		{"Percentage", FeeRule{Method: FeePercentage, Rate: Rate(1_500_000)}, Money(1_234_500), Money(18_500)},
+		{"Percentage plus flat", FeeRule{Method: FeePercentage, Rate: Rate(1_000_000), FlatAmount: NewMoney(1)}, NewMoney(100), NewMoney(2)},
+		{"Percentage capped", FeeRule{Method: FeePercentage, Rate: Rate(1_000_000), MaxFee: &maxFee}, NewMoney(1000), NewMoney(5)},
+		{"Percentage with minimum", FeeRule{Method: FeePercentage, Rate: Rate(1_000_000), MinFee: NewMoney(1)}, NewMoney(10), NewMoney(1)},
+		// 2% of the first 100, 1% of the next 900 and 0.5% of the remaining 1000
+		{"Tiered", FeeRule{Method: FeeTiered, Tiers: []FeeTier{
+			{UpTo: upTo(100), Rate: Rate(2_000_000)},
+			{UpTo: upTo(1000), Rate: Rate(1_000_000)},
+			{Rate: Rate(500_000)},
+		}}, NewMoney(2000), NewMoney(16)},
+		{"Tiered within the first tier", FeeRule{Method: FeeTiered, Tiers: []FeeTier{
+			{UpTo: upTo(100), Rate: Rate(2_000_000)},
+			{Rate: Rate(1_000_000)},
+		}}, NewMoney(50), NewMoney(1)},
+		{"Tiered beyond the last bounded tier", FeeRule{Method: FeeTiered, Tiers: []FeeTier{
+			{UpTo: upTo(100), Rate: Rate(2_000_000)},
+		}}, NewMoney(500), NewMoney(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Currency = DefaultCurrency
+			assert.Equal(t, tt.want, tt.rule.Compute(tt.amount))
+		})
+	}
+}
+
+func TestFeeRuleValidate(t *testing.T) {
+	valid := FeeRuleRequest{
+		Name:            "ATM withdrawal",
+		Kind:            FeeKindTransaction,
+		Method:          FeePercentage,
+		TransactionType: WITHDRAWL,
+		Currency:        "usd",
+		Product:         "savings",
+		Rate:            Rate(1_000_000),
+	}
+	rule, err := NewFeeRule(valid)
+	require.NoError(t, err)
+	assert.True(t, rule.Active)
+	assert.Equal(t, DefaultCurrency, rule.Currency)
+	assert.Equal(t, "SAVINGS", rule.Product)
+
+	maxAmount := NewMoney(100)
+	fraction := Money(1)
+	invalid := map[string]func(r *FeeRuleRequest){
+		"Unfeeable type":           func(r *FeeRuleRequest) { r.TransactionType = ADJUSTMENT },
+		"Unknown method":           func(r *FeeRuleRequest) { r.Method = "FANCY" },
+		"Percentage above one":     func(r *FeeRuleRequest) { r.Rate = 2 * rateFactor },
+		"Empty band":               func(r *FeeRuleRequest) { r.MinAmount = NewMoney(100); r.MaxAmount = &maxAmount },
+		"Flat with a rate":         func(r *FeeRuleRequest) { r.Method = FeeFlat; r.FlatAmount = NewMoney(1) },
+		"Tiered without tiers":     func(r *FeeRuleRequest) { r.Method = FeeTiered; r.Rate = 0 },
+		"Open tier before the end": func(r *FeeRuleRequest) { r.Method = FeeTiered; r.Rate = 0; r.Tiers = []FeeTier{{}, {UpTo: &maxAmount}} },
+		"Crossed caps":             func(r *FeeRuleRequest) { r.MinFee = NewMoney(5); r.MaxFee = &fraction },
+		"Sub-cent amount":          func(r *FeeRuleRequest) { r.MinFee = fraction },
+		"Maintenance with a type":  func(r *FeeRuleRequest) { r.Kind = FeeKindMaintenance },
+		"Unknown tier":             func(r *FeeRuleRequest) { r.Tier = "GOLD" },
+		"Invalid product":          func(r *FeeRuleRequest) { r.Product = "easy access" },
+	}
+	for name, change := range invalid {
+		t.Run(name, func(t *testing.T) {
+			request := valid
+			change(&request)
+			_, err := NewFeeRule(request)
+			assert.ErrorIs(t, err, ErrInvalidFeeRule)
+		})
+	}
+
+	t.Run("Invalid update leaves the rule unchanged", func(t *testing.T) {
+		request := valid
+		request.Rate = 2 * rateFactor
+		assert.Error(t, rule.Apply(request))
+		assert.Equal(t, Rate(1_000_000), rule.Rate)
+	})
+}
+
+func TestTransactionFeeRule(t *testing.T) {
+	account := &Account{ID: uuid.New(), Currency: DefaultCurrency, Tier: TierStandard, Product: "SAVINGS"}
+	created := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	bandEnd := NewMoney(1000)
+	rule := func(name string, change func(r *FeeRule)) FeeRule {
+		r := FeeRule{
+			ID:              uuid.New(),
+			Name:            name,
+			Kind:            FeeKindTransaction,
+			TransactionType: WITHDRAWL,
+			Currency:        DefaultCurrency,
+			Active:          true,
+			CreatedAt:       created,
+		}
+		change(&r)
+		return r
+	}
+	rules := []FeeRule{
+		rule("any withdrawal", func(r *FeeRule) {}),
+		rule("small withdrawal", func(r *FeeRule) { r.MaxAmount = &bandEnd; r.Priority = 1 }),
+		rule("premium", func(r *FeeRule) { r.Tier = TierPremium; r.Priority = 5 }),
+		rule("euro", func(r *FeeRule) { r.Currency = "EUR"; r.Priority = 5 }),
+		rule("inactive", func(r *FeeRule) { r.Active = false; r.Priority = 5 }),
+		rule("transfer", func(r *FeeRule) { r.TransactionType = TRANSFER; r.Priority = 5 }),
+		rule("newer any withdrawal", func(r *FeeRule) { r.CreatedAt = created.Add(time.Hour) }),
+	}
+	withdrawal := func(amount Money) *Transaction {
+		return &Transaction{Type: WITHDRAWL, Amount: amount, Currency: DefaultCurrency}
+	}
+
+	assert.Equal(t, "small withdrawal", TransactionFeeRule(rules, withdrawal(NewMoney(50)), account).Name)
+	assert.Equal(t, "any withdrawal", TransactionFeeRule(rules, withdrawal(NewMoney(1000)), account).Name)
+	assert.Nil(t, TransactionFeeRule(rules, &Transaction{Type: DEPOSIT, Amount: NewMoney(50), Currency: DefaultCurrency}, account))
+
+	premium := *account
+	premium.Tier = TierPremium
+	assert.Equal(t, "premium", TransactionFeeRule(rules, withdrawal(NewMoney(50)), &premium).Name)
+}
+
+func TestMaintenancePeriod(t *testing.T) {
+	period, end := MaintenancePeriod(time.Date(2025, time.January, 15, 8, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-12", period)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), end)
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rate is an exchange rate held as a fixed-point decimal with RateScale
+// decimal places, so conversions can be replayed exactly from stored values.
+type Rate int64
+
+const RateScale = 8
+
+const rateFactor = 100000000
+
+// FXSpreadLedgerAccount collects the spread earned on conversions, and
+// FXPositionLedgerAccount is the bank's position in each currency it converts.
+const (
+	FXPositionLedgerAccount = SystemLedgerAccountPrefix + "fx-position"
+	FXSpreadLedgerAccount   = SystemLedgerAccountPrefix + "fx-spread"
+)
+
+var (
+	ErrInvalidRate  = errors.New("invalid rate")
+	ErrFXRateExists = errors.New("a rate for the pair already takes effect at that time")
+)
+
+type FXRate struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	BaseCurrency  Currency  `json:"baseCurrency" gorm:"type:char(3);not null;uniqueIndex:idx_fx_rates_pair_effective"`
+	QuoteCurrency Currency  `json:"quoteCurrency" gorm:"type:char(3);not null;uniqueIndex:idx_fx_rates_pair_effective"`
+	Rate          Rate      `json:"rate" gorm:"not null"`
+	SpreadBps     int       `json:"spreadBps" gorm:"not null;default:0"`
+	EffectiveAt   time.Time `json:"effectiveAt" gorm:"not null;uniqueIndex:idx_fx_rates_pair_effective"`
+	Source        string    `json:"source" gorm:"not null"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+type FXRateCreate struct {
+	BaseCurrency  string    `json:"baseCurrency" validate:"required,iso4217"`
+	QuoteCurrency string    `json:"quoteCurrency" validate:"required,iso4217,nefield=BaseCurrency"`
+	Rate          Rate      `json:"rate" validate:"required,gt=0"`
+	SpreadBps     int       `json:"spreadBps" validate:"gte=0,lt=10000"`
+	EffectiveAt   time.Time `json:"effectiveAt" validate:"required"`
+}
+
+// FXConversion records everything needed to replay and audit a conversion:
+// the rate used, both amounts and the spread kept by the bank. The spread is
+// in the target currency.
+type FXConversion struct {
+	RateID         uuid.UUID `json:"rateID" bson:"rateID"`
+	Rate           Rate      `json:"rate" bson:"rate"`
+	EffectiveAt    time.Time `json:"effectiveAt" bson:"effectiveAt"`
+	SpreadBps      int       `json:"spreadBps" bson:"spreadBps"`
+	SourceAmount   Money     `json:"sourceAmount" bson:"sourceAmount"`
+	SourceCurrency Currency  `json:"sourceCurrency" bson:"sourceCurrency"`
+	TargetAmount   Money     `json:"targetAmount" bson:"targetAmount"`
+	TargetCurrency Currency  `json:"targetCurrency" bson:"targetCurrency"`
+	SpreadAmount   Money     `json:"spreadAmount" bson:"spreadAmount"`
+}
+
+// Convert converts an amount in the rate's base currency into its quote
+// currency. The gross amount is rounded half-to-even to MoneyScale places, the
+// spread is taken off it, and the customer's amount is rounded half-to-even to
+// the quote currency's minor unit; whatever rounding leaves over is spread.
+func (r *FXRate) Convert(amount Money) FXConversion {
+	gross := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(int64(r.Rate)))
+	grossAmount := Money(divRoundHalfEven(gross, big.NewInt(rateFactor)).Int64())
+
+	net := new(big.Int).Mul(big.NewInt(int64(grossAmount)), big.NewInt(int64(10000-r.SpreadBps)))
+	target := Money(divRoundHalfEven(net, big.NewInt(10000)).Int64()).Round(r.QuoteCurrency.Decimals())
+
+	return FXConversion{
+		RateID:         r.ID,
+		Rate:           r.Rate,
+		EffectiveAt:    r.EffectiveAt,
+		SpreadBps:      r.SpreadBps,
+		SourceAmount:   amount,
+		SourceCurrency: r.BaseCurrency,
+		TargetAmount:   target,
+		TargetCurrency: r.QuoteCurrency,
+		SpreadAmount:   grossAmount - target,
+	}
+}
+
+func divRoundHalfEven(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+
+	cmp := twice.Cmp(new(big.Int).Abs(denominator))
+	if cmp > 0 || cmp == 0 && quotient.Bit(0) == 1 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+// ParseRate parses a positive decimal rate such as "1.0825" exactly.
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	whole, fraction, hasPoint := strings.Cut(s, ".")
+	if whole == "" || hasPoint && fraction == "" || len(fraction) > RateScale ||
+		!isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > 1<<62/rateFactor {
+		return 0, fmt.Errorf("%w: out of range", ErrInvalidRate)
+	}
+	var minor int64
+	if fraction != "" {
+		minor, _ = strconv.ParseInt(fraction+strings.Repeat("0", RateScale-len(fraction)), 10, 64)
+	}
+	return Rate(units*rateFactor + minor), nil
+}
+
+func (r Rate) String() string {
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", RateScale, int64(r)%rateFactor), "0")
+	if fraction == "" {
+		fraction = "0"
+	}
+	return fmt.Sprintf("%d.%s", int64(r)/rateFactor, fraction)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts the rate as a JSON number or a quoted decimal string.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" {
+		return nil
+	}
+	rate, err := ParseRate(text)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFXRate(t *testing.T) {
+	t.Run("Parse Rate", func(t *testing.T) {
+		rate, err := ParseRate("1.0825")
+		require.NoError(t, err)
+		assert.Equal(t, Rate(108250000), rate)
+		assert.Equal(t, "1.0825", rate.String())
+
+		_, err = ParseRate("1.123456789")
+		assert.ErrorIs(t, err, ErrInvalidRate)
+		_, err = ParseRate("-1")
+		assert.ErrorIs(t, err, ErrInvalidRate)
+	})
+
+	t.Run("Rate JSON", func(t *testing.T) {
+		var request FXRateCreate
+		require.NoError(t, json.Unmarshal([]byte(`{"rate": 0.9231}`), &request))
+		assert.Equal(t, "0.9231", request.Rate.String())
+	})
+
+	t.Run("Convert Without Spread", func(t *testing.T) {
+		rate := FXRate{ID: uuid.New(), BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: mustParseRate(t, "0.9"), EffectiveAt: time.Now()}
+
+		conversion := rate.Convert(NewMoney(100))
+		assert.Equal(t, NewMoney(90), conversion.TargetAmount)
+		assert.Equal(t, Money(0), conversion.SpreadAmount)
+		assert.Equal(t, rate.ID, conversion.RateID)
+		assert.Equal(t, Currency("USD"), conversion.SourceCurrency)
+		assert.Equal(t, Currency("EUR"), conversion.TargetCurrency)
+	})
+
+	t.Run("Convert With Spread Rounds To Target Currency", func(t *testing.T) {
+		rate := FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: mustParseRate(t, "0.91234567"), SpreadBps: 50}
+
+		conversion := rate.Convert(MustParseMoney("10.00"))
+		// gross 9.123457 rounds to 9.1235, less 0.5% is 9.0779 which rounds to 9.08.
+		assert.Equal(t, MustParseMoney("9.08"), conversion.TargetAmount)
+		assert.Equal(t, MustParseMoney("0.0435"), conversion.SpreadAmount)
+		assert.Equal(t, MustParseMoney("9.1235"), conversion.TargetAmount+conversion.SpreadAmount)
+	})
+
+	t.Run("Convert To Zero Decimal Currency", func(t *testing.T) {
+		rate := FXRate{BaseCurrency: "USD", QuoteCurrency: "JPY", Rate: mustParseRate(t, "151.37")}
+
+		conversion := rate.Convert(MustParseMoney("1.25"))
+		assert.Equal(t, NewMoney(189), conversion.TargetAmount)
+		assert.True(t, conversion.TargetAmount.HasPrecision(Currency("JPY").Decimals()))
+	})
+
+	t.Run("Converted Transfer Journal", func(t *testing.T) {
+		sourceID, destinationID := uuid.New(), uuid.New()
+		rate := FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: mustParseRate(t, "0.9"), SpreadBps: 100}
+		conversion := rate.Convert(NewMoney(100))
+
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 TRANSFER,
+			Amount:               NewMoney(100),
+			Currency:             "USD",
+			AccountID:            sourceID.String(),
+			DestinationAccountID: destinationID.String(),
+			FX:                   &conversion,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(100), journal.NetChange(CustomerLedgerAccount(sourceID)))
+		assert.Equal(t, MustParseMoney("89.10"), journal.NetChange(CustomerLedgerAccount(destinationID)))
+		assert.Equal(t, MustParseMoney("0.90"), journal.NetChange(FXSpreadLedgerAccount))
+	})
+
+	t.Run("Converted Transfer Must Match Amount", func(t *testing.T) {
+		rate := FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: mustParseRate(t, "0.9")}
+		conversion := rate.Convert(NewMoney(50))
+
+		_, err := NewTransactionJournal(&Transaction{
+			ID:                   "tx",
+			Type:                 TRANSFER,
+			Amount:               NewMoney(100),
+			Currency:             "USD",
+			AccountID:            uuid.New().String(),
+			DestinationAccountID: uuid.New().String(),
+			FX:                   &conversion,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func mustParseRate(t *testing.T, s string) Rate {
+	rate, err := ParseRate(s)
+	require.NoError(t, err)
+	return rate
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldVoided   HoldStatus = "VOIDED"
+	HoldExpired  HoldStatus = "EXPIRED"
+)
+
+// DefaultHoldDuration is how long an authorization holds funds when the request
+// does not say; MaxHoldDuration is the longest it may ask for.
+const (
+	DefaultHoldDuration = 7 * 24 * time.Hour
+	MaxHoldDuration     = 30 * 24 * time.Hour
+)
+
+// SettlementLedgerAccount receives captured card payments.
+const SettlementLedgerAccount = SystemLedgerAccountPrefix + "settlement"
+
+var (
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrHoldNotActive = errors.New("hold is no longer active")
+)
+
+// Hold reserves funds for an AUTHORIZATION transaction, whose ID it shares.
+// While it is active its amount counts towards the account's held balance and
+// is not available to spend. A capture settles part or all of it and releases
+// the rest; a void or expiry releases all of it.
+type Hold struct {
+	ID                   string     `json:"id" gorm:"primaryKey"`
+	AccountID            uuid.UUID  `json:"accountID" gorm:"type:uuid;not null;index"`
+	Amount               Money      `json:"amount" gorm:"not null"`
+	Currency             Currency   `json:"currency" gorm:"type:char(3);not null"`
+	Status               HoldStatus `json:"status" gorm:"not null;index"`
+	ExpiresAt            time.Time  `json:"expiresAt" gorm:"not null;index"`
+	CaptureTransactionID *string    `json:"captureTransactionID,omitempty"`
+	CapturedAmount       Money      `json:"capturedAmount" gorm:"not null;default:0"`
+	CreatedAt            time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+type HoldCapture struct {
+	// Amount to capture; zero captures the full hold.
+	Amount Money `json:"amount,omitempty"`
+}
+
+// NewHold builds the hold placed by an authorization.
+func NewHold(tx *Transaction, now time.Time) (Hold, error) {
+	if tx.Type != AUTHORIZATION {
+		return Hold{}, errors.New("only authorizations place holds")
+	}
+	accountID, err := uuid.Parse(tx.AccountID)
+	if err != nil {
+		return Hold{}, err
+	}
+	expiresAt := now.Add(DefaultHoldDuration)
+	if tx.HoldExpiresAt != nil {
+		expiresAt = *tx.HoldExpiresAt
+	}
+	return Hold{
+		ID:        tx.ID,
+		AccountID: accountID,
+		Amount:    tx.Amount,
+		Currency:  tx.Currency,
+		Status:    HoldActive,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (h *Hold) Expired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHold(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	authorization := func() *Transaction {
+		return &Transaction{
+			ID:        uuid.New().String(),
+			Type:      AUTHORIZATION,
+			Amount:    NewMoney(25),
+			Currency:  DefaultCurrency,
+			AccountID: uuid.New().String(),
+		}
+	}
+
+	t.Run("Default Expiry", func(t *testing.T) {
+		tx := authorization()
+		hold, err := NewHold(tx, now)
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID, hold.ID)
+		assert.Equal(t, tx.AccountID, hold.AccountID.String())
+		assert.Equal(t, NewMoney(25), hold.Amount)
+		assert.Equal(t, HoldActive, hold.Status)
+		assert.Equal(t, now.Add(DefaultHoldDuration), hold.ExpiresAt)
+	})
+
+	t.Run("Requested Expiry", func(t *testing.T) {
+		tx := authorization()
+		expiresAt := now.Add(time.Hour)
+		tx.HoldExpiresAt = &expiresAt
+
+		hold, err := NewHold(tx, now)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, hold.ExpiresAt)
+		assert.False(t, hold.Expired(now))
+		assert.True(t, hold.Expired(expiresAt))
+	})
+
+	t.Run("Not An Authorization", func(t *testing.T) {
+		tx := authorization()
+		tx.Type = WITHDRAWL
+		_, err := NewHold(tx, now)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// IdempotencyKey remembers a request made with an Idempotency-Key header so a
+// retry gets the original response instead of repeating the request. The
+// fingerprint identifies the request the key was first used for; a key is
+// pending until the response is stored.
+type IdempotencyKey struct {
+	Key          string    `gorm:"primaryKey"`
+	Fingerprint  string    `gorm:"not null"`
+	Completed    bool      `gorm:"not null;default:false"`
+	StatusCode   int       `gorm:"not null;default:0"`
+	ResponseBody []byte    `gorm:"type:bytea"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (k *IdempotencyKey) Expired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
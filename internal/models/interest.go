@@ -0,0 +1,184 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DayCountConvention string
+type Compounding string
+
+// ACT_365 and ACT_360 divide the annual rate by a fixed 365 or 360 days;
+// ACT_ACT divides it by the days in the year being accrued, 366 in leap years.
+const (
+	DayCountActual365    DayCountConvention = "ACT_365"
+	DayCountActual360    DayCountConvention = "ACT_360"
+	DayCountActualActual DayCountConvention = "ACT_ACT"
+)
+
+// Compounding sets how often accrued interest is posted to the account, after
+// which it earns interest itself. DAILY accrues on the unposted interest too
+// and posts monthly.
+const (
+	CompoundDaily     Compounding = "DAILY"
+	CompoundMonthly   Compounding = "MONTHLY"
+	CompoundQuarterly Compounding = "QUARTERLY"
+	CompoundAnnually  Compounding = "ANNUALLY"
+)
+
+// InterestExpenseLedgerAccount pays for the interest credited to customers.
+const InterestExpenseLedgerAccount = SystemLedgerAccountPrefix + "interest-expense"
+
+var (
+	ErrInterestConfigNotFound  = errors.New("interest configuration not found")
+	ErrInterestAccrualNotFound = errors.New("interest accrual not found")
+	ErrInvalidInterestConfig   = errors.New("invalid interest configuration")
+	ErrInvalidProduct          = errors.New("invalid product")
+)
+
+var productPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,31}$`)
+
+// ParseProduct normalizes a product code such as "savings" to "SAVINGS".
+// Products group accounts that share terms such as their interest rate.
+func ParseProduct(s string) (string, error) {
+	product := strings.ToUpper(strings.TrimSpace(s))
+	if !productPattern.MatchString(product) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProduct, s)
+	}
+	return product, nil
+}
+
+// InterestConfig is the interest paid on positive balances, set for one
+// account or for every account of a product. AnnualRate is a fraction, so
+// 0.0425 is 4.25% a year.
+type InterestConfig struct {
+	Scope       string             `json:"scope" gorm:"primaryKey"`
+	AnnualRate  Rate               `json:"annualRate" gorm:"not null"`
+	DayCount    DayCountConvention `json:"dayCount" gorm:"not null"`
+	Compounding Compounding        `json:"compounding" gorm:"not null"`
+	UpdatedAt   time.Time          `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+type InterestConfigUpdate struct {
+	AnnualRate  Rate               `json:"annualRate"`
+	DayCount    DayCountConvention `json:"dayCount"`
+	Compounding Compounding        `json:"compounding"`
+}
+
+func AccountInterestScope(accountID uuid.UUID) string {
+	return "account:" + accountID.String()
+}
+
+func ProductInterestScope(product string) string {
+	return "product:" + product
+}
+
+func (c *InterestConfig) Validate() error {
+	if c.AnnualRate < 0 || c.AnnualRate > rateFactor {
+		return fmt.Errorf("%w: the annual rate must be between 0 and 1", ErrInvalidInterestConfig)
+	}
+	switch c.DayCount {
+	case DayCountActual365, DayCountActual360, DayCountActualActual:
+	default:
+		return fmt.Errorf("%w: unknown day count convention %q", ErrInvalidInterestConfig, c.DayCount)
+	}
+	switch c.Compounding {
+	case CompoundDaily, CompoundMonthly, CompoundQuarterly, CompoundAnnually:
+	default:
+		return fmt.Errorf("%w: unknown compounding %q", ErrInvalidInterestConfig, c.Compounding)
+	}
+	return nil
+}
+
+// DailyInterest is the interest a balance earns over the given day, rounded
+// half-to-even to MoneyScale places. Zero and negative balances earn nothing.
+func (c *InterestConfig) DailyInterest(balance Money, day time.Time) Money {
+	if balance <= 0 {
+		return 0
+	}
+	numerator := new(big.Int).Mul(big.NewInt(int64(balance)), big.NewInt(int64(c.AnnualRate)))
+	denominator := big.NewInt(int64(c.DayCount.DaysInYear(day)) * rateFactor)
+	return Money(divRoundHalfEven(numerator, denominator).Int64())
+}
+
+func (d DayCountConvention) DaysInYear(day time.Time) int {
+	switch d {
+	case DayCountActual360:
+		return 360
+	case DayCountActualActual:
+		year := day.Year()
+		if time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+			return 366
+		}
+	}
+	return 365
+}
+
+// PeriodStart returns the start of the posting period that contains t, in
+// UTC. Interest accrued before it is due to be posted.
+func (c Compounding) PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	switch c {
+	case CompoundQuarterly:
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+	case CompoundAnnually:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// InterestAccrual is the interest an account earned on one UTC day, from its
+// balance at the end of that day. There is at most one per account and day,
+// so accruing a day again changes nothing. TransactionID is set once the
+// accrual has been posted.
+//
+// A Carried accrual is instead the part of a period's interest its posting
+// did not pay, having been rounded to the currency's minor unit. It is dated
+// the first day of the next period and posted with it, and is negative if the
+// posting rounded up.
+type InterestAccrual struct {
+	AccountID     uuid.UUID `json:"accountID" gorm:"type:uuid;primaryKey"`
+	Date          time.Time `json:"date" gorm:"type:date;primaryKey"`
+	Carried       bool      `json:"carried,omitempty" gorm:"primaryKey;default:false"`
+	Balance       Money     `json:"balance" gorm:"not null"`
+	AnnualRate    Rate      `json:"annualRate" gorm:"not null"`
+	Amount        Money     `json:"amount" gorm:"not null"`
+	TransactionID *string   `json:"transactionID,omitempty" gorm:"index"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// InterestPosting is carried by the DEPOSIT that credits an account with the
+// interest it accrued before PeriodEnd.
+type InterestPosting struct {
+	PeriodEnd time.Time `json:"periodEnd" bson:"periodEnd"`
+	Accruals  int       `json:"accruals" bson:"accruals"`
+}
+
+// InterestPostingID is the ID of the transaction posting an account's
+// interest for the period ending at periodEnd, so that posting a period again
+// finds the transaction already created. A period whose deposit failed is
+// posted again by its next attempt, which has an ID of its own.
+func InterestPostingID(accountID uuid.UUID, periodEnd time.Time, attempt int) string {
+	name := "interest:" + periodEnd.UTC().Format(time.RFC3339)
+	if attempt > 0 {
+		name = fmt.Sprintf("%s:%d", name, attempt)
+	}
+	return uuid.NewSHA1(accountID, []byte(name)).String()
+}
+
+// InterestStatus shows an account's effective interest terms and the interest
+// it has accrued but not yet been paid.
+type InterestStatus struct {
+	AccountID       uuid.UUID         `json:"accountID"`
+	Config          *InterestConfig   `json:"config"`
+	AccruedInterest Money             `json:"accruedInterest"`
+	Accruals        []InterestAccrual `json:"accruals"`
+}
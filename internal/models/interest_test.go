@@ -0,0 +1,88 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestConfigValidate(t *testing.T) {
+	valid := InterestConfig{AnnualRate: Rate(4_250_000), DayCount: DayCountActual365, Compounding: CompoundMonthly}
+	require.NoError(t, valid.Validate())
+
+	tooHigh := valid
+	tooHigh.AnnualRate = 2 * rateFactor
+	assert.ErrorIs(t, tooHigh.Validate(), ErrInvalidInterestConfig)
+
+	unknownDayCount := valid
+	unknownDayCount.DayCount = "30_360"
+	assert.ErrorIs(t, unknownDayCount.Validate(), ErrInvalidInterestConfig)
+
+	unknownCompounding := valid
+	unknownCompounding.Compounding = "HOURLY"
+	assert.ErrorIs(t, unknownCompounding.Validate(), ErrInvalidInterestConfig)
+}
+
+func TestDailyInterest(t *testing.T) {
+	day := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rate     Rate
+		dayCount DayCountConvention
+		day      time.Time
+		balance  Money
+		want     Money
+	}{
+		// 10000 * 0.0365 / 365 = 1
+		{"ACT/365", Rate(3_650_000), DayCountActual365, day, NewMoney(10000), NewMoney(1)},
+		// 10000 * 0.036 / 360 = 1
+		{"ACT/360", Rate(3_600_000), DayCountActual360, day, NewMoney(10000), NewMoney(1)},
+		// 10000 * 0.0366 / 366 = 1 in a leap year
+		{"ACT/ACT leap year", Rate(3_660_000), DayCountActualActual, leapDay, NewMoney(10000), NewMoney(1)},
+		{"ACT/ACT common year", Rate(3_650_000), DayCountActualActual, day, NewMoney(10000), NewMoney(1)},
+		// 1 * 0.05 / 365 = 0.000136..., rounded to four places
+		{"Rounds to four places", Rate(5_000_000), DayCountActual365, day, NewMoney(1), Money(1)},
+		{"Zero balance", Rate(5_000_000), DayCountActual365, day, 0, 0},
+		{"Negative balance", Rate(5_000_000), DayCountActual365, day, -NewMoney(500), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := InterestConfig{AnnualRate: tt.rate, DayCount: tt.dayCount, Compounding: CompoundMonthly}
+			assert.Equal(t, tt.want, config.DailyInterest(tt.balance, tt.day))
+		})
+	}
+}
+
+func TestCompoundingPeriodStart(t *testing.T) {
+	at := time.Date(2025, time.August, 17, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), CompoundDaily.PeriodStart(at))
+	assert.Equal(t, time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC), CompoundMonthly.PeriodStart(at))
+	assert.Equal(t, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), CompoundQuarterly.PeriodStart(at))
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), CompoundAnnually.PeriodStart(at))
+}
+
+func TestParseProduct(t *testing.T) {
+	product, err := ParseProduct(" savings ")
+	require.NoError(t, err)
+	assert.Equal(t, "SAVINGS", product)
+
+	for _, invalid := range []string{"", "1SAVER", "easy access", "SAVINGS-PLUS"} {
+		_, err := ParseProduct(invalid)
+		assert.ErrorIs(t, err, ErrInvalidProduct, invalid)
+	}
+}
+
+func TestInterestPostingID(t *testing.T) {
+	accountID := uuid.New()
+	periodEnd := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, InterestPostingID(accountID, periodEnd, 0), InterestPostingID(accountID, periodEnd.In(time.FixedZone("IST", 19800)), 0))
+	assert.NotEqual(t, InterestPostingID(accountID, periodEnd, 0), InterestPostingID(accountID, periodEnd.AddDate(0, 1, 0), 0))
+	assert.NotEqual(t, InterestPostingID(accountID, periodEnd, 0), InterestPostingID(accountID, periodEnd, 1))
+}
@@ -0,0 +1,308 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PostingDirection string
+
+const (
+	DEBIT  PostingDirection = "DEBIT"
+	CREDIT PostingDirection = "CREDIT"
+)
+
+// System ledger accounts are the bank's side of every journal. Customer ledger
+// accounts are identified by the account ID itself.
+const (
+	SystemLedgerAccountPrefix   = "system:"
+	CashLedgerAccount           = SystemLedgerAccountPrefix + "cash"
+	OpeningBalanceLedgerAccount = SystemLedgerAccountPrefix + "opening-balance"
+)
+
+var ErrJournalNotFound = errors.New("journal not found")
+
+type Journal struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	Postings  []Posting `json:"postings" gorm:"foreignKey:JournalID"`
+}
+
+type Posting struct {
+	ID            uuid.UUID        `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	JournalID     string           `json:"journalID" gorm:"index;not null"`
+	LedgerAccount string           `json:"ledgerAccount" gorm:"index;not null"`
+	Direction     PostingDirection `json:"direction" gorm:"not null"`
+	Amount        Money            `json:"amount" gorm:"not null"`
+	Currency      Currency         `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	CreatedAt     time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+type LedgerStatement struct {
+	AccountID     uuid.UUID `json:"accountID"`
+	Currency      Currency  `json:"currency"`
+	Balance       Money     `json:"balance"`
+	PostedBalance Money     `json:"postedBalance"`
+	InBalance     bool      `json:"inBalance"`
+	Postings      []Posting `json:"postings"`
+}
+
+func CustomerLedgerAccount(accountID uuid.UUID) string {
+	return accountID.String()
+}
+
+func IsSystemLedgerAccount(ledgerAccount string) bool {
+	return strings.HasPrefix(ledgerAccount, SystemLedgerAccountPrefix)
+}
+
+// NewTransactionJournal builds the balanced postings that a transaction makes
+// against the ledger. The journal shares the transaction's ID so that it can be
+// posted at most once.
+func NewTransactionJournal(tx *Transaction) (Journal, error) {
+	accountID, err := uuid.Parse(tx.AccountID)
+	if err != nil {
+		return Journal{}, fmt.Errorf("invalid account ID: %w", err)
+	}
+	customer := CustomerLedgerAccount(accountID)
+
+	if tx.Currency == "" {
+		return Journal{}, errors.New("transaction currency is required")
+	}
+
+	journal := Journal{ID: tx.ID}
+	switch tx.Type {
+	case DEPOSIT:
+		funding := CashLedgerAccount
+		if tx.Interest != nil {
+			funding = InterestExpenseLedgerAccount
+		}
+		journal.add(funding, DEBIT, tx.Amount, tx.Currency)
+		journal.add(customer, CREDIT, tx.Amount, tx.Currency)
+	case WITHDRAWL:
+		journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+		journal.add(CashLedgerAccount, CREDIT, tx.Amount, tx.Currency)
+	case TRANSFER:
+		destinationID, err := uuid.Parse(tx.DestinationAccountID)
+		if err != nil {
+			return Journal{}, fmt.Errorf("invalid destination account ID: %w", err)
+		}
+		if destinationID == accountID {
+			return Journal{}, errors.New("cannot transfer to the same account")
+		}
+		destination := CustomerLedgerAccount(destinationID)
+		if tx.FX == nil {
+			journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+			journal.add(destination, CREDIT, tx.Amount, tx.Currency)
+			break
+		}
+		if tx.FX.SourceAmount != tx.Amount || tx.FX.SourceCurrency != tx.Currency {
+			return Journal{}, errors.New("conversion does not match the transaction amount")
+		}
+		journal.addConversion(customer, destination, tx.FX)
+	case ADJUSTMENT:
+		if tx.Adjustment == nil {
+			return Journal{}, errors.New("adjustment details are required")
+		}
+		validate := tx.Adjustment.Validate
+		if IsReconciliationAdjustment(tx) {
+			validate = tx.Adjustment.ValidateCorrection
+		}
+		if err := validate(); err != nil {
+			return Journal{}, err
+		}
+		if tx.Adjustment.Direction == CREDIT {
+			journal.add(AdjustmentsLedgerAccount, DEBIT, tx.Amount, tx.Currency)
+			journal.add(customer, CREDIT, tx.Amount, tx.Currency)
+		} else {
+			journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+			journal.add(AdjustmentsLedgerAccount, CREDIT, tx.Amount, tx.Currency)
+		}
+	case CAPTURE:
+		journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+		journal.add(SettlementLedgerAccount, CREDIT, tx.Amount, tx.Currency)
+	case CLOSURE:
+		if tx.Closure == nil {
+			return Journal{}, errors.New("closure details are required")
+		}
+		payout, err := tx.Closure.PayoutLedgerAccount()
+		if err != nil {
+			return Journal{}, err
+		}
+		if payout == customer {
+			return Journal{}, errors.New("cannot pay a closing account out to itself")
+		}
+		journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+		journal.add(payout, CREDIT, tx.Amount, tx.Currency)
+	case FEE:
+		journal.add(customer, DEBIT, tx.Amount, tx.Currency)
+		journal.add(FeeIncomeLedgerAccount, CREDIT, tx.Amount, tx.Currency)
+	case AUTHORIZATION:
+		return Journal{}, errors.New("authorizations hold funds without posting to the ledger")
+	case REVERSAL:
+		return Journal{}, errors.New("reversal journals are built from the original transaction")
+	default:
+		return Journal{}, fmt.Errorf("invalid transaction type: %s", tx.Type)
+	}
+
+	return journal, journal.Validate()
+}
+
+// NewReversalJournal builds the journal of a reversal: every posting of the
+// original transaction's journal with its direction swapped, so posting it
+// exactly undoes the original, conversion spread included.
+func NewReversalJournal(reversal, original *Transaction) (Journal, error) {
+	if reversal.Type != REVERSAL || reversal.ReversalOf != original.ID {
+		return Journal{}, fmt.Errorf("transaction %s is not a reversal of %s", reversal.ID, original.ID)
+	}
+	originalJournal, err := NewTransactionJournal(original)
+	if err != nil {
+		return Journal{}, err
+	}
+
+	journal := Journal{ID: reversal.ID}
+	for _, posting := range originalJournal.Postings {
+		direction := DEBIT
+		if posting.Direction == DEBIT {
+			direction = CREDIT
+		}
+		journal.add(posting.LedgerAccount, direction, posting.Amount, posting.Currency)
+	}
+	return journal, journal.Validate()
+}
+
+// NewOpeningJournal records the balance an account was opened with, so that
+// the account's balance can be proven from its postings from day one.
+func NewOpeningJournal(account *Account) Journal {
+	journal := Journal{ID: OpeningJournalID(account.ID)}
+	customer := CustomerLedgerAccount(account.ID)
+
+	if account.Balance >= 0 {
+		journal.add(OpeningBalanceLedgerAccount, DEBIT, account.Balance, account.Currency)
+		journal.add(customer, CREDIT, account.Balance, account.Currency)
+	} else {
+		journal.add(customer, DEBIT, -account.Balance, account.Currency)
+		journal.add(OpeningBalanceLedgerAccount, CREDIT, -account.Balance, account.Currency)
+	}
+	return journal
+}
+
+// OpeningJournalID is the ID of the journal recording the balance the account
+// was opened with. Accounts opened with a zero balance have none.
+func OpeningJournalID(accountID uuid.UUID) string {
+	return "opening:" + accountID.String()
+}
+
+// addConversion moves the source amount into the bank's FX position in the
+// source currency and pays the destination out of the position in the target
+// currency, booking the spread as income.
+func (j *Journal) addConversion(source, destination string, fx *FXConversion) {
+	j.add(source, DEBIT, fx.SourceAmount, fx.SourceCurrency)
+	j.add(FXPositionLedgerAccount, CREDIT, fx.SourceAmount, fx.SourceCurrency)
+
+	j.add(FXPositionLedgerAccount, DEBIT, fx.TargetAmount+fx.SpreadAmount, fx.TargetCurrency)
+	j.add(destination, CREDIT, fx.TargetAmount, fx.TargetCurrency)
+	if fx.SpreadAmount != 0 {
+		j.add(FXSpreadLedgerAccount, CREDIT, fx.SpreadAmount, fx.TargetCurrency)
+	}
+}
+
+func (j *Journal) add(ledgerAccount string, direction PostingDirection, amount Money, currency Currency) {
+	j.Postings = append(j.Postings, Posting{
+		ID:            uuid.New(),
+		JournalID:     j.ID,
+		LedgerAccount: ledgerAccount,
+		Direction:     direction,
+		Amount:        amount,
+		Currency:      currency,
+	})
+}
+
+// Validate checks that the journal has at least one debit and one credit and
+// that, in every currency, its debits and credits add up to the same amount.
+func (j *Journal) Validate() error {
+	if j.ID == "" {
+		return errors.New("journal ID is required")
+	}
+	if len(j.Postings) < 2 {
+		return errors.New("journal must have at least two postings")
+	}
+
+	debits := map[Currency]Money{}
+	credits := map[Currency]Money{}
+	for _, posting := range j.Postings {
+		if posting.Amount < 0 {
+			return fmt.Errorf("posting amount must not be negative: %s", posting.Amount)
+		}
+		if posting.Currency == "" {
+			return errors.New("posting currency is required")
+		}
+		switch posting.Direction {
+		case DEBIT:
+			debits[posting.Currency] += posting.Amount
+		case CREDIT:
+			credits[posting.Currency] += posting.Amount
+		default:
+			return fmt.Errorf("invalid posting direction: %s", posting.Direction)
+		}
+	}
+
+	for _, currency := range j.currencies() {
+		if debits[currency] != credits[currency] {
+			return fmt.Errorf("journal %s is unbalanced in %s: debits %s, credits %s",
+				j.ID, currency, debits[currency], credits[currency])
+		}
+	}
+	return nil
+}
+
+// NetChange returns how much the journal changes a ledger account's balance.
+// Ledger accounts have a credit-normal balance, matching customer deposits.
+func (j *Journal) NetChange(ledgerAccount string) Money {
+	return PostingsBalance(j.Postings, ledgerAccount)
+}
+
+// PostingsBalance sums the credits minus the debits made to a ledger account.
+func PostingsBalance(postings []Posting, ledgerAccount string) Money {
+	var balance Money
+	for _, posting := range postings {
+		if posting.LedgerAccount != ledgerAccount {
+			continue
+		}
+		if posting.Direction == CREDIT {
+			balance += posting.Amount
+		} else {
+			balance -= posting.Amount
+		}
+	}
+	return balance
+}
+
+func (j *Journal) currencies() []Currency {
+	seen := map[Currency]bool{}
+	var currencies []Currency
+	for _, posting := range j.Postings {
+		if !seen[posting.Currency] {
+			seen[posting.Currency] = true
+			currencies = append(currencies, posting.Currency)
+		}
+	}
+	return currencies
+}
+
+// LedgerAccounts returns the distinct ledger accounts touched by the journal,
+// in the order they first appear.
+func (j *Journal) LedgerAccounts() []string {
+	seen := map[string]bool{}
+	var accounts []string
+	for _, posting := range j.Postings {
+		if !seen[posting.LedgerAccount] {
+			seen[posting.LedgerAccount] = true
+			accounts = append(accounts, posting.LedgerAccount)
+		}
+	}
+	return accounts
+}
@@ -0,0 +1,353 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionJournal(t *testing.T) {
+	accountID := uuid.New()
+	customer := CustomerLedgerAccount(accountID)
+
+	t.Run("Deposit credits the customer", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      DEPOSIT,
+			Amount:    NewMoney(100),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+		})
+		require.NoError(t, err)
+		assert.Len(t, journal.Postings, 2)
+		assert.Equal(t, NewMoney(100), journal.NetChange(customer))
+		assert.Equal(t, -NewMoney(100), journal.NetChange(CashLedgerAccount))
+	})
+
+	t.Run("Interest deposit is paid by interest expense", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      DEPOSIT,
+			Amount:    NewMoney(3),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			Interest:  &InterestPosting{Accruals: 30},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(3), journal.NetChange(customer))
+		assert.Equal(t, -NewMoney(3), journal.NetChange(InterestExpenseLedgerAccount))
+		assert.Equal(t, Money(0), journal.NetChange(CashLedgerAccount))
+	})
+
+	t.Run("Fee debits the customer to fee income", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      FEE,
+			Amount:    NewMoney(2),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			Fee:       &FeeCharge{RuleID: uuid.New(), Period: "2025-03"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(2), journal.NetChange(customer))
+		assert.Equal(t, NewMoney(2), journal.NetChange(FeeIncomeLedgerAccount))
+	})
+
+	t.Run("Withdrawal debits the customer", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      WITHDRAWL,
+			Amount:    NewMoney(40),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(40), journal.NetChange(customer))
+		assert.Equal(t, []string{customer, CashLedgerAccount}, journal.LedgerAccounts())
+	})
+
+	t.Run("Transfer moves money between customers", func(t *testing.T) {
+		destinationID := uuid.New()
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 TRANSFER,
+			Amount:               NewMoney(75),
+			AccountID:            accountID.String(),
+			DestinationAccountID: destinationID.String(),
+			Currency:             DefaultCurrency,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(75), journal.NetChange(customer))
+		assert.Equal(t, NewMoney(75), journal.NetChange(CustomerLedgerAccount(destinationID)))
+	})
+
+	t.Run("Adjustment moves the balance in its direction", func(t *testing.T) {
+		adjustment := &Adjustment{
+			Direction:     DEBIT,
+			ReasonCode:    AdjustmentBankError,
+			Justification: "duplicate deposit on 3 March",
+			OperatorID:    "ops-17",
+		}
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:         uuid.New().String(),
+			Type:       ADJUSTMENT,
+			Amount:     NewMoney(30),
+			AccountID:  accountID.String(),
+			Currency:   DefaultCurrency,
+			Adjustment: adjustment,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(30), journal.NetChange(customer))
+		assert.Equal(t, NewMoney(30), journal.NetChange(AdjustmentsLedgerAccount))
+
+		adjustment.Direction = CREDIT
+		journal, err = NewTransactionJournal(&Transaction{
+			ID:         uuid.New().String(),
+			Type:       ADJUSTMENT,
+			Amount:     NewMoney(30),
+			AccountID:  accountID.String(),
+			Currency:   DefaultCurrency,
+			Adjustment: adjustment,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(30), journal.NetChange(customer))
+	})
+
+	t.Run("Capture debits the customer into settlement", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      CAPTURE,
+			Amount:    NewMoney(20),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			HoldID:    uuid.New().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(20), journal.NetChange(customer))
+		assert.Equal(t, NewMoney(20), journal.NetChange(SettlementLedgerAccount))
+	})
+
+	t.Run("Closure pays the balance out", func(t *testing.T) {
+		payoutID := uuid.New()
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      CLOSURE,
+			Amount:    NewMoney(35),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			Closure:   &AccountClosure{PayoutAccountID: payoutID.String(), Reason: "customer request"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -NewMoney(35), journal.NetChange(customer))
+		assert.Equal(t, NewMoney(35), journal.NetChange(CustomerLedgerAccount(payoutID)))
+	})
+
+	t.Run("Closure without payout account credits suspense", func(t *testing.T) {
+		journal, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      CLOSURE,
+			Amount:    NewMoney(35),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			Closure:   &AccountClosure{Reason: "customer request"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, NewMoney(35), journal.NetChange(SuspenseLedgerAccount))
+	})
+
+	t.Run("Closure paid out to itself", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      CLOSURE,
+			Amount:    NewMoney(35),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+			Closure:   &AccountClosure{PayoutAccountID: accountID.String(), Reason: "customer request"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Authorization Posts No Journal", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      AUTHORIZATION,
+			Amount:    NewMoney(20),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Adjustment Without Details", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{
+			ID:        uuid.New().String(),
+			Type:      ADJUSTMENT,
+			Amount:    NewMoney(30),
+			AccountID: accountID.String(),
+			Currency:  DefaultCurrency,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Transfer To Same Account", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{
+			ID:                   "tx",
+			Type:                 TRANSFER,
+			Amount:               NewMoney(1),
+			AccountID:            accountID.String(),
+			DestinationAccountID: accountID.String(),
+			Currency:             DefaultCurrency,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Account ID", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{ID: "tx", Type: DEPOSIT, Amount: NewMoney(1), AccountID: "invalid"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing Currency", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{ID: "tx", Type: DEPOSIT, Amount: 1, AccountID: accountID.String()})
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Transaction Type", func(t *testing.T) {
+		_, err := NewTransactionJournal(&Transaction{ID: "tx", Type: "INVALID", Amount: NewMoney(1), AccountID: accountID.String()})
+		assert.Error(t, err)
+	})
+}
+
+func TestReversalJournal(t *testing.T) {
+	sourceID, destinationID := uuid.New(), uuid.New()
+	original := &Transaction{
+		ID:                   uuid.New().String(),
+		Type:                 TRANSFER,
+		Amount:               NewMoney(100),
+		Currency:             "USD",
+		AccountID:            sourceID.String(),
+		DestinationAccountID: destinationID.String(),
+		FX: &FXConversion{
+			SourceAmount:   NewMoney(100),
+			SourceCurrency: "USD",
+			TargetAmount:   NewMoney(90),
+			TargetCurrency: "EUR",
+			SpreadAmount:   MustParseMoney("0.50"),
+		},
+	}
+	reversal := &Transaction{
+		ID:         uuid.New().String(),
+		Type:       REVERSAL,
+		Amount:     original.Amount,
+		Currency:   original.Currency,
+		AccountID:  original.AccountID,
+		ReversalOf: original.ID,
+	}
+
+	t.Run("Undoes every posting", func(t *testing.T) {
+		originalJournal, err := NewTransactionJournal(original)
+		require.NoError(t, err)
+		journal, err := NewReversalJournal(reversal, original)
+		require.NoError(t, err)
+
+		assert.Equal(t, reversal.ID, journal.ID)
+		for _, ledgerAccount := range originalJournal.LedgerAccounts() {
+			assert.Equal(t, -originalJournal.NetChange(ledgerAccount), journal.NetChange(ledgerAccount), ledgerAccount)
+		}
+		assert.Equal(t, MustParseMoney("-0.50"), journal.NetChange(FXSpreadLedgerAccount))
+	})
+
+	t.Run("Not Linked To Original", func(t *testing.T) {
+		other := *reversal
+		other.ReversalOf = uuid.New().String()
+		_, err := NewReversalJournal(&other, original)
+		assert.Error(t, err)
+	})
+
+	t.Run("Reversal Needs Original", func(t *testing.T) {
+		_, err := NewTransactionJournal(reversal)
+		assert.Error(t, err)
+	})
+}
+
+func TestOpeningJournal(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance Money
+	}{
+		{name: "Positive Opening Balance", balance: NewMoney(250)},
+		{name: "Negative Opening Balance", balance: MustParseMoney("-30.25")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &Account{ID: uuid.New(), Balance: tt.balance, Currency: "EUR"}
+			journal := NewOpeningJournal(account)
+
+			require.NoError(t, journal.Validate())
+			assert.Equal(t, tt.balance, journal.NetChange(CustomerLedgerAccount(account.ID)))
+		})
+	}
+}
+
+func TestJournalValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		journal     Journal
+		errContains string
+	}{
+		{
+			name:        "Missing ID",
+			journal:     Journal{},
+			errContains: "ID is required",
+		},
+		{
+			name: "Single Posting",
+			journal: Journal{ID: "j1", Postings: []Posting{
+				{LedgerAccount: CashLedgerAccount, Direction: DEBIT, Amount: NewMoney(10), Currency: DefaultCurrency},
+			}},
+			errContains: "at least two postings",
+		},
+		{
+			name: "Unbalanced",
+			journal: Journal{ID: "j1", Postings: []Posting{
+				{LedgerAccount: CashLedgerAccount, Direction: DEBIT, Amount: NewMoney(10), Currency: DefaultCurrency},
+				{LedgerAccount: "acc", Direction: CREDIT, Amount: NewMoney(5), Currency: DefaultCurrency},
+			}},
+			errContains: "unbalanced",
+		},
+		{
+			name: "Unbalanced Across Currencies",
+			journal: Journal{ID: "j1", Postings: []Posting{
+				{LedgerAccount: CashLedgerAccount, Direction: DEBIT, Amount: NewMoney(10), Currency: "USD"},
+				{LedgerAccount: "acc", Direction: CREDIT, Amount: NewMoney(10), Currency: "EUR"},
+			}},
+			errContains: "unbalanced in USD",
+		},
+		{
+			name: "Missing Currency",
+			journal: Journal{ID: "j1", Postings: []Posting{
+				{LedgerAccount: CashLedgerAccount, Direction: DEBIT, Amount: NewMoney(10)},
+				{LedgerAccount: "acc", Direction: CREDIT, Amount: NewMoney(10)},
+			}},
+			errContains: "currency is required",
+		},
+		{
+			name: "Invalid Direction",
+			journal: Journal{ID: "j1", Postings: []Posting{
+				{LedgerAccount: CashLedgerAccount, Direction: "SIDEWAYS", Amount: NewMoney(10), Currency: DefaultCurrency},
+				{LedgerAccount: "acc", Direction: CREDIT, Amount: NewMoney(10), Currency: DefaultCurrency},
+			}},
+			errContains: "invalid posting direction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.journal.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
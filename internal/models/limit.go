@@ -0,0 +1,175 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountTier string
+
+const (
+	TierStandard AccountTier = "STANDARD"
+	TierPremium  AccountTier = "PREMIUM"
+	TierBusiness AccountTier = "BUSINESS"
+)
+
+func (t AccountTier) Valid() bool {
+	_, ok := DefaultTierLimits[t]
+	return ok
+}
+
+// LimitCode names the limit a rejected transaction would have exceeded.
+type LimitCode string
+
+const (
+	LimitVelocity      LimitCode = "VELOCITY_LIMIT_EXCEEDED"
+	LimitDailyCount    LimitCode = "DAILY_COUNT_LIMIT_EXCEEDED"
+	LimitDailyAmount   LimitCode = "DAILY_AMOUNT_LIMIT_EXCEEDED"
+	LimitMonthlyCount  LimitCode = "MONTHLY_COUNT_LIMIT_EXCEEDED"
+	LimitMonthlyAmount LimitCode = "MONTHLY_AMOUNT_LIMIT_EXCEEDED"
+)
+
+var (
+	ErrLimitExceeded  = errors.New("transaction limit exceeded")
+	ErrLimitsNotFound = errors.New("limits not found")
+	ErrInvalidLimits  = errors.New("invalid limits")
+)
+
+// LimitExceededError is returned for a transaction that would exceed one of
+// its account's limits. It matches ErrLimitExceeded with errors.Is.
+type LimitExceededError struct {
+	Code  LimitCode
+	Limit string
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: limit of %s reached", e.Code, e.Limit)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// LimitedTransactionTypes are the transactions that take money out of an
+// account at its holder's request and so count towards its limits.
+var LimitedTransactionTypes = []TransactionType{WITHDRAWL, TRANSFER, AUTHORIZATION}
+
+func (t TransactionType) Limited() bool {
+	for _, limited := range LimitedTransactionTypes {
+		if t == limited {
+			return true
+		}
+	}
+	return false
+}
+
+// TransactionLimits caps the limited transactions of a tier or of a single
+// account, whose limits replace its tier's. Amounts are in the account's
+// currency and days and months are UTC calendar days and months. A zero cap
+// means no cap.
+type TransactionLimits struct {
+	Scope          string    `json:"scope" gorm:"primaryKey"`
+	DailyAmount    Money     `json:"dailyAmount" gorm:"not null;default:0" validate:"gte=0"`
+	DailyCount     int       `json:"dailyCount" gorm:"not null;default:0" validate:"gte=0"`
+	MonthlyAmount  Money     `json:"monthlyAmount" gorm:"not null;default:0" validate:"gte=0"`
+	MonthlyCount   int       `json:"monthlyCount" gorm:"not null;default:0" validate:"gte=0"`
+	PerMinuteCount int       `json:"perMinuteCount" gorm:"not null;default:0" validate:"gte=0"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// DefaultTierLimits apply to a tier until an admin sets its limits.
+var DefaultTierLimits = map[AccountTier]TransactionLimits{
+	TierStandard: {
+		DailyAmount: NewMoney(2000), DailyCount: 20,
+		MonthlyAmount: NewMoney(20000), MonthlyCount: 300,
+		PerMinuteCount: 5,
+	},
+	TierPremium: {
+		DailyAmount: NewMoney(10000), DailyCount: 50,
+		MonthlyAmount: NewMoney(100000), MonthlyCount: 1000,
+		PerMinuteCount: 10,
+	},
+	TierBusiness: {
+		DailyAmount: NewMoney(100000), DailyCount: 500,
+		MonthlyAmount: NewMoney(1000000), MonthlyCount: 10000,
+		PerMinuteCount: 60,
+	},
+}
+
+func TierLimitScope(tier AccountTier) string {
+	return "tier:" + string(tier)
+}
+
+func AccountLimitScope(accountID uuid.UUID) string {
+	return "account:" + accountID.String()
+}
+
+func (l *TransactionLimits) Validate() error {
+	if l.DailyAmount < 0 || l.DailyCount < 0 || l.MonthlyAmount < 0 || l.MonthlyCount < 0 || l.PerMinuteCount < 0 {
+		return fmt.Errorf("%w: caps must not be negative", ErrInvalidLimits)
+	}
+	return nil
+}
+
+// Check returns a *LimitExceededError if a transaction of the given amount,
+// on top of the usage so far, would exceed any of the limits.
+func (l *TransactionLimits) Check(usage LimitUsage, amount Money) error {
+	switch {
+	case l.PerMinuteCount > 0 && usage.MinuteCount+1 > l.PerMinuteCount:
+		return &LimitExceededError{Code: LimitVelocity, Limit: fmt.Sprintf("%d per minute", l.PerMinuteCount)}
+	case l.DailyCount > 0 && usage.DailyCount+1 > l.DailyCount:
+		return &LimitExceededError{Code: LimitDailyCount, Limit: fmt.Sprintf("%d per day", l.DailyCount)}
+	case l.DailyAmount > 0 && usage.DailyAmount+amount > l.DailyAmount:
+		return &LimitExceededError{Code: LimitDailyAmount, Limit: l.DailyAmount.String() + " per day"}
+	case l.MonthlyCount > 0 && usage.MonthlyCount+1 > l.MonthlyCount:
+		return &LimitExceededError{Code: LimitMonthlyCount, Limit: fmt.Sprintf("%d per month", l.MonthlyCount)}
+	case l.MonthlyAmount > 0 && usage.MonthlyAmount+amount > l.MonthlyAmount:
+		return &LimitExceededError{Code: LimitMonthlyAmount, Limit: l.MonthlyAmount.String() + " per month"}
+	}
+	return nil
+}
+
+// LimitWindows are the starts of the periods limits are counted over.
+type LimitWindows struct {
+	Minute time.Time
+	Day    time.Time
+	Month  time.Time
+}
+
+func NewLimitWindows(now time.Time) LimitWindows {
+	now = now.UTC()
+	return LimitWindows{
+		Minute: now.Add(-time.Minute),
+		Day:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Month:  time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Earliest is the start of the longest window.
+func (w LimitWindows) Earliest() time.Time {
+	earliest := w.Month
+	if w.Minute.Before(earliest) {
+		earliest = w.Minute
+	}
+	return earliest
+}
+
+// LimitUsage is an account's limited transactions in each window.
+type LimitUsage struct {
+	MinuteCount   int   `json:"minuteCount" bson:"minuteCount"`
+	DailyCount    int   `json:"dailyCount" bson:"dailyCount"`
+	DailyAmount   Money `json:"dailyAmount" bson:"dailyAmount"`
+	MonthlyCount  int   `json:"monthlyCount" bson:"monthlyCount"`
+	MonthlyAmount Money `json:"monthlyAmount" bson:"monthlyAmount"`
+}
+
+// LimitStatus shows an account's limits and how much of them is used.
+type LimitStatus struct {
+	AccountID uuid.UUID         `json:"accountID"`
+	Tier      AccountTier       `json:"tier"`
+	Limits    TransactionLimits `json:"limits"`
+	Usage     LimitUsage        `json:"usage"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionLimitsCheck(t *testing.T) {
+	limits := TransactionLimits{
+		DailyAmount:    NewMoney(500),
+		DailyCount:     10,
+		MonthlyAmount:  NewMoney(2000),
+		MonthlyCount:   50,
+		PerMinuteCount: 3,
+	}
+
+	tests := []struct {
+		name   string
+		usage  LimitUsage
+		amount Money
+		code   LimitCode
+	}{
+		{"Within Limits", LimitUsage{MinuteCount: 2, DailyCount: 9, DailyAmount: NewMoney(400), MonthlyCount: 49, MonthlyAmount: NewMoney(1900)}, NewMoney(100), ""},
+		{"Velocity", LimitUsage{MinuteCount: 3}, NewMoney(1), LimitVelocity},
+		{"Daily Count", LimitUsage{DailyCount: 10}, NewMoney(1), LimitDailyCount},
+		{"Daily Amount", LimitUsage{DailyAmount: NewMoney(450)}, NewMoney(51), LimitDailyAmount},
+		{"Monthly Count", LimitUsage{MonthlyCount: 50}, NewMoney(1), LimitMonthlyCount},
+		{"Monthly Amount", LimitUsage{MonthlyAmount: NewMoney(1950)}, NewMoney(51), LimitMonthlyAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(tt.usage, tt.amount)
+			if tt.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var limitErr *LimitExceededError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tt.code, limitErr.Code)
+			assert.ErrorIs(t, err, ErrLimitExceeded)
+		})
+	}
+
+	t.Run("Zero Means No Cap", func(t *testing.T) {
+		unlimited := TransactionLimits{}
+		assert.NoError(t, unlimited.Check(LimitUsage{MinuteCount: 1000, DailyAmount: NewMoney(1e6)}, NewMoney(1e6)))
+	})
+}
+
+func TestLimitWindows(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 30, 0, time.UTC)
+	windows := NewLimitWindows(now)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), windows.Day)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), windows.Month)
+	assert.Equal(t, now.Add(-time.Minute), windows.Minute)
+	assert.Equal(t, windows.Minute, windows.Earliest())
+}
+
+func TestLimitedTransactionTypes(t *testing.T) {
+	assert.True(t, WITHDRAWL.Limited())
+	assert.True(t, TRANSFER.Limited())
+	assert.True(t, AUTHORIZATION.Limited())
+	assert.False(t, DEPOSIT.Limited())
+	assert.False(t, CAPTURE.Limited())
+	assert.False(t, REVERSAL.Limited())
+}
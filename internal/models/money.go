@@ -0,0 +1,188 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Money is an exact amount held as a fixed-point decimal with MoneyScale
+// decimal places: 12.34 is stored as 123400. It is an int64 in Postgres and
+// Mongo and a plain decimal number in JSON. The extra places beyond a
+// currency's minor unit hold sub-unit results such as accrued interest; amounts
+// that move money are checked with HasPrecision before they are accepted.
+type Money int64
+
+const MoneyScale = 4
+
+// DefaultCurrencyDecimals is the minor unit of most currencies; see
+// Currency.Decimals for the exceptions.
+const DefaultCurrencyDecimals = 2
+
+const moneyFactor = 10000
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+func NewMoney(units int64) Money {
+	return Money(units * moneyFactor)
+}
+
+// ParseMoney parses a decimal string such as "12.34" or "-0.5" exactly. It
+// rejects exponents and more than MoneyScale decimal places rather than
+// rounding them away.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(s, ".")
+	if whole == "" && fraction == "" || hasPoint && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(fraction) > MoneyScale {
+		return 0, fmt.Errorf("%w: more than %d decimal places", ErrInvalidAmount, MoneyScale)
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	var units, minor int64
+	var err error
+	if whole != "" {
+		units, err = strconv.ParseInt(whole, 10, 64)
+		if err != nil || units > math.MaxInt64/moneyFactor {
+			return 0, fmt.Errorf("%w: out of range", ErrInvalidAmount)
+		}
+	}
+	if fraction != "" {
+		minor, _ = strconv.ParseInt(fraction+strings.Repeat("0", MoneyScale-len(fraction)), 10, 64)
+	}
+
+	amount := Money(units*moneyFactor + minor)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func MustParseMoney(s string) Money {
+	amount, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount with at least two decimal places and only as many
+// more as it needs, e.g. "12.30" or "0.0125".
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	fraction := fmt.Sprintf("%0*d", MoneyScale, value%moneyFactor)
+	fraction = strings.TrimRight(fraction, "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	return fmt.Sprintf("%s%d.%s", sign, value/moneyFactor, fraction)
+}
+
+// HasPrecision reports whether the amount fits in the given number of decimal
+// places without rounding.
+func (m Money) HasPrecision(places int) bool {
+	return m.Round(places) == m
+}
+
+// Round rounds the amount to the given number of decimal places using
+// round-half-to-even (banker's rounding), so repeated rounding has no bias.
+func (m Money) Round(places int) Money {
+	if places >= MoneyScale {
+		return m
+	}
+	if places < 0 {
+		places = 0
+	}
+	step := int64(math.Pow10(MoneyScale - places))
+	value := int64(m)
+	quotient, remainder := value/step, value%step
+	if remainder < 0 {
+		remainder = -remainder
+	}
+
+	roundAway := remainder*2 > step || remainder*2 == step && quotient%2 != 0
+	if roundAway {
+		if value < 0 {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Money(quotient * step)
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number or a quoted decimal string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" {
+		return nil
+	}
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(int64(m))
+}
+
+// UnmarshalBSONValue reads the int64 that MarshalBSONValue writes. Floating
+// point amounts written before Money existed are converted when the database
+// is opened, so they are rejected here rather than rounded.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.Int64:
+		*m = Money(raw.Int64())
+	case bsontype.Int32:
+		*m = Money(raw.Int32())
+	case bsontype.Null:
+		*m = 0
+	default:
+		return fmt.Errorf("cannot decode %s into Money", t)
+	}
+	return nil
+}
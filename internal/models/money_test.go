@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			input       string
+			expected    Money
+			expectError bool
+		}{
+			{input: "0", expected: 0},
+			{input: "12.34", expected: 123400},
+			{input: "-0.5", expected: -5000},
+			{input: "+7", expected: 70000},
+			{input: ".25", expected: 2500},
+			{input: "0.0001", expected: 1},
+			{input: "0.00001", expectError: true},
+			{input: "1e2", expectError: true},
+			{input: "12.", expectError: true},
+			{input: "", expectError: true},
+			{input: "abc", expectError: true},
+			{input: "99999999999999999999", expectError: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				amount, err := ParseMoney(tt.input)
+				if tt.expectError {
+					assert.ErrorIs(t, err, ErrInvalidAmount)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, amount)
+			})
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "12.30", MustParseMoney("12.3").String())
+		assert.Equal(t, "0.0125", MustParseMoney("0.0125").String())
+		assert.Equal(t, "-4.00", NewMoney(-4).String())
+		assert.Equal(t, "-0.05", MustParseMoney("-0.05").String())
+	})
+
+	t.Run("No Floating Point Drift", func(t *testing.T) {
+		var balance Money
+		for i := 0; i < 3; i++ {
+			balance += MustParseMoney("0.10")
+		}
+		assert.Equal(t, MustParseMoney("0.30"), balance)
+		assert.Equal(t, "0.30", balance.String())
+	})
+
+	t.Run("Round Half To Even", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			places   int
+			expected string
+		}{
+			{input: "1.005", places: 2, expected: "1.00"},
+			{input: "1.015", places: 2, expected: "1.02"},
+			{input: "1.0151", places: 2, expected: "1.02"},
+			{input: "-1.005", places: 2, expected: "-1.00"},
+			{input: "-1.015", places: 2, expected: "-1.02"},
+			{input: "2.5", places: 0, expected: "2.00"},
+			{input: "3.5", places: 0, expected: "4.00"},
+			{input: "1.2345", places: 4, expected: "1.2345"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				assert.Equal(t, tt.expected, MustParseMoney(tt.input).Round(tt.places).String())
+			})
+		}
+	})
+
+	t.Run("Precision", func(t *testing.T) {
+		assert.True(t, MustParseMoney("10.25").HasPrecision(2))
+		assert.False(t, MustParseMoney("10.255").HasPrecision(2))
+		assert.True(t, NewMoney(10).HasPrecision(0))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var tx Transaction
+		require.NoError(t, json.Unmarshal([]byte(`{"amount": 100.10}`), &tx))
+		assert.Equal(t, MustParseMoney("100.10"), tx.Amount)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"amount": "0.30"}`), &tx))
+		assert.Equal(t, MustParseMoney("0.30"), tx.Amount)
+
+		assert.Error(t, json.Unmarshal([]byte(`{"amount": 1.123456}`), &tx))
+
+		body, err := json.Marshal(struct {
+			Amount Money `json:"amount"`
+		}{Amount: MustParseMoney("0.3")})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"amount": 0.30}`, string(body))
+	})
+
+	t.Run("BSON", func(t *testing.T) {
+		type document struct {
+			Amount Money `bson:"amount"`
+		}
+
+		data, err := bson.Marshal(document{Amount: MustParseMoney("12.34")})
+		require.NoError(t, err)
+
+		var decoded document
+		require.NoError(t, bson.Unmarshal(data, &decoded))
+		assert.Equal(t, MustParseMoney("12.34"), decoded.Amount)
+
+		legacy, err := bson.Marshal(bson.M{"amount": 0.1 + 0.2})
+		require.NoError(t, err)
+		assert.Error(t, bson.Unmarshal(legacy, &decoded))
+	})
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "PENDING"
+	OutboxSent    OutboxStatus = "SENT"
+)
+
+// Outbox tracks delivery of a transaction's event to the queue. It is stored
+// on the transaction document itself so the transaction and its pending event
+// are written in one atomic insert, and a relay publishes it afterwards.
+type Outbox struct {
+	Status        OutboxStatus `json:"status" bson:"status"`
+	Attempts      int          `json:"attempts" bson:"attempts"`
+	NextAttemptAt time.Time    `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastError     string       `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	SentAt        time.Time    `json:"sentAt,omitempty" bson:"sentAt,omitempty"`
+}
+
+func NewOutbox(now time.Time) *Outbox {
+	return &Outbox{Status: OutboxPending, NextAttemptAt: now}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidOverdraftLimit = errors.New("invalid overdraft limit")
+
+// OverdraftLimitChange is the audit record of an admin setting an account's
+// overdraft limit.
+type OverdraftLimitChange struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AccountID     uuid.UUID `json:"accountID" gorm:"type:uuid;not null;index"`
+	PreviousLimit Money     `json:"previousLimit" gorm:"not null"`
+	NewLimit      Money     `json:"newLimit" gorm:"not null"`
+	Reason        string    `json:"reason" gorm:"not null"`
+	OperatorID    string    `json:"operatorID" gorm:"not null"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+type OverdraftLimitUpdate struct {
+	Limit  Money  `json:"limit" validate:"gte=0"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+// OverdraftUsage shows how much of an account's overdraft is in use.
+type OverdraftUsage struct {
+	AccountID uuid.UUID `json:"accountID"`
+	Currency  Currency  `json:"currency"`
+	Limit     Money     `json:"limit"`
+	Used      Money     `json:"used"`
+	Remaining Money     `json:"remaining"`
+}
+
+func NewOverdraftUsage(account *Account) OverdraftUsage {
+	used := account.OverdraftUsed()
+	remaining := account.OverdraftLimit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return OverdraftUsage{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Limit:     account.OverdraftLimit,
+		Used:      used,
+		Remaining: remaining,
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdraftUsage(t *testing.T) {
+	t.Run("Positive Balance", func(t *testing.T) {
+		account := &Account{Balance: NewMoney(30), OverdraftLimit: NewMoney(100)}
+		require.NoError(t, account.AfterFind(nil))
+		assert.Equal(t, NewMoney(130), account.AvailableBalance)
+
+		usage := NewOverdraftUsage(account)
+		assert.Equal(t, Money(0), usage.Used)
+		assert.Equal(t, NewMoney(100), usage.Remaining)
+	})
+
+	t.Run("Overdrawn With Holds", func(t *testing.T) {
+		account := &Account{Balance: NewMoney(-40), HeldBalance: NewMoney(10), OverdraftLimit: NewMoney(100)}
+		require.NoError(t, account.AfterFind(nil))
+		assert.Equal(t, NewMoney(50), account.AvailableBalance)
+
+		usage := NewOverdraftUsage(account)
+		assert.Equal(t, NewMoney(50), usage.Used)
+		assert.Equal(t, NewMoney(50), usage.Remaining)
+	})
+
+	t.Run("Limit Lowered Below Usage", func(t *testing.T) {
+		account := &Account{Balance: NewMoney(-40), OverdraftLimit: NewMoney(25)}
+		usage := NewOverdraftUsage(account)
+		assert.Equal(t, NewMoney(40), usage.Used)
+		assert.Equal(t, Money(0), usage.Remaining)
+	})
+}
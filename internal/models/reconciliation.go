@@ -0,0 +1,195 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CaseStatus string
+
+const (
+	CaseOpen     CaseStatus = "OPEN"
+	CaseResolved CaseStatus = "RESOLVED"
+)
+
+// Reasons a transaction is listed on a discrepancy: the transaction store and
+// the ledger disagree about whether it happened.
+const (
+	IssuePostedNotSucceeded  = "POSTED_NOT_SUCCEEDED"
+	IssueSucceededNotPosted  = "SUCCEEDED_NOT_POSTED"
+	IssuePostedNoTransaction = "POSTED_NO_TRANSACTION"
+)
+
+// ReconciliationOperatorID is the operator recorded on the adjustments
+// reconciliation posts to correct balances.
+const ReconciliationOperatorID = "reconciliation"
+
+var (
+	ErrReconciliationNotFound = errors.New("reconciliation report not found")
+	ErrCaseNotFound           = errors.New("investigation case not found")
+	ErrCaseResolved           = errors.New("investigation case is already resolved")
+	ErrInvalidCaseResolution  = errors.New("invalid investigation case resolution")
+)
+
+// ReconciliationReport is the result of checking every account's stored
+// balance against the balance its history adds up to: the balance it was
+// opened with plus every successful transaction. Accounts with transactions
+// still being processed are skipped, since the two stores are expected to
+// disagree about them for a moment.
+type ReconciliationReport struct {
+	ID              uuid.UUID     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	StartedAt       time.Time     `json:"startedAt" gorm:"not null"`
+	FinishedAt      time.Time     `json:"finishedAt" gorm:"not null"`
+	AccountsChecked int           `json:"accountsChecked" gorm:"not null"`
+	AccountsSkipped int           `json:"accountsSkipped" gorm:"not null"`
+	OpenCases       bool          `json:"openCases" gorm:"not null"`
+	Discrepancies   []Discrepancy `json:"discrepancies" gorm:"serializer:json;not null"`
+}
+
+// Discrepancy is an account whose stored balance (Actual) is not what its
+// history adds up to (Expected). Ledger is the balance of its ledger postings,
+// which tells which store is wrong when it agrees with one of the others.
+// AdjustmentID is the adjustment posted to correct it, if any.
+type Discrepancy struct {
+	AccountID    uuid.UUID                `json:"accountID"`
+	Currency     Currency                 `json:"currency"`
+	Expected     Money                    `json:"expected"`
+	Actual       Money                    `json:"actual"`
+	Ledger       Money                    `json:"ledger"`
+	Difference   Money                    `json:"difference"`
+	Transactions []DiscrepancyTransaction `json:"transactions"`
+	CaseID       *uuid.UUID               `json:"caseID,omitempty"`
+	AdjustmentID string                   `json:"adjustmentID,omitempty"`
+}
+
+// Correctable reports whether an adjustment can bring the account back in
+// balance. An adjustment moves the stored and the ledger balance together, so
+// it cannot correct them while they disagree with each other.
+func (d *Discrepancy) Correctable() bool {
+	return d.Ledger == d.Actual
+}
+
+// Correction returns the ADJUSTMENT that moves the account's balance by the
+// difference back to what its history adds up to. balanceAsOf is when the
+// account was last updated before it was checked.
+func (d *Discrepancy) Correction(reportID uuid.UUID, balanceAsOf, now time.Time) *Transaction {
+	direction := CREDIT
+	if d.Difference > 0 {
+		direction = DEBIT
+	}
+	return &Transaction{
+		ID:        ReconciliationAdjustmentID(d.AccountID, balanceAsOf),
+		Type:      ADJUSTMENT,
+		Amount:    d.Difference.Abs(),
+		Currency:  d.Currency,
+		AccountID: d.AccountID.String(),
+		Status:    PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Adjustment: &Adjustment{
+			Direction:     direction,
+			ReasonCode:    AdjustmentReconciliation,
+			Justification: fmt.Sprintf("Reconciliation report %s found the balance %s off its history", reportID, d.Difference),
+			OperatorID:    ReconciliationOperatorID,
+		},
+	}
+}
+
+// ReconciliationAdjustmentID is the ID of the adjustment correcting an
+// account's balance as of its last update, so that runs which check the same
+// balance at once post a single correction for it. The correction updates the
+// account, so a discrepancy found after it gets a new ID.
+func ReconciliationAdjustmentID(accountID uuid.UUID, balanceAsOf time.Time) string {
+	return uuid.NewSHA1(accountID, []byte("reconciliation:"+balanceAsOf.UTC().Format(time.RFC3339Nano))).String()
+}
+
+// IsReconciliationAdjustment reports whether tx is an adjustment correcting a
+// balance to its history.
+func IsReconciliationAdjustment(tx *Transaction) bool {
+	return tx.Type == ADJUSTMENT && tx.Adjustment != nil && tx.Adjustment.ReasonCode == AdjustmentReconciliation
+}
+
+// DiscrepancyTransaction is a transaction the transaction store and the
+// ledger disagree about.
+type DiscrepancyTransaction struct {
+	ID     string            `json:"id"`
+	Type   TransactionType   `json:"type,omitempty"`
+	Status TransactionStatus `json:"status,omitempty"`
+	Amount Money             `json:"amount"`
+	Issue  string            `json:"issue"`
+}
+
+// InvestigationCase is opened for an account a reconciliation found out of
+// balance, for an operator to look into and fix by hand. An account has at
+// most one open case; later reconciliations that find it still out of
+// balance add their reports to it.
+type InvestigationCase struct {
+	ID           uuid.UUID                `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AccountID    uuid.UUID                `json:"accountID" gorm:"type:uuid;not null;index;uniqueIndex:idx_investigation_case_open,where:status = 'OPEN'"`
+	Status       CaseStatus               `json:"status" gorm:"not null;index"`
+	ReportIDs    []uuid.UUID              `json:"reportIDs" gorm:"serializer:json;not null"`
+	Expected     Money                    `json:"expected" gorm:"not null"`
+	Actual       Money                    `json:"actual" gorm:"not null"`
+	Difference   Money                    `json:"difference" gorm:"not null"`
+	Transactions []DiscrepancyTransaction `json:"transactions" gorm:"serializer:json;not null"`
+	OpenedAt     time.Time                `json:"openedAt" gorm:"not null"`
+	UpdatedAt    time.Time                `json:"updatedAt" gorm:"autoUpdateTime"`
+	ResolvedAt   *time.Time               `json:"resolvedAt,omitempty"`
+	ResolvedBy   string                   `json:"resolvedBy,omitempty"`
+	Resolution   string                   `json:"resolution,omitempty"`
+}
+
+type CaseResolution struct {
+	Resolution string `json:"resolution"`
+	OperatorID string `json:"operatorID"`
+}
+
+func (r *CaseResolution) Validate() error {
+	if strings.TrimSpace(r.Resolution) == "" {
+		return fmt.Errorf("%w: a resolution is required", ErrInvalidCaseResolution)
+	}
+	if strings.TrimSpace(r.OperatorID) == "" {
+		return fmt.Errorf("%w: an operator is required", ErrInvalidCaseResolution)
+	}
+	return nil
+}
+
+// NewInvestigationCase opens a case for the discrepancy found by a report.
+func NewInvestigationCase(reportID uuid.UUID, discrepancy *Discrepancy, now time.Time) InvestigationCase {
+	c := InvestigationCase{
+		ID:        uuid.New(),
+		AccountID: discrepancy.AccountID,
+		Status:    CaseOpen,
+		OpenedAt:  now,
+	}
+	c.Update(reportID, discrepancy)
+	return c
+}
+
+// Update records what a later report found for the case's account.
+func (c *InvestigationCase) Update(reportID uuid.UUID, discrepancy *Discrepancy) {
+	c.ReportIDs = append(c.ReportIDs, reportID)
+	c.Expected = discrepancy.Expected
+	c.Actual = discrepancy.Actual
+	c.Difference = discrepancy.Difference
+	c.Transactions = discrepancy.Transactions
+}
+
+// Resolve closes the case with the operator's account of how it was fixed.
+func (c *InvestigationCase) Resolve(resolution CaseResolution, now time.Time) error {
+	if c.Status == CaseResolved {
+		return ErrCaseResolved
+	}
+	if err := resolution.Validate(); err != nil {
+		return err
+	}
+	c.Status = CaseResolved
+	c.ResolvedAt = &now
+	c.ResolvedBy = resolution.OperatorID
+	c.Resolution = resolution.Resolution
+	return nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvestigationCase(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+	discrepancy := &Discrepancy{AccountID: uuid.New(), Expected: NewMoney(100), Actual: NewMoney(90), Difference: -NewMoney(10)}
+
+	investigation := NewInvestigationCase(first, discrepancy, now)
+	assert.Equal(t, CaseOpen, investigation.Status)
+	assert.Equal(t, discrepancy.AccountID, investigation.AccountID)
+	assert.Equal(t, -NewMoney(10), investigation.Difference)
+
+	investigation.Update(second, &Discrepancy{AccountID: discrepancy.AccountID, Expected: NewMoney(100), Actual: NewMoney(80), Difference: -NewMoney(20)})
+	assert.Equal(t, []uuid.UUID{first, second}, investigation.ReportIDs)
+	assert.Equal(t, -NewMoney(20), investigation.Difference)
+
+	err := investigation.Resolve(CaseResolution{Resolution: "posted the missing withdrawal"}, now)
+	assert.ErrorIs(t, err, ErrInvalidCaseResolution)
+	err = investigation.Resolve(CaseResolution{OperatorID: "ops-1"}, now)
+	assert.ErrorIs(t, err, ErrInvalidCaseResolution)
+
+	require.NoError(t, investigation.Resolve(CaseResolution{Resolution: "posted the missing withdrawal", OperatorID: "ops-1"}, now))
+	assert.Equal(t, CaseResolved, investigation.Status)
+	assert.Equal(t, "ops-1", investigation.ResolvedBy)
+	require.NotNil(t, investigation.ResolvedAt)
+
+	err = investigation.Resolve(CaseResolution{Resolution: "again", OperatorID: "ops-1"}, now)
+	assert.ErrorIs(t, err, ErrCaseResolved)
+}
+
+func TestDiscrepancyCorrection(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	reportID := uuid.New()
+	discrepancy := &Discrepancy{AccountID: uuid.New(), Currency: DefaultCurrency, Expected: NewMoney(100), Actual: NewMoney(90), Ledger: NewMoney(90), Difference: -NewMoney(10)}
+	assert.True(t, discrepancy.Correctable())
+
+	balanceAsOf := now.Add(-time.Hour)
+	correction := discrepancy.Correction(reportID, balanceAsOf, now)
+	assert.Equal(t, ADJUSTMENT, correction.Type)
+	assert.Equal(t, NewMoney(10), correction.Amount)
+	assert.Equal(t, CREDIT, correction.Adjustment.Direction)
+	require.NoError(t, correction.Adjustment.ValidateCorrection())
+	assert.ErrorContains(t, correction.Adjustment.Validate(), "reason code")
+	assert.Contains(t, correction.Adjustment.Justification, reportID.String())
+	assert.True(t, IsReconciliationAdjustment(correction))
+
+	// Another run that checks the same balance posts the same correction.
+	assert.Equal(t, ReconciliationAdjustmentID(discrepancy.AccountID, balanceAsOf), correction.ID)
+	assert.Equal(t, correction.ID, discrepancy.Correction(uuid.New(), balanceAsOf, now.Add(time.Minute)).ID)
+	assert.NotEqual(t, correction.ID, discrepancy.Correction(reportID, now, now).ID)
+
+	discrepancy.Difference, discrepancy.Actual = NewMoney(10), NewMoney(110)
+	assert.Equal(t, DEBIT, discrepancy.Correction(reportID, balanceAsOf, now).Adjustment.Direction)
+	assert.False(t, discrepancy.Correctable())
+
+	correction.Adjustment.OperatorID = "ops-1"
+	assert.ErrorContains(t, correction.Adjustment.ValidateCorrection(), "operator")
+	correction.Adjustment.ReasonCode = AdjustmentBankError
+	assert.False(t, IsReconciliationAdjustment(correction))
+	assert.ErrorContains(t, correction.Adjustment.ValidateCorrection(), "reason code")
+}
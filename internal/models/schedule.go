@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxScheduleAhead is how far in the future a transaction may be scheduled.
+const MaxScheduleAhead = 366 * 24 * time.Hour
+
+var (
+	ErrInvalidSchedule         = errors.New("invalid transaction schedule")
+	ErrTransactionNotScheduled = errors.New("transaction is not scheduled")
+)
+
+// Schedulable reports whether transactions of this type may be deferred with
+// an execution time.
+func (t TransactionType) Schedulable() bool {
+	switch t {
+	case DEPOSIT, WITHDRAWL, TRANSFER:
+		return true
+	}
+	return false
+}
+
+// IsScheduled reports whether the transaction is to be executed later rather
+// than processed at once.
+func (tx *Transaction) IsScheduled() bool {
+	return tx.ExecuteAt != nil
+}
+
+// ValidateSchedule checks a requested execution time against now. A
+// transaction without one is processed at once and is always valid.
+func (tx *Transaction) ValidateSchedule(now time.Time) error {
+	if tx.ExecuteAt == nil {
+		return nil
+	}
+	if !tx.Type.Schedulable() {
+		return fmt.Errorf("%w: %s transactions cannot be scheduled", ErrInvalidSchedule, tx.Type)
+	}
+	if !tx.ExecuteAt.After(now) {
+		return fmt.Errorf("%w: the execution time must be in the future", ErrInvalidSchedule)
+	}
+	if tx.ExecuteAt.Sub(now) > MaxScheduleAhead {
+		return fmt.Errorf("%w: the execution time must be within a year", ErrInvalidSchedule)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionValidateSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		executeAt := now.Add(d)
+		return &executeAt
+	}
+
+	assert.NoError(t, (&Transaction{Type: AUTHORIZATION}).ValidateSchedule(now))
+	assert.NoError(t, (&Transaction{Type: TRANSFER, ExecuteAt: at(24 * time.Hour)}).ValidateSchedule(now))
+
+	tests := map[string]*Transaction{
+		"past":          {Type: DEPOSIT, ExecuteAt: at(-time.Second)},
+		"now":           {Type: DEPOSIT, ExecuteAt: at(0)},
+		"too far":       {Type: DEPOSIT, ExecuteAt: at(MaxScheduleAhead + time.Hour)},
+		"adjustment":    {Type: ADJUSTMENT, ExecuteAt: at(time.Hour)},
+		"authorization": {Type: AUTHORIZATION, ExecuteAt: at(time.Hour)},
+	}
+	for name, tx := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, tx.ValidateSchedule(now), ErrInvalidSchedule)
+		})
+	}
+}
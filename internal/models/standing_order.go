@@ -0,0 +1,315 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StandingOrderFrequency string
+type StandingOrderStatus string
+type StandingOrderRunStatus string
+
+const (
+	// WEEKLY runs every seven days from the start date. MONTHLY runs on
+	// DayOfMonth, or on the last day of months too short to have it.
+	// END_OF_MONTH runs on the last day of every month.
+	FrequencyWeekly     StandingOrderFrequency = "WEEKLY"
+	FrequencyMonthly    StandingOrderFrequency = "MONTHLY"
+	FrequencyEndOfMonth StandingOrderFrequency = "END_OF_MONTH"
+)
+
+const (
+	StandingOrderActive    StandingOrderStatus = "ACTIVE"
+	StandingOrderSuspended StandingOrderStatus = "SUSPENDED"
+	StandingOrderCompleted StandingOrderStatus = "COMPLETED"
+	StandingOrderCancelled StandingOrderStatus = "CANCELLED"
+)
+
+// A run is PENDING while the worker processes its transaction, and FAILED
+// without a transaction when the transaction could not be created at all.
+const (
+	StandingOrderRunPending StandingOrderRunStatus = "PENDING"
+	StandingOrderRunSuccess StandingOrderRunStatus = "SUCCESS"
+	StandingOrderRunFailed  StandingOrderRunStatus = "FAILED"
+)
+
+// DefaultMaxStandingOrderFailures is how many runs in a row may fail before a
+// standing order is suspended.
+const DefaultMaxStandingOrderFailures = 3
+
+var (
+	ErrStandingOrderNotFound = errors.New("standing order not found")
+	ErrInvalidStandingOrder  = errors.New("invalid standing order")
+	ErrStandingOrderEnded    = errors.New("standing order has ended")
+	ErrStandingOrderChanged  = errors.New("standing order changed while it was run")
+)
+
+// StandingOrder is a template for a transaction that is created on a
+// recurring schedule. NextRunAt is the occurrence due next. It runs until it
+// reaches EndAt or MaxRuns (zero meaning no limit), and is suspended after too
+// many consecutive failures.
+type StandingOrder struct {
+	ID                   uuid.UUID              `json:"id" gorm:"type:uuid;primaryKey"`
+	AccountID            uuid.UUID              `json:"accountID" gorm:"type:uuid;not null;index"`
+	DestinationAccountID *uuid.UUID             `json:"destinationAccountID,omitempty" gorm:"type:uuid"`
+	Type                 TransactionType        `json:"type" gorm:"not null"`
+	Amount               Money                  `json:"amount" gorm:"not null"`
+	Currency             Currency               `json:"currency" gorm:"type:char(3);not null"`
+	Frequency            StandingOrderFrequency `json:"frequency" gorm:"not null"`
+	DayOfMonth           int                    `json:"dayOfMonth,omitempty" gorm:"not null;default:0"`
+	StartAt              time.Time              `json:"startAt" gorm:"not null"`
+	EndAt                *time.Time             `json:"endAt,omitempty"`
+	MaxRuns              int                    `json:"maxRuns,omitempty" gorm:"not null;default:0"`
+	NextRunAt            time.Time              `json:"nextRunAt" gorm:"not null;index"`
+	RunCount             int                    `json:"runCount" gorm:"not null;default:0"`
+	ConsecutiveFailures  int                    `json:"consecutiveFailures" gorm:"not null;default:0"`
+	Status               StandingOrderStatus    `json:"status" gorm:"not null;index"`
+	CreatedAt            time.Time              `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time              `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// StandingOrderRun records one occurrence of a standing order and the
+// transaction created for it.
+type StandingOrderRun struct {
+	ID              uuid.UUID              `json:"id" gorm:"type:uuid;primaryKey"`
+	StandingOrderID uuid.UUID              `json:"standingOrderID" gorm:"type:uuid;not null;uniqueIndex:idx_standing_order_run"`
+	ScheduledFor    time.Time              `json:"scheduledFor" gorm:"not null;uniqueIndex:idx_standing_order_run"`
+	TransactionID   string                 `json:"transactionID,omitempty"`
+	Status          StandingOrderRunStatus `json:"status" gorm:"not null;index"`
+	Error           string                 `json:"error,omitempty"`
+	CreatedAt       time.Time              `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time              `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+type StandingOrderCreate struct {
+	AccountID            string                 `json:"accountID" binding:"required"`
+	DestinationAccountID string                 `json:"destinationAccountID,omitempty"`
+	Type                 TransactionType        `json:"type" binding:"required"`
+	Amount               Money                  `json:"amount" binding:"required"`
+	Currency             Currency               `json:"currency,omitempty"`
+	Frequency            StandingOrderFrequency `json:"frequency" binding:"required"`
+	DayOfMonth           int                    `json:"dayOfMonth,omitempty"`
+	StartAt              time.Time              `json:"startAt" binding:"required"`
+	EndAt                *time.Time             `json:"endAt,omitempty"`
+	MaxRuns              int                    `json:"maxRuns,omitempty"`
+}
+
+// StandingOrderUpdate changes an order's amount or end, or pauses (SUSPENDED)
+// and resumes (ACTIVE) it. Fields left out are unchanged.
+type StandingOrderUpdate struct {
+	Amount  *Money               `json:"amount,omitempty"`
+	EndAt   *time.Time           `json:"endAt,omitempty"`
+	MaxRuns *int                 `json:"maxRuns,omitempty"`
+	Status  *StandingOrderStatus `json:"status,omitempty"`
+}
+
+// NewStandingOrder builds an active standing order from a request, first due
+// on its first occurrence at or after the start date.
+func NewStandingOrder(request StandingOrderCreate) (StandingOrder, error) {
+	accountID, err := uuid.Parse(request.AccountID)
+	if err != nil {
+		return StandingOrder{}, fmt.Errorf("%w: invalid account ID", ErrInvalidStandingOrder)
+	}
+	order := StandingOrder{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Type:       request.Type,
+		Amount:     request.Amount,
+		Currency:   request.Currency,
+		Frequency:  request.Frequency,
+		DayOfMonth: request.DayOfMonth,
+		StartAt:    request.StartAt,
+		EndAt:      request.EndAt,
+		MaxRuns:    request.MaxRuns,
+		Status:     StandingOrderActive,
+	}
+	if request.DestinationAccountID != "" {
+		destinationID, err := uuid.Parse(request.DestinationAccountID)
+		if err != nil {
+			return StandingOrder{}, fmt.Errorf("%w: invalid destination account ID", ErrInvalidStandingOrder)
+		}
+		order.DestinationAccountID = &destinationID
+	}
+	if err := order.Validate(); err != nil {
+		return StandingOrder{}, err
+	}
+	order.NextRunAt = order.firstOccurrence()
+	if order.ended() {
+		return StandingOrder{}, fmt.Errorf("%w: it ends before its first run", ErrInvalidStandingOrder)
+	}
+	return order, nil
+}
+
+// Validate checks the order's template and schedule.
+func (o *StandingOrder) Validate() error {
+	switch o.Type {
+	case DEPOSIT, WITHDRAWL:
+		if o.DestinationAccountID != nil {
+			return fmt.Errorf("%w: a destination account is only allowed for transfers", ErrInvalidStandingOrder)
+		}
+	case TRANSFER:
+		if o.DestinationAccountID == nil {
+			return fmt.Errorf("%w: a transfer needs a destination account", ErrInvalidStandingOrder)
+		}
+		if *o.DestinationAccountID == o.AccountID {
+			return fmt.Errorf("%w: source and destination accounts must differ", ErrInvalidStandingOrder)
+		}
+	default:
+		return fmt.Errorf("%w: %s transactions cannot be standing orders", ErrInvalidStandingOrder, o.Type)
+	}
+	if o.Amount <= 0 {
+		return fmt.Errorf("%w: the amount must be greater than 0", ErrInvalidStandingOrder)
+	}
+
+	switch o.Frequency {
+	case FrequencyWeekly, FrequencyEndOfMonth:
+		if o.DayOfMonth != 0 {
+			return fmt.Errorf("%w: a day of the month is only allowed for monthly orders", ErrInvalidStandingOrder)
+		}
+	case FrequencyMonthly:
+		if o.DayOfMonth < 1 || o.DayOfMonth > 31 {
+			return fmt.Errorf("%w: a monthly order needs a day of the month from 1 to 31", ErrInvalidStandingOrder)
+		}
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidStandingOrder, o.Frequency)
+	}
+
+	if o.StartAt.IsZero() {
+		return fmt.Errorf("%w: a start date is required", ErrInvalidStandingOrder)
+	}
+	if o.EndAt != nil && o.EndAt.Before(o.StartAt) {
+		return fmt.Errorf("%w: the end date must not be before the start date", ErrInvalidStandingOrder)
+	}
+	if o.MaxRuns < 0 {
+		return fmt.Errorf("%w: the number of runs must not be negative", ErrInvalidStandingOrder)
+	}
+	return nil
+}
+
+// Transaction builds the transaction for the occurrence due at scheduledFor.
+// Its ID is derived from the order and the occurrence, so a run repeated after
+// a crash finds the transaction it already created instead of paying twice.
+func (o *StandingOrder) Transaction(scheduledFor time.Time) *Transaction {
+	tx := &Transaction{
+		ID:        o.RunTransactionID(scheduledFor),
+		Type:      o.Type,
+		Amount:    o.Amount,
+		Currency:  o.Currency,
+		AccountID: o.AccountID.String(),
+		Status:    PENDING,
+	}
+	if o.DestinationAccountID != nil {
+		tx.DestinationAccountID = o.DestinationAccountID.String()
+	}
+	return tx
+}
+
+func (o *StandingOrder) RunTransactionID(scheduledFor time.Time) string {
+	return uuid.NewSHA1(o.ID, []byte(scheduledFor.UTC().Format(time.RFC3339Nano))).String()
+}
+
+// Advance moves the order past the occurrence it has just run, completing it
+// if that was its last.
+func (o *StandingOrder) Advance() {
+	o.RunCount++
+	o.NextRunAt = o.nextOccurrence(o.NextRunAt)
+	if o.Status == StandingOrderActive && o.ended() {
+		o.Status = StandingOrderCompleted
+	}
+}
+
+// RecordOutcome counts a run's success or failure, suspending an active order
+// once maxFailures runs in a row have failed.
+func (o *StandingOrder) RecordOutcome(succeeded bool, maxFailures int) {
+	if succeeded {
+		o.ConsecutiveFailures = 0
+		return
+	}
+	o.ConsecutiveFailures++
+	if o.Status == StandingOrderActive && maxFailures > 0 && o.ConsecutiveFailures >= maxFailures {
+		o.Status = StandingOrderSuspended
+	}
+}
+
+// Apply makes the changes in update as of now. A resumed order starts again
+// from its next occurrence after now, skipping the ones it missed.
+func (o *StandingOrder) Apply(update StandingOrderUpdate, now time.Time) error {
+	if o.Status == StandingOrderCompleted || o.Status == StandingOrderCancelled {
+		return ErrStandingOrderEnded
+	}
+	if update.Amount != nil {
+		o.Amount = *update.Amount
+	}
+	if update.EndAt != nil {
+		o.EndAt = update.EndAt
+	}
+	if update.MaxRuns != nil {
+		o.MaxRuns = *update.MaxRuns
+	}
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	if update.Status != nil {
+		switch *update.Status {
+		case StandingOrderSuspended:
+			o.Status = StandingOrderSuspended
+		case StandingOrderActive:
+			if o.Status == StandingOrderSuspended {
+				o.ConsecutiveFailures = 0
+				for o.NextRunAt.Before(now) {
+					o.NextRunAt = o.nextOccurrence(o.NextRunAt)
+				}
+			}
+			o.Status = StandingOrderActive
+		default:
+			return fmt.Errorf("%w: status can only be changed to ACTIVE or SUSPENDED", ErrInvalidStandingOrder)
+		}
+	}
+	if o.ended() {
+		return fmt.Errorf("%w: it would end before its next run", ErrInvalidStandingOrder)
+	}
+	return nil
+}
+
+// ended reports whether the order has no occurrence left to run.
+func (o *StandingOrder) ended() bool {
+	if o.MaxRuns > 0 && o.RunCount >= o.MaxRuns {
+		return true
+	}
+	return o.EndAt != nil && o.NextRunAt.After(*o.EndAt)
+}
+
+func (o *StandingOrder) firstOccurrence() time.Time {
+	if o.Frequency == FrequencyWeekly {
+		return o.StartAt
+	}
+	occurrence := o.occurrenceIn(o.StartAt.Year(), o.StartAt.Month())
+	if occurrence.Before(o.StartAt) {
+		occurrence = o.occurrenceIn(o.StartAt.Year(), o.StartAt.Month()+1)
+	}
+	return occurrence
+}
+
+func (o *StandingOrder) nextOccurrence(previous time.Time) time.Time {
+	if o.Frequency == FrequencyWeekly {
+		return previous.AddDate(0, 0, 7)
+	}
+	return o.occurrenceIn(previous.Year(), previous.Month()+1)
+}
+
+// occurrenceIn returns the monthly occurrence in the given month, at the start
+// date's time of day. A month past December rolls over into the next year.
+func (o *StandingOrder) occurrenceIn(year int, month time.Month) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, o.StartAt.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := lastDay
+	if o.Frequency == FrequencyMonthly && o.DayOfMonth < lastDay {
+		day = o.DayOfMonth
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day,
+		o.StartAt.Hour(), o.StartAt.Minute(), o.StartAt.Second(), o.StartAt.Nanosecond(), o.StartAt.Location())
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStandingOrderSchedule(t *testing.T) {
+	start := time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+	request := func(frequency StandingOrderFrequency, day int) StandingOrderCreate {
+		return StandingOrderCreate{
+			AccountID:  uuid.NewString(),
+			Type:       WITHDRAWL,
+			Amount:     NewMoney(25),
+			Frequency:  frequency,
+			DayOfMonth: day,
+			StartAt:    start,
+		}
+	}
+	occurrences := func(order StandingOrder, n int) []time.Time {
+		var runs []time.Time
+		for i := 0; i < n; i++ {
+			runs = append(runs, order.NextRunAt)
+			order.Advance()
+		}
+		return runs
+	}
+
+	t.Run("weekly", func(t *testing.T) {
+		order, err := NewStandingOrder(request(FrequencyWeekly, 0))
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)}, occurrences(order, 3))
+	})
+
+	t.Run("monthly on a day short months lack", func(t *testing.T) {
+		order, err := NewStandingOrder(request(FrequencyMonthly, 31))
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC),
+		}, occurrences(order, 4))
+	})
+
+	t.Run("monthly on a day already passed starts next month", func(t *testing.T) {
+		order, err := NewStandingOrder(request(FrequencyMonthly, 5))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC), order.NextRunAt)
+	})
+
+	t.Run("end of month rolls over the year", func(t *testing.T) {
+		r := request(FrequencyEndOfMonth, 0)
+		r.StartAt = time.Date(2024, 11, 30, 9, 0, 0, 0, time.UTC)
+		order, err := NewStandingOrder(r)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 11, 30, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+		}, occurrences(order, 3))
+	})
+
+	t.Run("completes after its last run", func(t *testing.T) {
+		r := request(FrequencyWeekly, 0)
+		r.MaxRuns = 2
+		order, err := NewStandingOrder(r)
+		require.NoError(t, err)
+		order.Advance()
+		assert.Equal(t, StandingOrderActive, order.Status)
+		order.Advance()
+		assert.Equal(t, StandingOrderCompleted, order.Status)
+
+		endAt := start.AddDate(0, 0, 10)
+		r = request(FrequencyWeekly, 0)
+		r.EndAt = &endAt
+		order, err = NewStandingOrder(r)
+		require.NoError(t, err)
+		order.Advance()
+		assert.Equal(t, StandingOrderActive, order.Status)
+		order.Advance()
+		assert.Equal(t, StandingOrderCompleted, order.Status)
+	})
+}
+
+func TestNewStandingOrderValidation(t *testing.T) {
+	accountID := uuid.NewString()
+	valid := StandingOrderCreate{
+		AccountID: accountID,
+		Type:      TRANSFER,
+		Amount:    NewMoney(25),
+		Frequency: FrequencyWeekly,
+		StartAt:   time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC),
+	}
+	valid.DestinationAccountID = uuid.NewString()
+	_, err := NewStandingOrder(valid)
+	require.NoError(t, err)
+
+	tests := map[string]func(r *StandingOrderCreate){
+		"transfer without destination": func(r *StandingOrderCreate) { r.DestinationAccountID = "" },
+		"transfer to itself":           func(r *StandingOrderCreate) { r.DestinationAccountID = accountID },
+		"adjustment":                   func(r *StandingOrderCreate) { r.Type = ADJUSTMENT },
+		"zero amount":                  func(r *StandingOrderCreate) { r.Amount = 0 },
+		"unknown frequency":            func(r *StandingOrderCreate) { r.Frequency = "DAILY" },
+		"monthly without day":          func(r *StandingOrderCreate) { r.Frequency = FrequencyMonthly },
+		"weekly with day":              func(r *StandingOrderCreate) { r.DayOfMonth = 3 },
+		"negative runs":                func(r *StandingOrderCreate) { r.MaxRuns = -1 },
+		"ends before it starts": func(r *StandingOrderCreate) {
+			endAt := r.StartAt.Add(-time.Hour)
+			r.EndAt = &endAt
+		},
+	}
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := valid
+			change(&r)
+			_, err := NewStandingOrder(r)
+			assert.ErrorIs(t, err, ErrInvalidStandingOrder)
+		})
+	}
+}
+
+func TestStandingOrderRecordOutcome(t *testing.T) {
+	order := StandingOrder{Status: StandingOrderActive}
+	order.RecordOutcome(false, 2)
+	assert.Equal(t, StandingOrderActive, order.Status)
+	order.RecordOutcome(true, 2)
+	assert.Equal(t, 0, order.ConsecutiveFailures)
+
+	order.RecordOutcome(false, 2)
+	order.RecordOutcome(false, 2)
+	assert.Equal(t, 2, order.ConsecutiveFailures)
+	assert.Equal(t, StandingOrderSuspended, order.Status)
+}
+
+func TestStandingOrderApply(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	order := StandingOrder{
+		AccountID:           uuid.New(),
+		Type:                DEPOSIT,
+		Amount:              NewMoney(25),
+		Frequency:           FrequencyWeekly,
+		StartAt:             time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		NextRunAt:           time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC),
+		ConsecutiveFailures: 3,
+		Status:              StandingOrderSuspended,
+	}
+
+	amount := NewMoney(40)
+	active := StandingOrderActive
+	require.NoError(t, order.Apply(StandingOrderUpdate{Amount: &amount, Status: &active}, now))
+	assert.Equal(t, amount, order.Amount)
+	assert.Equal(t, StandingOrderActive, order.Status)
+	assert.Equal(t, 0, order.ConsecutiveFailures)
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), order.NextRunAt)
+
+	cancelled := StandingOrderCancelled
+	assert.ErrorIs(t, order.Apply(StandingOrderUpdate{Status: &cancelled}, now), ErrInvalidStandingOrder)
+
+	order.Status = StandingOrderCompleted
+	assert.ErrorIs(t, order.Apply(StandingOrderUpdate{Amount: &amount}, now), ErrStandingOrderEnded)
+}
+
+func TestStandingOrderTransaction(t *testing.T) {
+	destinationID := uuid.New()
+	order := StandingOrder{
+		ID:                   uuid.New(),
+		AccountID:            uuid.New(),
+		DestinationAccountID: &destinationID,
+		Type:                 TRANSFER,
+		Amount:               NewMoney(25),
+		Currency:             DefaultCurrency,
+	}
+	at := time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)
+
+	tx := order.Transaction(at)
+	assert.Equal(t, destinationID.String(), tx.DestinationAccountID)
+	assert.Equal(t, PENDING, tx.Status)
+	assert.Equal(t, tx.ID, order.Transaction(at).ID)
+	assert.NotEqual(t, tx.ID, order.Transaction(at.AddDate(0, 0, 7)).ID)
+}
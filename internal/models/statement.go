@@ -0,0 +1,275 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStatementNotFound      = errors.New("statement not found")
+	ErrInvalidStatementPeriod = errors.New("invalid statement period")
+	ErrInvalidStatementFormat = errors.New("statement format must be json or csv")
+)
+
+type StatementFormat string
+
+const (
+	StatementJSON StatementFormat = "json"
+	StatementCSV  StatementFormat = "csv"
+)
+
+// StatementFormats are the formats every statement is rendered in when it is
+// generated.
+var StatementFormats = []StatementFormat{StatementJSON, StatementCSV}
+
+func ParseStatementFormat(format string) (StatementFormat, error) {
+	switch StatementFormat(format) {
+	case StatementJSON, StatementCSV:
+		return StatementFormat(format), nil
+	}
+	return "", ErrInvalidStatementFormat
+}
+
+func (f StatementFormat) ContentType() string {
+	if f == StatementCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// Statement is an account's monthly statement: every successful transaction
+// processed in the month with the balance after it. OpeningBalance is the
+// balance before the first line, which for an account opened during the month
+// is the balance it was opened with. A statement never changes once
+// generated, and its documents are stored so that every download returns the
+// same content.
+type Statement struct {
+	ID             uuid.UUID       `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AccountID      uuid.UUID       `json:"accountID" gorm:"type:uuid;not null;uniqueIndex:idx_statement_account_period"`
+	Period         string          `json:"period" gorm:"not null;uniqueIndex:idx_statement_account_period"`
+	PeriodStart    time.Time       `json:"periodStart" gorm:"not null"`
+	PeriodEnd      time.Time       `json:"periodEnd" gorm:"not null"`
+	Currency       Currency        `json:"currency" gorm:"type:char(3);not null"`
+	OpeningBalance Money           `json:"openingBalance" gorm:"not null"`
+	ClosingBalance Money           `json:"closingBalance" gorm:"not null"`
+	TotalCredits   Money           `json:"totalCredits" gorm:"not null"`
+	TotalDebits    Money           `json:"totalDebits" gorm:"not null"`
+	TotalFees      Money           `json:"totalFees" gorm:"not null"`
+	TotalInterest  Money           `json:"totalInterest" gorm:"not null"`
+	Lines          []StatementLine `json:"lines,omitempty" gorm:"serializer:json;not null"`
+	GeneratedAt    time.Time       `json:"generatedAt" gorm:"not null"`
+
+	Documents []StatementDocument `json:"-" gorm:"foreignKey:StatementID"`
+}
+
+// StatementLine is one transaction on a statement. Amount is what it changed
+// the account's balance by, negative for debits.
+type StatementLine struct {
+	TransactionID string          `json:"transactionID"`
+	Date          time.Time       `json:"date"`
+	Type          TransactionType `json:"type"`
+	Description   string          `json:"description"`
+	Amount        Money           `json:"amount"`
+	Balance       Money           `json:"balance"`
+}
+
+// StatementDocument is a statement rendered in one format when it was
+// generated.
+type StatementDocument struct {
+	StatementID uuid.UUID       `json:"statementID" gorm:"type:uuid;primaryKey"`
+	Format      StatementFormat `json:"format" gorm:"primaryKey"`
+	Content     []byte          `json:"-" gorm:"not null"`
+	Checksum    string          `json:"checksum" gorm:"not null"`
+	CreatedAt   time.Time       `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// SettledTransaction is a successful transaction and what it changed an
+// account's balance by.
+type SettledTransaction struct {
+	Transaction *Transaction
+	Change      Money
+}
+
+// StatementPeriod parses a statement period given as YYYY-MM and returns the
+// UTC month it covers.
+func StatementPeriod(period string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q is not a YYYY-MM month", ErrInvalidStatementPeriod, period)
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// TransactionChange returns what a successful transaction changed the
+// account's balance by, from the journal it posted. original is the
+// transaction a REVERSAL undoes and is ignored otherwise.
+func TransactionChange(tx, original *Transaction, accountID uuid.UUID) (Money, error) {
+	var journal Journal
+	var err error
+	if tx.Type == REVERSAL {
+		if original == nil {
+			return 0, fmt.Errorf("reversal %s needs its original transaction", tx.ID)
+		}
+		journal, err = NewReversalJournal(tx, original)
+	} else {
+		journal, err = NewTransactionJournal(tx)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return journal.NetChange(CustomerLedgerAccount(accountID)), nil
+}
+
+// NewStatement builds the account's statement for a period from its opening
+// balance and the period's entries, in the order they were processed.
+func NewStatement(account *Account, period string, start, end time.Time, opening Money, entries []SettledTransaction, now time.Time) Statement {
+	statement := Statement{
+		AccountID:      account.ID,
+		Period:         period,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		Currency:       account.Currency,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Lines:          []StatementLine{},
+		GeneratedAt:    now,
+	}
+	for _, entry := range entries {
+		tx := entry.Transaction
+		statement.ClosingBalance += entry.Change
+		if entry.Change >= 0 {
+			statement.TotalCredits += entry.Change
+		} else {
+			statement.TotalDebits -= entry.Change
+		}
+		switch {
+		case tx.Type == FEE:
+			statement.TotalFees -= entry.Change
+		case tx.Type == DEPOSIT && tx.Interest != nil:
+			statement.TotalInterest += entry.Change
+		}
+		statement.Lines = append(statement.Lines, StatementLine{
+			TransactionID: tx.ID,
+			Date:          tx.ProcessedAt,
+			Type:          tx.Type,
+			Description:   StatementDescription(tx, account.ID.String()),
+			Amount:        entry.Change,
+			Balance:       statement.ClosingBalance,
+		})
+	}
+	return statement
+}
+
+// StatementDescription describes a transaction as it appears on the
+// statement of the account with the given ID.
+func StatementDescription(tx *Transaction, accountID string) string {
+	switch tx.Type {
+	case DEPOSIT:
+		if tx.Interest != nil {
+			return "Interest"
+		}
+		return "Deposit"
+	case WITHDRAWL:
+		return "Withdrawal"
+	case TRANSFER:
+		if tx.DestinationAccountID == accountID {
+			return "Transfer from " + tx.AccountID
+		}
+		return "Transfer to " + tx.DestinationAccountID
+	case ADJUSTMENT:
+		if tx.Adjustment != nil {
+			return "Adjustment: " + string(tx.Adjustment.ReasonCode)
+		}
+		return "Adjustment"
+	case REVERSAL:
+		return "Reversal of " + tx.ReversalOf
+	case CAPTURE:
+		return "Card payment"
+	case CLOSURE:
+		if tx.AccountID != accountID {
+			return "Closing balance of " + tx.AccountID
+		}
+		return "Account closure"
+	case FEE:
+		switch {
+		case tx.Fee == nil:
+			return "Fee"
+		case tx.Fee.Period != "":
+			return "Maintenance fee for " + tx.Fee.Period
+		default:
+			return "Fee for " + tx.Fee.TransactionID
+		}
+	}
+	return string(tx.Type)
+}
+
+// Render renders the statement as a document in the given format.
+func (s *Statement) Render(format StatementFormat) ([]byte, error) {
+	switch format {
+	case StatementJSON:
+		return json.MarshalIndent(s, "", "  ")
+	case StatementCSV:
+		return s.renderCSV()
+	}
+	return nil, ErrInvalidStatementFormat
+}
+
+func (s *Statement) renderCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"account", s.AccountID.String()},
+		{"period", s.Period},
+		{"currency", string(s.Currency)},
+		{"opening balance", s.OpeningBalance.String()},
+		{"total credits", s.TotalCredits.String()},
+		{"total debits", s.TotalDebits.String()},
+		{"fees", s.TotalFees.String()},
+		{"interest", s.TotalInterest.String()},
+		{"closing balance", s.ClosingBalance.String()},
+		{},
+		{"date", "transaction id", "type", "description", "amount", "balance"},
+	}
+	for _, line := range s.Lines {
+		rows = append(rows, []string{
+			line.Date.UTC().Format(time.RFC3339),
+			line.TransactionID,
+			string(line.Type),
+			line.Description,
+			line.Amount.String(),
+			line.Balance.String(),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderDocuments renders the statement in every format.
+func (s *Statement) RenderDocuments() ([]StatementDocument, error) {
+	documents := make([]StatementDocument, 0, len(StatementFormats))
+	for _, format := range StatementFormats {
+		content, err := s.Render(format)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		documents = append(documents, StatementDocument{
+			StatementID: s.ID,
+			Format:      format,
+			Content:     content,
+			Checksum:    hex.EncodeToString(sum[:]),
+		})
+	}
+	return documents, nil
+}
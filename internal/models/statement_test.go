@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementPeriod(t *testing.T) {
+	start, end, err := StatementPeriod("2025-02")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+
+	for _, period := range []string{"", "2025-13", "2025-02-01", "March"} {
+		_, _, err := StatementPeriod(period)
+		assert.ErrorIs(t, err, ErrInvalidStatementPeriod, period)
+	}
+}
+
+func TestTransactionChange(t *testing.T) {
+	accountID := uuid.New()
+	other := uuid.New()
+
+	incoming := &Transaction{ID: uuid.NewString(), Type: TRANSFER, Amount: NewMoney(40), Currency: DefaultCurrency, AccountID: other.String(), DestinationAccountID: accountID.String()}
+	change, err := TransactionChange(incoming, nil, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, NewMoney(40), change)
+
+	change, err = TransactionChange(incoming, nil, other)
+	require.NoError(t, err)
+	assert.Equal(t, -NewMoney(40), change)
+
+	reversal := &Transaction{ID: uuid.NewString(), Type: REVERSAL, Amount: NewMoney(40), Currency: DefaultCurrency, AccountID: other.String(), ReversalOf: incoming.ID}
+	change, err = TransactionChange(reversal, incoming, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, -NewMoney(40), change)
+
+	_, err = TransactionChange(reversal, nil, accountID)
+	assert.Error(t, err)
+}
+
+func TestNewStatement(t *testing.T) {
+	account := &Account{ID: uuid.New(), Currency: DefaultCurrency}
+	start, end, err := StatementPeriod("2025-03")
+	require.NoError(t, err)
+	at := func(day int) time.Time { return time.Date(2025, time.March, day, 12, 0, 0, 0, time.UTC) }
+
+	deposit := &Transaction{ID: "deposit", Type: DEPOSIT, AccountID: account.ID.String(), ProcessedAt: at(2)}
+	fee := &Transaction{ID: "fee", Type: FEE, AccountID: account.ID.String(), ProcessedAt: at(3), Fee: &FeeCharge{TransactionID: "deposit"}}
+	interest := &Transaction{ID: "interest", Type: DEPOSIT, AccountID: account.ID.String(), ProcessedAt: at(31), Interest: &InterestPosting{}}
+
+	statement := NewStatement(account, "2025-03", start, end, NewMoney(100), []SettledTransaction{
+		{Transaction: deposit, Change: NewMoney(50)},
+		{Transaction: fee, Change: -MustParseMoney("1.50")},
+		{Transaction: interest, Change: MustParseMoney("0.25")},
+	}, end)
+
+	assert.Equal(t, NewMoney(100), statement.OpeningBalance)
+	assert.Equal(t, MustParseMoney("148.75"), statement.ClosingBalance)
+	assert.Equal(t, MustParseMoney("50.25"), statement.TotalCredits)
+	assert.Equal(t, MustParseMoney("1.50"), statement.TotalDebits)
+	assert.Equal(t, MustParseMoney("1.50"), statement.TotalFees)
+	assert.Equal(t, MustParseMoney("0.25"), statement.TotalInterest)
+
+	require.Len(t, statement.Lines, 3)
+	assert.Equal(t, NewMoney(150), statement.Lines[0].Balance)
+	assert.Equal(t, "Fee for deposit", statement.Lines[1].Description)
+	assert.Equal(t, MustParseMoney("148.50"), statement.Lines[1].Balance)
+	assert.Equal(t, "Interest", statement.Lines[2].Description)
+}
+
+func TestStatementDescription(t *testing.T) {
+	accountID := uuid.NewString()
+	sent := &Transaction{Type: TRANSFER, AccountID: accountID, DestinationAccountID: "receiver"}
+	received := &Transaction{Type: TRANSFER, AccountID: "sender", DestinationAccountID: accountID}
+	payout := &Transaction{Type: CLOSURE, AccountID: "closed", Closure: &AccountClosure{PayoutAccountID: accountID}}
+	maintenance := &Transaction{Type: FEE, AccountID: accountID, Fee: &FeeCharge{Period: "2025-03"}}
+
+	assert.Equal(t, "Transfer to receiver", StatementDescription(sent, accountID))
+	assert.Equal(t, "Transfer from sender", StatementDescription(received, accountID))
+	assert.Equal(t, "Closing balance of closed", StatementDescription(payout, accountID))
+	assert.Equal(t, "Maintenance fee for 2025-03", StatementDescription(maintenance, accountID))
+}
+
+func TestStatement_RenderDocuments(t *testing.T) {
+	account := &Account{ID: uuid.New(), Currency: DefaultCurrency}
+	start, end, err := StatementPeriod("2025-03")
+	require.NoError(t, err)
+	deposit := &Transaction{ID: "deposit", Type: DEPOSIT, AccountID: account.ID.String(), ProcessedAt: start.Add(time.Hour)}
+	statement := NewStatement(account, "2025-03", start, end, NewMoney(10), []SettledTransaction{{Transaction: deposit, Change: NewMoney(5)}}, end)
+	statement.ID = uuid.New()
+
+	documents, err := statement.RenderDocuments()
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+
+	assert.Equal(t, StatementJSON, documents[0].Format)
+	assert.Contains(t, string(documents[0].Content), `"closingBalance": 15.00`)
+
+	assert.Equal(t, StatementCSV, documents[1].Format)
+	csv := string(documents[1].Content)
+	assert.Contains(t, csv, "opening balance,10.00\n")
+	assert.Contains(t, csv, "closing balance,15.00\n")
+	assert.True(t, strings.HasSuffix(csv, "2025-03-01T01:00:00Z,deposit,DEPOSIT,Deposit,5.00,15.00\n"))
+
+	again, err := statement.RenderDocuments()
+	require.NoError(t, err)
+	assert.Equal(t, documents[0].Checksum, again[0].Checksum)
+	assert.Len(t, documents[1].Checksum, 64)
+}
+
+func TestParseStatementFormat(t *testing.T) {
+	format, err := ParseStatementFormat("csv")
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", format.ContentType())
+
+	_, err = ParseStatementFormat("pdf")
+	assert.ErrorIs(t, err, ErrInvalidStatementFormat)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 )
 
@@ -10,20 +11,92 @@ type TransactionStatus string
 const (
 	DEPOSIT   TransactionType = "DEPOSIT"
 	WITHDRAWL TransactionType = "WITHDRAWL"
+	TRANSFER  TransactionType = "TRANSFER"
+
+	// ADJUSTMENT corrects an account's balance by hand. It must carry an
+	// Adjustment saying why and who made it.
+	ADJUSTMENT TransactionType = "ADJUSTMENT"
+
+	// REVERSAL undoes the transaction named by ReversalOf by posting the
+	// opposite of its journal.
+	REVERSAL TransactionType = "REVERSAL"
+
+	// AUTHORIZATION reserves funds with a Hold without moving them, and
+	// CAPTURE settles the hold named by HoldID.
+	AUTHORIZATION TransactionType = "AUTHORIZATION"
+	CAPTURE       TransactionType = "CAPTURE"
+
+	// CLOSURE pays out an account's whole balance as described by its Closure
+	// and closes the account.
+	CLOSURE TransactionType = "CLOSURE"
+
+	// FEE charges an account the fee described by its Fee.
+	FEE TransactionType = "FEE"
 )
 const (
 	SUCCESS TransactionStatus = "SUCCESS"
 	FAILED  TransactionStatus = "FAILED"
 	PENDING TransactionStatus = "PENDING"
+
+	// SCHEDULED transactions wait for their ExecuteAt and become PENDING when
+	// they are due, unless they are CANCELLED first.
+	SCHEDULED TransactionStatus = "SCHEDULED"
+	CANCELLED TransactionStatus = "CANCELLED"
 )
 
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 type Transaction struct {
 	ID          string            `json:"id" bson:"_id,omitempty" validate:"omitempty,uuid4"`
-	Type        TransactionType   `json:"type" bson:"type" validate:"required,oneof=DEPOSIT WITHDRAWL"`
-	Amount      float64           `json:"amount" bson:"amount" validate:"required,gt=0"`
+	Type        TransactionType   `json:"type" bson:"type" validate:"required,oneof=DEPOSIT WITHDRAWL TRANSFER ADJUSTMENT REVERSAL AUTHORIZATION CAPTURE CLOSURE FEE"`
+	Amount      Money             `json:"amount" bson:"amount" validate:"required,gt=0"`
+	Currency    Currency          `json:"currency" bson:"currency" validate:"omitempty,iso4217"`
 	AccountID   string            `json:"accountID" bson:"accountID" validate:"required"`
-	Status      TransactionStatus `json:"status" bson:"status" validate:"required,oneof=SUCCESS FAILED PENDING"`
+	Status      TransactionStatus `json:"status" bson:"status" validate:"required,oneof=SUCCESS FAILED PENDING SCHEDULED CANCELLED"`
 	CreatedAt   time.Time         `json:"createdAt" bson:"createdAt" validate:"required"`
 	UpdatedAt   time.Time         `json:"updatedAt" bson:"updatedAt" validate:"required"`
 	ProcessedAt time.Time         `json:"processedAt,omitempty" bson:"processedAt,omitempty"`
+
+	// DestinationAccountID is the account credited by a TRANSFER; AccountID is
+	// the account it is debited from.
+	DestinationAccountID string `json:"destinationAccountID,omitempty" bson:"destinationAccountID,omitempty" validate:"required_if=Type TRANSFER,excluded_unless=Type TRANSFER"`
+
+	// FX is set on a TRANSFER between accounts in different currencies. Amount
+	// and Currency are then the source side of the conversion.
+	FX *FXConversion `json:"fx,omitempty" bson:"fx,omitempty"`
+
+	Adjustment *Adjustment `json:"adjustment,omitempty" bson:"adjustment,omitempty" validate:"required_if=Type ADJUSTMENT,excluded_unless=Type ADJUSTMENT"`
+
+	// ReversalOf links a REVERSAL to the transaction it undoes, and ReversedBy
+	// links that transaction back to its reversal.
+	ReversalOf string `json:"reversalOf,omitempty" bson:"reversalOf,omitempty" validate:"required_if=Type REVERSAL,excluded_unless=Type REVERSAL"`
+	ReversedBy string `json:"reversedBy,omitempty" bson:"reversedBy,omitempty"`
+
+	// HoldExpiresAt optionally sets when an AUTHORIZATION's hold lapses.
+	// HoldID names the authorization a CAPTURE settles.
+	HoldExpiresAt *time.Time `json:"holdExpiresAt,omitempty" bson:"holdExpiresAt,omitempty" validate:"excluded_unless=Type AUTHORIZATION"`
+	HoldID        string     `json:"holdID,omitempty" bson:"holdID,omitempty" validate:"required_if=Type CAPTURE,excluded_unless=Type CAPTURE"`
+
+	Closure *AccountClosure `json:"closure,omitempty" bson:"closure,omitempty" validate:"required_if=Type CLOSURE,excluded_unless=Type CLOSURE"`
+
+	// Interest is set on the DEPOSIT that pays an account its accrued
+	// interest, which is funded by the bank rather than by cash.
+	Interest *InterestPosting `json:"interest,omitempty" bson:"interest,omitempty" validate:"excluded_unless=Type DEPOSIT"`
+
+	// Fee describes what a FEE transaction charges for, and FeeTransactionID
+	// links a transaction to the fee charged for it.
+	Fee              *FeeCharge `json:"fee,omitempty" bson:"fee,omitempty" validate:"required_if=Type FEE,excluded_unless=Type FEE"`
+	FeeTransactionID string     `json:"feeTransactionID,omitempty" bson:"feeTransactionID,omitempty"`
+
+	// ExecuteAt optionally defers a transaction to a future time. It is
+	// stored as SCHEDULED until then.
+	ExecuteAt *time.Time `json:"executeAt,omitempty" bson:"executeAt,omitempty"`
+
+	// Outbox is the delivery state of the transaction's event. It is internal
+	// and never part of the event or API response.
+	Outbox *Outbox `json:"-" bson:"outbox,omitempty"`
+
+	// ChainedAt is when the transaction was linked into the hash chains of
+	// the accounts it involves. It is internal like Outbox.
+	ChainedAt *time.Time `json:"-" bson:"chainedAt,omitempty"`
 }
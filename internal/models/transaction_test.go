@@ -23,7 +23,7 @@ func TestTransactionModel(t *testing.T) {
 				name: "Valid Deposit Transaction",
 				transaction: Transaction{
 					Type:      DEPOSIT,
-					Amount:    100.50,
+					Amount:    MustParseMoney("100.50"),
 					AccountID: "acc_123",
 					Status:    SUCCESS,
 					CreatedAt: now,
@@ -35,7 +35,7 @@ func TestTransactionModel(t *testing.T) {
 				name: "Invalid Transaction Type",
 				transaction: Transaction{
 					Type:      "INVALID",
-					Amount:    100.50,
+					Amount:    MustParseMoney("100.50"),
 					AccountID: "acc_123",
 					Status:    SUCCESS,
 					CreatedAt: now,
@@ -48,7 +48,7 @@ func TestTransactionModel(t *testing.T) {
 				name: "Negative Amount",
 				transaction: Transaction{
 					Type:      WITHDRAWL,
-					Amount:    -50.00,
+					Amount:    NewMoney(-50),
 					AccountID: "acc_123",
 					Status:    SUCCESS,
 					CreatedAt: now,
@@ -61,7 +61,7 @@ func TestTransactionModel(t *testing.T) {
 				name: "Missing Account ID",
 				transaction: Transaction{
 					Type:      DEPOSIT,
-					Amount:    100.50,
+					Amount:    MustParseMoney("100.50"),
 					Status:    SUCCESS,
 					CreatedAt: now,
 					UpdatedAt: now,
@@ -69,11 +69,78 @@ func TestTransactionModel(t *testing.T) {
 				expectError: true,
 				errContains: []string{"AccountID", "required"},
 			},
+			{
+				name: "Valid Transfer Transaction",
+				transaction: Transaction{
+					Type:                 TRANSFER,
+					Amount:               NewMoney(25),
+					AccountID:            "acc_123",
+					DestinationAccountID: "acc_456",
+					Status:               PENDING,
+					CreatedAt:            now,
+					UpdatedAt:            now,
+				},
+				expectError: false,
+			},
+			{
+				name: "Transfer Without Destination",
+				transaction: Transaction{
+					Type:      TRANSFER,
+					Amount:    NewMoney(25),
+					AccountID: "acc_123",
+					Status:    PENDING,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				expectError: true,
+				errContains: []string{"DestinationAccountID", "required_if"},
+			},
+			{
+				name: "Deposit With Destination",
+				transaction: Transaction{
+					Type:                 DEPOSIT,
+					Amount:               NewMoney(25),
+					AccountID:            "acc_123",
+					DestinationAccountID: "acc_456",
+					Status:               PENDING,
+					CreatedAt:            now,
+					UpdatedAt:            now,
+				},
+				expectError: true,
+				errContains: []string{"DestinationAccountID", "excluded_unless"},
+			},
+			{
+				name: "Adjustment Without Details",
+				transaction: Transaction{
+					Type:      ADJUSTMENT,
+					Amount:    NewMoney(25),
+					AccountID: "acc_123",
+					Status:    PENDING,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				expectError: true,
+				errContains: []string{"Adjustment", "required_if"},
+			},
+			{
+				name: "Deposit With Adjustment",
+				transaction: Transaction{
+					Type:       DEPOSIT,
+					Amount:     NewMoney(25),
+					AccountID:  "acc_123",
+					Status:     PENDING,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+					Adjustment: &Adjustment{Direction: CREDIT},
+				},
+				expectError: true,
+				errContains: []string{"Adjustment", "excluded_unless"},
+			},
 			{
 				name: "Invalid Status",
 				transaction: Transaction{
 					Type:      DEPOSIT,
-					Amount:    100.50,
+					Amount:    MustParseMoney("100.50"),
 					AccountID: "acc_123",
 					Status:    "INVALID",
 					CreatedAt: now,
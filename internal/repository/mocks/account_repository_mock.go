@@ -27,12 +27,32 @@ func (m *MockAccountRepository) Create(ctx context.Context, account *models.Acco
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error {
-	args := m.Called(ctx, id, updates)
+func (m *MockAccountRepository) Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error {
+	args := m.Called(ctx, id, version, updates)
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockAccountRepository) SetOverdraftLimit(ctx context.Context, change *models.OverdraftLimitChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) GetOverdraftLimitChanges(ctx context.Context, id uuid.UUID) ([]models.OverdraftLimitChange, error) {
 	args := m.Called(ctx, id)
+	return args.Get(0).([]models.OverdraftLimitChange), args.Error(1)
+}
+
+func (m *MockAccountRepository) ChangeStatus(ctx context.Context, change *models.AccountStatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) GetStatusChanges(ctx context.Context, id uuid.UUID) ([]models.AccountStatusChange, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]models.AccountStatusChange), args.Error(1)
+}
+
+func (m *MockAccountRepository) Close(ctx context.Context, journal *models.Journal, change *models.AccountStatusChange) error {
+	args := m.Called(ctx, journal, change)
 	return args.Error(0)
 }
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBalanceSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockBalanceSnapshotRepository) Create(ctx context.Context, snapshot *models.BalanceSnapshot) (bool, error) {
+	args := m.Called(ctx, snapshot)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBalanceSnapshotRepository) GetLatest(ctx context.Context, accountID uuid.UUID, at time.Time) (models.BalanceSnapshot, error) {
+	args := m.Called(ctx, accountID, at)
+	return args.Get(0).(models.BalanceSnapshot), args.Error(1)
+}
+
+func (m *MockBalanceSnapshotRepository) GetRange(ctx context.Context, accountID uuid.UUID, from, until time.Time) ([]models.BalanceSnapshot, error) {
+	args := m.Called(ctx, accountID, from, until)
+	return args.Get(0).([]models.BalanceSnapshot), args.Error(1)
+}
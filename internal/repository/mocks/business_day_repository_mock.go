@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBusinessDayRepository struct {
+	mock.Mock
+}
+
+func (m *MockBusinessDayRepository) Start(ctx context.Context, day *models.BusinessDay) error {
+	return m.Called(ctx, day).Error(0)
+}
+
+func (m *MockBusinessDayRepository) Get(ctx context.Context, date time.Time) (models.BusinessDay, error) {
+	args := m.Called(ctx, date)
+	return args.Get(0).(models.BusinessDay), args.Error(1)
+}
+
+func (m *MockBusinessDayRepository) GetLatest(ctx context.Context) (models.BusinessDay, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(models.BusinessDay), args.Error(1)
+}
+
+func (m *MockBusinessDayRepository) GetAll(ctx context.Context) ([]models.BusinessDay, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.BusinessDay), args.Error(1)
+}
+
+func (m *MockBusinessDayRepository) Close(ctx context.Context, day *models.BusinessDay) error {
+	return m.Called(ctx, day).Error(0)
+}
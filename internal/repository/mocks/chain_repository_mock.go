@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChainRepository struct {
+	mock.Mock
+}
+
+func (m *MockChainRepository) Append(ctx context.Context, accountID string, tx *models.Transaction, now time.Time) (models.ChainLink, error) {
+	args := m.Called(ctx, accountID, tx, now)
+	return args.Get(0).(models.ChainLink), args.Error(1)
+}
+
+func (m *MockChainRepository) GetLinks(ctx context.Context, accountID string) ([]models.ChainLink, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.ChainLink), args.Error(1)
+}
+
+func (m *MockChainRepository) GetHeads(ctx context.Context) ([]models.ChainHead, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.ChainHead), args.Error(1)
+}
+
+func (m *MockChainRepository) CreateCheckpoint(ctx context.Context, checkpoint *models.ChainCheckpoint) error {
+	return m.Called(ctx, checkpoint).Error(0)
+}
+
+func (m *MockChainRepository) GetCheckpoint(ctx context.Context, id uuid.UUID) (models.ChainCheckpoint, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.ChainCheckpoint), args.Error(1)
+}
+
+func (m *MockChainRepository) GetLatestCheckpoint(ctx context.Context) (models.ChainCheckpoint, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(models.ChainCheckpoint), args.Error(1)
+}
+
+func (m *MockChainRepository) GetCheckpoints(ctx context.Context, limit int) ([]models.ChainCheckpoint, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]models.ChainCheckpoint), args.Error(1)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFeeRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeeRuleRepository) Create(ctx context.Context, rule *models.FeeRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockFeeRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (models.FeeRule, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.FeeRule), args.Error(1)
+}
+
+func (m *MockFeeRuleRepository) GetAll(ctx context.Context) ([]models.FeeRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.FeeRule), args.Error(1)
+}
+
+func (m *MockFeeRuleRepository) GetActive(ctx context.Context, kind models.FeeKind) ([]models.FeeRule, error) {
+	args := m.Called(ctx, kind)
+	return args.Get(0).([]models.FeeRule), args.Error(1)
+}
+
+// Update applies change to the rule the mock returns, as the repository would
+// to the stored one.
+func (m *MockFeeRuleRepository) Update(ctx context.Context, id uuid.UUID, change func(*models.FeeRule) error) (models.FeeRule, error) {
+	args := m.Called(ctx, id)
+	rule := args.Get(0).(models.FeeRule)
+	if err := args.Error(1); err != nil {
+		return models.FeeRule{}, err
+	}
+	if err := change(&rule); err != nil {
+		return models.FeeRule{}, err
+	}
+	return rule, nil
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFXRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockFXRateRepository) Create(ctx context.Context, rate *models.FXRate) error {
+	args := m.Called(ctx, rate)
+	return args.Error(0)
+}
+
+func (m *MockFXRateRepository) GetEffective(ctx context.Context, base, quote models.Currency, at time.Time) (models.FXRate, error) {
+	args := m.Called(ctx, base, quote, at)
+	return args.Get(0).(models.FXRate), args.Error(1)
+}
+
+func (m *MockFXRateRepository) GetAll(ctx context.Context) ([]models.FXRate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.FXRate), args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) GetByID(ctx context.Context, id string) (models.Hold, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Hold, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Place(ctx context.Context, hold *models.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) ClaimCapture(ctx context.Context, holdID, captureTransactionID string, now time.Time) error {
+	args := m.Called(ctx, holdID, captureTransactionID, now)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) ReleaseCaptureClaim(ctx context.Context, holdID, captureTransactionID string) error {
+	args := m.Called(ctx, holdID, captureTransactionID)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, holdID string, status models.HoldStatus) error {
+	args := m.Called(ctx, holdID, status)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Capture(ctx context.Context, holdID string, journal *models.Journal) error {
+	args := m.Called(ctx, holdID, journal)
+	return args.Error(0)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Reserve(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	args := m.Called(ctx, key)
+	existing, _ := args.Get(0).(*models.IdempotencyKey)
+	return existing, args.Bool(1), args.Error(2)
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	args := m.Called(ctx, key, statusCode, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInterestRepository struct {
+	mock.Mock
+}
+
+func (m *MockInterestRepository) GetConfig(ctx context.Context, scope string) (models.InterestConfig, error) {
+	args := m.Called(ctx, scope)
+	return args.Get(0).(models.InterestConfig), args.Error(1)
+}
+
+func (m *MockInterestRepository) SaveConfig(ctx context.Context, config *models.InterestConfig) error {
+	args := m.Called(ctx, config)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) DeleteConfig(ctx context.Context, scope string) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) Accrue(ctx context.Context, accrual *models.InterestAccrual) (bool, error) {
+	args := m.Called(ctx, accrual)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInterestRepository) GetUnposted(ctx context.Context, accountID uuid.UUID) ([]models.InterestAccrual, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.InterestAccrual), args.Error(1)
+}
+
+func (m *MockInterestRepository) GetLastAccrualDate(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockInterestRepository) GetAccountsWithUnposted(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockInterestRepository) MarkPosted(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string, carried models.Money) error {
+	args := m.Called(ctx, accountID, periodEnd, transactionID, carried)
+	return args.Error(0)
+}
+
+func (m *MockInterestRepository) ReleasePosting(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string) error {
+	args := m.Called(ctx, accountID, periodEnd, transactionID)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) PostJournal(ctx context.Context, journal *models.Journal) error {
+	args := m.Called(ctx, journal)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) PostJournals(ctx context.Context, journals ...*models.Journal) error {
+	args := m.Called(ctx, journals)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) GetJournal(ctx context.Context, id string) (models.Journal, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Journal), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetJournalIDs(ctx context.Context, ledgerAccount string) ([]string, error) {
+	args := m.Called(ctx, ledgerAccount)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetBalanceAt(ctx context.Context, ledgerAccount string, at time.Time) (models.Money, error) {
+	args := m.Called(ctx, ledgerAccount, at)
+	return args.Get(0).(models.Money), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetPostings(ctx context.Context, ledgerAccount string) ([]models.Posting, error) {
+	args := m.Called(ctx, ledgerAccount)
+	return args.Get(0).([]models.Posting), args.Error(1)
+}
+
+func (m *MockLedgerRepository) SumPostings(ctx context.Context, ledgerAccount string, from, until time.Time) (models.Money, int, error) {
+	args := m.Called(ctx, ledgerAccount, from, until)
+	return args.Get(0).(models.Money), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockLimitRepository) Get(ctx context.Context, scope string) (models.TransactionLimits, error) {
+	args := m.Called(ctx, scope)
+	return args.Get(0).(models.TransactionLimits), args.Error(1)
+}
+
+func (m *MockLimitRepository) Save(ctx context.Context, limits *models.TransactionLimits) error {
+	args := m.Called(ctx, limits)
+	return args.Error(0)
+}
+
+func (m *MockLimitRepository) Delete(ctx context.Context, scope string) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
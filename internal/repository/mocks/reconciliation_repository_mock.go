@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) CreateReport(ctx context.Context, report *models.ReconciliationReport) error {
+	return m.Called(ctx, report).Error(0)
+}
+
+func (m *MockReconciliationRepository) GetReport(ctx context.Context, id uuid.UUID) (models.ReconciliationReport, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.ReconciliationReport), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) GetReports(ctx context.Context, limit int) ([]models.ReconciliationReport, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]models.ReconciliationReport), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) RecordCase(ctx context.Context, reportID uuid.UUID, discrepancy *models.Discrepancy, now time.Time) (models.InvestigationCase, error) {
+	args := m.Called(ctx, reportID, discrepancy, now)
+	return args.Get(0).(models.InvestigationCase), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) GetCase(ctx context.Context, id uuid.UUID) (models.InvestigationCase, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.InvestigationCase), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) GetCases(ctx context.Context, status models.CaseStatus) ([]models.InvestigationCase, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]models.InvestigationCase), args.Error(1)
+}
+
+// UpdateCase applies change to the case the mock returns, as the repository
+// would to the stored one.
+func (m *MockReconciliationRepository) UpdateCase(ctx context.Context, id uuid.UUID, change func(*models.InvestigationCase) error) (models.InvestigationCase, error) {
+	args := m.Called(ctx, id)
+	investigation := args.Get(0).(models.InvestigationCase)
+	if err := args.Error(1); err != nil {
+		return models.InvestigationCase{}, err
+	}
+	if err := change(&investigation); err != nil {
+		return models.InvestigationCase{}, err
+	}
+	return investigation, nil
+}
@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStandingOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockStandingOrderRepository) Create(ctx context.Context, order *models.StandingOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (models.StandingOrder, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.StandingOrder), args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.StandingOrder, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.StandingOrder), args.Error(1)
+}
+
+// Update applies change to the order the mock returns, as the repository
+// would to the stored one.
+func (m *MockStandingOrderRepository) Update(ctx context.Context, id uuid.UUID, change func(*models.StandingOrder) error) (models.StandingOrder, error) {
+	args := m.Called(ctx, id)
+	order := args.Get(0).(models.StandingOrder)
+	if err := args.Error(1); err != nil {
+		return models.StandingOrder{}, err
+	}
+	if err := change(&order); err != nil {
+		return models.StandingOrder{}, err
+	}
+	return order, nil
+}
+
+func (m *MockStandingOrderRepository) GetDue(ctx context.Context, now time.Time) ([]models.StandingOrder, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]models.StandingOrder), args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) RecordRun(ctx context.Context, order *models.StandingOrder, scheduledFor time.Time, run *models.StandingOrderRun) error {
+	args := m.Called(ctx, order, scheduledFor, run)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) GetPendingRuns(ctx context.Context) ([]models.StandingOrderRun, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.StandingOrderRun), args.Error(1)
+}
+
+func (m *MockStandingOrderRepository) SettleRun(ctx context.Context, run *models.StandingOrderRun, maxFailures int) error {
+	args := m.Called(ctx, run, maxFailures)
+	return args.Error(0)
+}
+
+func (m *MockStandingOrderRepository) GetRuns(ctx context.Context, orderID uuid.UUID) ([]models.StandingOrderRun, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]models.StandingOrderRun), args.Error(1)
+}
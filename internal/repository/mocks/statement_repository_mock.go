@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatementRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatementRepository) Create(ctx context.Context, statement *models.Statement) (bool, error) {
+	args := m.Called(ctx, statement)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStatementRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Statement, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.Statement), args.Error(1)
+}
+
+func (m *MockStatementRepository) GetByPeriod(ctx context.Context, accountID uuid.UUID, period string) (models.Statement, error) {
+	args := m.Called(ctx, accountID, period)
+	return args.Get(0).(models.Statement), args.Error(1)
+}
+
+func (m *MockStatementRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Statement, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.Statement), args.Error(1)
+}
+
+func (m *MockStatementRepository) GetDocument(ctx context.Context, statementID uuid.UUID, format models.StatementFormat) (models.StatementDocument, error) {
+	args := m.Called(ctx, statementID, format)
+	return args.Get(0).(models.StatementDocument), args.Error(1)
+}
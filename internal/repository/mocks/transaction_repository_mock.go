@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/stretchr/testify/mock"
@@ -30,3 +31,84 @@ func (m *MockTransactionRepository) Update(ctx context.Context, id string, tx *m
 	args := m.Called(ctx, id, tx)
 	return args.Error(0)
 }
+
+func (m *MockTransactionRepository) ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration) (*models.Transaction, error) {
+	args := m.Called(ctx, now, lease)
+	tx, _ := args.Get(0).(*models.Transaction)
+	return tx, args.Error(1)
+}
+
+func (m *MockTransactionRepository) MarkOutboxSent(ctx context.Context, id string, sentAt time.Time) error {
+	args := m.Called(ctx, id, sentAt)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) MarkOutboxFailed(ctx context.Context, id string, nextAttemptAt time.Time, reason string) error {
+	args := m.Called(ctx, id, nextAttemptAt, reason)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) ClaimReversal(ctx context.Context, id, current, reversalID string) (bool, error) {
+	args := m.Called(ctx, id, current, reversalID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ReleaseReversal(ctx context.Context, id, reversalID string) error {
+	args := m.Called(ctx, id, reversalID)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetLimitUsage(ctx context.Context, accountID string, windows models.LimitWindows) (models.LimitUsage, error) {
+	args := m.Called(ctx, accountID, windows)
+	return args.Get(0).(models.LimitUsage), args.Error(1)
+}
+
+func (m *MockTransactionRepository) HasPending(ctx context.Context, accountID string) (bool, error) {
+	args := m.Called(ctx, accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) HasOtherPending(ctx context.Context, accountID, transactionID string) (bool, error) {
+	args := m.Called(ctx, accountID, transactionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetScheduled(ctx context.Context, accountID string) ([]models.Transaction, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]models.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ReleaseScheduled(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CancelScheduled(ctx context.Context, id string, now time.Time) (*models.Transaction, error) {
+	args := m.Called(ctx, id, now)
+	tx, _ := args.Get(0).(*models.Transaction)
+	return tx, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetSettled(ctx context.Context, accountID string, from, until time.Time) ([]models.Transaction, error) {
+	args := m.Called(ctx, accountID, from, until)
+	return args.Get(0).([]models.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountPending(ctx context.Context, from, until time.Time) (int, error) {
+	args := m.Called(ctx, from, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) IsProcessing(ctx context.Context, accountID string) (bool, error) {
+	args := m.Called(ctx, accountID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetUnchained(ctx context.Context, limit int) ([]models.Transaction, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]models.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) MarkChained(ctx context.Context, id string, chainedAt time.Time) error {
+	return m.Called(ctx, id, chainedAt).Error(0)
+}
@@ -2,11 +2,15 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TransactionRepository struct {
@@ -19,6 +23,8 @@ func NewTransactionRepository(db *mongo.Database) *TransactionRepository {
 	}
 }
 
+// Create inserts the transaction. A transaction created with an Outbox is
+// stored together with its pending event in the same single-document write.
 func (r *TransactionRepository) Create(ctx context.Context, tx *models.Transaction) error {
 	_, err := r.collection.InsertOne(ctx, tx)
 	if err != nil {
@@ -31,17 +37,23 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id string) (*models
 	var tx models.Transaction
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&tx)
 	if err == mongo.ErrNoDocuments {
-		return nil, fmt.Errorf("transaction not found")
+		return nil, models.ErrTransactionNotFound
 	} else if err != nil {
 		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
 	}
 	return &tx, nil
 }
 
+// GetByAccountID returns the transactions an account takes part in, including
+// transfers where it is the destination, oldest first.
 func (r *TransactionRepository) GetByAccountID(ctx context.Context, accountID string) ([]models.Transaction, error) {
 	var transactions []models.Transaction
 
-	cursor, err := r.collection.Find(ctx, bson.M{"accountID": accountID})
+	filter := bson.M{"$or": []bson.M{
+		{"accountID": accountID},
+		{"destinationAccountID": accountID},
+	}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
 	}
@@ -72,8 +84,361 @@ func (r *TransactionRepository) Update(ctx context.Context, id string, tx *model
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("transaction not found")
+		return models.ErrTransactionNotFound
 	}
 
 	return nil
 }
+
+// ClaimOutbox picks the oldest transaction whose event is due for delivery and
+// pushes its next attempt back by lease, so a relay that dies while publishing
+// leaves the event to be retried once the lease runs out. It returns nil when
+// nothing is due.
+func (r *TransactionRepository) ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration) (*models.Transaction, error) {
+	filter := bson.M{
+		"outbox.status":        models.OutboxPending,
+		"outbox.nextAttemptAt": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{"outbox.nextAttemptAt": now.Add(lease)},
+		"$inc": bson.M{"outbox.attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"outbox.nextAttemptAt": 1}).
+		SetReturnDocument(options.After)
+
+	var tx models.Transaction
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&tx)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox entry: %w", err)
+	}
+	return &tx, nil
+}
+
+func (r *TransactionRepository) MarkOutboxSent(ctx context.Context, id string, sentAt time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"outbox.status":    models.OutboxSent,
+		"outbox.sentAt":    sentAt,
+		"outbox.lastError": "",
+	}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to mark outbox entry sent: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed records a failed delivery and when to try again.
+func (r *TransactionRepository) MarkOutboxFailed(ctx context.Context, id string, nextAttemptAt time.Time, reason string) error {
+	update := bson.M{"$set": bson.M{
+		"outbox.nextAttemptAt": nextAttemptAt,
+		"outbox.lastError":     reason,
+	}}
+	filter := bson.M{"_id": id, "outbox.status": models.OutboxPending}
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to record outbox failure: %w", err)
+	}
+	return nil
+}
+
+// ClaimReversal links a successful transaction to its reversal, unless it
+// already has one. current is the reversal it is expected to be linked to now,
+// empty for none; it returns false if the transaction is not in that state.
+func (r *TransactionRepository) ClaimReversal(ctx context.Context, id, current, reversalID string) (bool, error) {
+	filter := bson.M{"_id": id, "status": models.SUCCESS}
+	if current == "" {
+		filter["reversedBy"] = bson.M{"$exists": false}
+	} else {
+		filter["reversedBy"] = current
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"reversedBy": reversalID}})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reversal: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// ReleaseReversal unlinks a transaction from a reversal that failed, so that
+// it can be reversed again.
+func (r *TransactionRepository) ReleaseReversal(ctx context.Context, id, reversalID string) error {
+	filter := bson.M{"_id": id, "reversedBy": reversalID}
+	if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"reversedBy": ""}}); err != nil {
+		return fmt.Errorf("failed to release reversal: %w", err)
+	}
+	return nil
+}
+
+// GetLimitUsage counts and sums the account's limited transactions in each of
+// the windows. Failed and cancelled transactions do not count.
+func (r *TransactionRepository) GetLimitUsage(ctx context.Context, accountID string, windows models.LimitWindows) (models.LimitUsage, error) {
+	since := func(start time.Time, value interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$createdAt", start}}, value, 0}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"accountID": accountID,
+			"type":      bson.M{"$in": models.LimitedTransactionTypes},
+			"status":    bson.M{"$nin": bson.A{models.FAILED, models.CANCELLED}},
+			"createdAt": bson.M{"$gte": windows.Earliest()},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           nil,
+			"minuteCount":   since(windows.Minute, 1),
+			"dailyCount":    since(windows.Day, 1),
+			"dailyAmount":   since(windows.Day, "$amount"),
+			"monthlyCount":  since(windows.Month, 1),
+			"monthlyAmount": since(windows.Month, "$amount"),
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return models.LimitUsage{}, fmt.Errorf("failed to aggregate limit usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var usage models.LimitUsage
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&usage); err != nil {
+			return models.LimitUsage{}, err
+		}
+	}
+	return usage, cursor.Err()
+}
+
+// HasPending reports whether any transaction the account takes part in is
+// still waiting to be processed or scheduled to run.
+func (r *TransactionRepository) HasPending(ctx context.Context, accountID string) (bool, error) {
+	return r.hasPending(ctx, pendingFilter(accountID))
+}
+
+// HasOtherPending is HasPending leaving out the transaction with the given ID.
+func (r *TransactionRepository) HasOtherPending(ctx context.Context, accountID, transactionID string) (bool, error) {
+	filter := pendingFilter(accountID)
+	filter["_id"] = bson.M{"$ne": transactionID}
+	return r.hasPending(ctx, filter)
+}
+
+func pendingFilter(accountID string) bson.M {
+	return bson.M{
+		"status": bson.M{"$in": bson.A{models.PENDING, models.SCHEDULED}},
+		"$or": []bson.M{
+			{"accountID": accountID},
+			{"destinationAccountID": accountID},
+			{"closure.payoutAccountID": accountID},
+		},
+	}
+}
+
+func (r *TransactionRepository) hasPending(ctx context.Context, filter bson.M) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to count pending transactions: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsProcessing reports whether any transaction the account takes part in has
+// been released for processing and not finished yet.
+func (r *TransactionRepository) IsProcessing(ctx context.Context, accountID string) (bool, error) {
+	filter := bson.M{
+		"status": models.PENDING,
+		"$or": []bson.M{
+			{"accountID": accountID},
+			{"destinationAccountID": accountID},
+			{"closure.payoutAccountID": accountID},
+		},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to count processing transactions: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountPending counts the transactions created at or after from and before
+// until that are still waiting to be processed, and the scheduled transactions
+// due before until that have not been released yet.
+func (r *TransactionRepository) CountPending(ctx context.Context, from, until time.Time) (int, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"status": models.PENDING, "createdAt": bson.M{"$gte": from, "$lt": until}},
+		{"status": models.SCHEDULED, "executeAt": bson.M{"$lt": until}},
+	}}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending transactions: %w", err)
+	}
+	return int(count), nil
+}
+
+// GetScheduled returns the scheduled transactions the account takes part in,
+// soonest first.
+func (r *TransactionRepository) GetScheduled(ctx context.Context, accountID string) ([]models.Transaction, error) {
+	filter := bson.M{
+		"status": models.SCHEDULED,
+		"$or": []bson.M{
+			{"accountID": accountID},
+			{"destinationAccountID": accountID},
+		},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"executeAt": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled transactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// ReleaseScheduled turns every scheduled transaction due by now into a
+// PENDING one with a pending outbox event, so the relay publishes it like any
+// other. It returns how many were released.
+func (r *TransactionRepository) ReleaseScheduled(ctx context.Context, now time.Time) (int, error) {
+	filter := bson.M{
+		"status":    models.SCHEDULED,
+		"executeAt": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":    models.PENDING,
+		"updatedAt": now,
+		"outbox":    models.NewOutbox(now),
+	}}
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release scheduled transactions: %w", err)
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// CancelScheduled cancels a transaction that is still scheduled and returns
+// it. It returns ErrTransactionNotScheduled if the transaction has already
+// been released or cancelled.
+func (r *TransactionRepository) CancelScheduled(ctx context.Context, id string, now time.Time) (*models.Transaction, error) {
+	filter := bson.M{"_id": id, "status": models.SCHEDULED}
+	update := bson.M{"$set": bson.M{
+		"status":    models.CANCELLED,
+		"updatedAt": now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var tx models.Transaction
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&tx)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, models.ErrTransactionNotScheduled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel scheduled transaction: %w", err)
+	}
+	return &tx, nil
+}
+
+// GetUnchained returns up to limit final transactions that have not been
+// linked into the hash chains of their accounts yet, in the order they were
+// processed.
+func (r *TransactionRepository) GetUnchained(ctx context.Context, limit int) ([]models.Transaction, error) {
+	filter := bson.M{
+		"status":    bson.M{"$in": models.ChainedTransactionStatuses},
+		"chainedAt": bson.M{"$exists": false},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "processedAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch unchained transactions: %w", err)
+	}
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, fmt.Errorf("failed to decode unchained transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (r *TransactionRepository) MarkChained(ctx context.Context, id string, chainedAt time.Time) error {
+	update := bson.M{"$set": bson.M{"chainedAt": chainedAt}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to mark transaction chained: %w", err)
+	}
+	return nil
+}
+
+// GetSettled returns the successful transactions that changed the account's
+// balance and were processed at or after from and before until, in the order
+// they were processed. Besides the transactions the account takes part in, it
+// finds the reversals of transfers the account received, which only name the
+// sender.
+func (r *TransactionRepository) GetSettled(ctx context.Context, accountID string, from, until time.Time) ([]models.Transaction, error) {
+	processed := bson.M{"$gte": from, "$lt": until}
+	order := bson.D{{Key: "processedAt", Value: 1}, {Key: "_id", Value: 1}}
+
+	filter := bson.M{
+		"status":      models.SUCCESS,
+		"processedAt": processed,
+		"type":        bson.M{"$ne": models.AUTHORIZATION},
+		"$or": []bson.M{
+			{"accountID": accountID},
+			{"destinationAccountID": accountID},
+			{"closure.payoutAccountID": accountID},
+		},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(order))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settled transactions: %w", err)
+	}
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, fmt.Errorf("failed to decode settled transactions: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"type":        models.REVERSAL,
+			"status":      models.SUCCESS,
+			"processedAt": processed,
+			"accountID":   bson.M{"$ne": accountID},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.collection.Name(),
+			"localField":   "reversalOf",
+			"foreignField": "_id",
+			"as":           "original",
+		}}},
+		{{Key: "$match", Value: bson.M{"original.destinationAccountID": accountID}}},
+		{{Key: "$project", Value: bson.M{"original": 0}}},
+	}
+	cursor, err = r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reversed transfers: %w", err)
+	}
+	var reversals []models.Transaction
+	if err := cursor.All(ctx, &reversals); err != nil {
+		return nil, fmt.Errorf("failed to decode reversed transfers: %w", err)
+	}
+	if len(reversals) == 0 {
+		return transactions, nil
+	}
+
+	transactions = append(transactions, reversals...)
+	sortByProcessedAt(transactions)
+	return transactions, nil
+}
+
+func sortByProcessedAt(transactions []models.Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if !a.ProcessedAt.Equal(b.ProcessedAt) {
+			return a.ProcessedAt.Before(b.ProcessedAt)
+		}
+		return a.ID < b.ID
+	})
+}
@@ -68,7 +68,7 @@ func TestTransactionRepository_Create(t *testing.T) {
 		ID:        primitive.NewObjectID().Hex(),
 		AccountID: "account123",
 		Type:      "deposit",
-		Amount:    100.50,
+		Amount:    models.MustParseMoney("100.50"),
 		CreatedAt: time.Now(),
 	}
 
@@ -92,7 +92,7 @@ func TestTransactionRepository_GetByID(t *testing.T) {
 	tx := &models.Transaction{
 		ID:        primitive.NewObjectID().Hex(),
 		AccountID: "account123",
-		Amount:    200.0,
+		Amount:    models.NewMoney(200),
 	}
 	_, err := repo.collection.InsertOne(ctx, tx)
 	require.NoError(t, err)
@@ -118,9 +118,10 @@ func TestTransactionRepository_GetByAccountID(t *testing.T) {
 
 	accountID := "account-789"
 	transactions := []interface{}{
-		&models.Transaction{AccountID: accountID, Amount: 100},
-		&models.Transaction{AccountID: accountID, Amount: 200},
-		&models.Transaction{AccountID: "other-account", Amount: 300},
+		&models.Transaction{AccountID: accountID, Amount: models.NewMoney(100)},
+		&models.Transaction{AccountID: accountID, Amount: models.NewMoney(200)},
+		&models.Transaction{AccountID: "other-account", Amount: models.NewMoney(300)},
+		&models.Transaction{AccountID: "other-account", DestinationAccountID: accountID, Type: models.TRANSFER, Amount: models.NewMoney(400)},
 	}
 
 	_, err := repo.collection.InsertMany(ctx, transactions)
@@ -129,9 +130,9 @@ func TestTransactionRepository_GetByAccountID(t *testing.T) {
 	results, err := repo.GetByAccountID(ctx, accountID)
 	require.NoError(t, err)
 
-	assert.Len(t, results, 2)
+	assert.Len(t, results, 3)
 	for _, tx := range results {
-		assert.Equal(t, accountID, tx.AccountID)
+		assert.True(t, tx.AccountID == accountID || tx.DestinationAccountID == accountID)
 	}
 }
 
@@ -144,7 +145,7 @@ func TestTransactionRepository_Update(t *testing.T) {
 		ID:        primitive.NewObjectID().Hex(),
 		AccountID: "account456",
 		Type:      models.WITHDRAWL,
-		Amount:    500.0,
+		Amount:    models.NewMoney(500),
 	}
 	_, err := repo.collection.InsertOne(ctx, originalTx)
 	require.NoError(t, err)
@@ -153,7 +154,7 @@ func TestTransactionRepository_Update(t *testing.T) {
 		ID:        originalTx.ID,
 		AccountID: "account456",
 		Type:      models.WITHDRAWL,
-		Amount:    600.0,
+		Amount:    models.NewMoney(600),
 		Status:    models.SUCCESS,
 	}
 
@@ -164,7 +165,7 @@ func TestTransactionRepository_Update(t *testing.T) {
 	err = repo.collection.FindOne(ctx, bson.M{"_id": originalTx.ID}).Decode(&updatedTx)
 	require.NoError(t, err)
 
-	assert.Equal(t, 600.0, updatedTx.Amount)
+	assert.Equal(t, models.NewMoney(600), updatedTx.Amount)
 	assert.Equal(t, models.SUCCESS, updatedTx.Status)
 }
 
@@ -177,10 +178,336 @@ func TestTransactionRepository_Update_NonExisting(t *testing.T) {
 	tx := &models.Transaction{
 		ID:        nonExistingID,
 		AccountID: "doesnt-exist",
-		Amount:    100.0,
+		Amount:    models.NewMoney(100),
 	}
 
 	err := repo.Update(ctx, nonExistingID, tx)
 	require.Error(t, err)
 	assert.Equal(t, "transaction not found", err.Error())
 }
+
+func TestTransactionRepository_Outbox(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+
+	tx := &models.Transaction{
+		ID:        primitive.NewObjectID().Hex(),
+		AccountID: "account789",
+		Type:      models.DEPOSIT,
+		Amount:    models.NewMoney(100),
+		Status:    models.PENDING,
+		Outbox:    models.NewOutbox(now),
+	}
+	require.NoError(t, repo.Create(ctx, tx))
+
+	t.Run("claim due entry", func(t *testing.T) {
+		claimed, err := repo.ClaimOutbox(ctx, now, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		assert.Equal(t, tx.ID, claimed.ID)
+		assert.Equal(t, 1, claimed.Outbox.Attempts)
+	})
+
+	t.Run("claimed entry is leased", func(t *testing.T) {
+		claimed, err := repo.ClaimOutbox(ctx, now, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, claimed)
+
+		claimed, err = repo.ClaimOutbox(ctx, now.Add(2*time.Minute), time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		assert.Equal(t, 2, claimed.Outbox.Attempts)
+	})
+
+	t.Run("sent entry is not claimed again", func(t *testing.T) {
+		require.NoError(t, repo.MarkOutboxSent(ctx, tx.ID, now))
+
+		claimed, err := repo.ClaimOutbox(ctx, now.Add(time.Hour), time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, claimed)
+
+		stored, err := repo.GetByID(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.OutboxSent, stored.Outbox.Status)
+	})
+
+	t.Run("worker update keeps outbox state", func(t *testing.T) {
+		processed := *tx
+		processed.Outbox = nil
+		processed.Status = models.SUCCESS
+		require.NoError(t, repo.Update(ctx, tx.ID, &processed))
+
+		stored, err := repo.GetByID(ctx, tx.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Outbox)
+		assert.Equal(t, models.OutboxSent, stored.Outbox.Status)
+	})
+}
+
+func TestTransactionRepository_ClaimReversal(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	original := &models.Transaction{
+		ID:        primitive.NewObjectID().Hex(),
+		AccountID: "account321",
+		Type:      models.DEPOSIT,
+		Amount:    models.NewMoney(100),
+		Status:    models.SUCCESS,
+	}
+	require.NoError(t, repo.Create(ctx, original))
+
+	claimed, err := repo.ClaimReversal(ctx, original.ID, "", "reversal-1")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.ClaimReversal(ctx, original.ID, "", "reversal-2")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	require.NoError(t, repo.ReleaseReversal(ctx, original.ID, "reversal-2"))
+	stored, err := repo.GetByID(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "reversal-1", stored.ReversedBy)
+
+	require.NoError(t, repo.ReleaseReversal(ctx, original.ID, "reversal-1"))
+	claimed, err = repo.ClaimReversal(ctx, original.ID, "", "reversal-3")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestTransactionRepository_GetLimitUsage(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	create := func(txType models.TransactionType, status models.TransactionStatus, amount models.Money, createdAt time.Time) {
+		require.NoError(t, repo.Create(ctx, &models.Transaction{
+			ID:        primitive.NewObjectID().Hex(),
+			AccountID: "limited",
+			Type:      txType,
+			Amount:    amount,
+			Status:    status,
+			CreatedAt: createdAt,
+		}))
+	}
+	create(models.WITHDRAWL, models.SUCCESS, models.NewMoney(10), now.Add(-30*time.Second))
+	create(models.TRANSFER, models.PENDING, models.NewMoney(20), now.Add(-time.Hour))
+	create(models.AUTHORIZATION, models.SUCCESS, models.NewMoney(40), now.Add(-5*24*time.Hour))
+	create(models.WITHDRAWL, models.FAILED, models.NewMoney(80), now.Add(-time.Hour))
+	create(models.DEPOSIT, models.SUCCESS, models.NewMoney(160), now.Add(-time.Hour))
+	create(models.WITHDRAWL, models.SUCCESS, models.NewMoney(320), now.AddDate(0, -1, 0))
+
+	usage, err := repo.GetLimitUsage(ctx, "limited", models.NewLimitWindows(now))
+	require.NoError(t, err)
+	assert.Equal(t, 1, usage.MinuteCount)
+	assert.Equal(t, 2, usage.DailyCount)
+	assert.Equal(t, models.NewMoney(30), usage.DailyAmount)
+	assert.Equal(t, 3, usage.MonthlyCount)
+	assert.Equal(t, models.NewMoney(70), usage.MonthlyAmount)
+
+	usage, err = repo.GetLimitUsage(ctx, "unused", models.NewLimitWindows(now))
+	require.NoError(t, err)
+	assert.Equal(t, models.LimitUsage{}, usage)
+}
+
+func TestTransactionRepository_HasPending(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	transfer := &models.Transaction{
+		ID:                   primitive.NewObjectID().Hex(),
+		AccountID:            "sender",
+		DestinationAccountID: "receiver",
+		Type:                 models.TRANSFER,
+		Amount:               models.NewMoney(10),
+		Status:               models.PENDING,
+	}
+	require.NoError(t, repo.Create(ctx, transfer))
+	require.NoError(t, repo.Create(ctx, &models.Transaction{
+		ID:        primitive.NewObjectID().Hex(),
+		AccountID: "settled",
+		Type:      models.DEPOSIT,
+		Amount:    models.NewMoney(10),
+		Status:    models.SUCCESS,
+	}))
+
+	for accountID, want := range map[string]bool{"sender": true, "receiver": true, "settled": false} {
+		pending, err := repo.HasPending(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, want, pending, accountID)
+	}
+
+	pending, err := repo.HasOtherPending(ctx, "sender", transfer.ID)
+	require.NoError(t, err)
+	assert.False(t, pending)
+	pending, err = repo.HasOtherPending(ctx, "sender", primitive.NewObjectID().Hex())
+	require.NoError(t, err)
+	assert.True(t, pending)
+}
+
+func TestTransactionRepository_IsProcessing(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	executeAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, repo.Create(ctx, &models.Transaction{
+		ID:                   primitive.NewObjectID().Hex(),
+		AccountID:            "sender",
+		DestinationAccountID: "receiver",
+		Type:                 models.TRANSFER,
+		Amount:               models.NewMoney(10),
+		Status:               models.PENDING,
+	}))
+	require.NoError(t, repo.Create(ctx, &models.Transaction{
+		ID:        primitive.NewObjectID().Hex(),
+		AccountID: "scheduled",
+		Type:      models.WITHDRAWL,
+		Amount:    models.NewMoney(10),
+		Status:    models.SCHEDULED,
+		ExecuteAt: &executeAt,
+	}))
+
+	for accountID, want := range map[string]bool{"sender": true, "receiver": true, "scheduled": false} {
+		processing, err := repo.IsProcessing(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, want, processing, accountID)
+	}
+}
+
+func TestTransactionRepository_Scheduled(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	schedule := func(executeAt time.Time) *models.Transaction {
+		tx := &models.Transaction{
+			ID:        primitive.NewObjectID().Hex(),
+			AccountID: "scheduler",
+			Type:      models.DEPOSIT,
+			Amount:    models.NewMoney(10),
+			Status:    models.SCHEDULED,
+			ExecuteAt: &executeAt,
+		}
+		require.NoError(t, repo.Create(ctx, tx))
+		return tx
+	}
+	due := schedule(now.Add(-time.Minute))
+	later := schedule(now.Add(time.Hour))
+	cancelled := schedule(now.Add(2 * time.Hour))
+
+	scheduled, err := repo.GetScheduled(ctx, "scheduler")
+	require.NoError(t, err)
+	require.Len(t, scheduled, 3)
+	assert.Equal(t, due.ID, scheduled[0].ID)
+
+	_, err = repo.CancelScheduled(ctx, cancelled.ID, now)
+	require.NoError(t, err)
+	_, err = repo.CancelScheduled(ctx, cancelled.ID, now)
+	assert.ErrorIs(t, err, models.ErrTransactionNotScheduled)
+
+	released, err := repo.ReleaseScheduled(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	tx, err := repo.GetByID(ctx, due.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PENDING, tx.Status)
+	require.NotNil(t, tx.Outbox)
+	assert.Equal(t, models.OutboxPending, tx.Outbox.Status)
+
+	tx, err = repo.GetByID(ctx, later.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.SCHEDULED, tx.Status)
+}
+
+func TestTransactionRepository_GetSettled(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	transfer := &models.Transaction{ID: "transfer", AccountID: "sender", DestinationAccountID: "receiver", Type: models.TRANSFER, Amount: models.NewMoney(10), Status: models.SUCCESS, ProcessedAt: start.Add(-time.Hour)}
+	for _, tx := range []*models.Transaction{
+		transfer,
+		{ID: "reversal", AccountID: "sender", ReversalOf: "transfer", Type: models.REVERSAL, Amount: models.NewMoney(10), Status: models.SUCCESS, ProcessedAt: start.Add(2 * time.Hour)},
+		{ID: "deposit", AccountID: "receiver", Type: models.DEPOSIT, Amount: models.NewMoney(5), Status: models.SUCCESS, ProcessedAt: start.Add(time.Hour)},
+		{ID: "failed", AccountID: "receiver", Type: models.WITHDRAWL, Amount: models.NewMoney(5), Status: models.FAILED, ProcessedAt: start.Add(time.Hour)},
+		{ID: "payout", AccountID: "closed", Type: models.CLOSURE, Amount: models.NewMoney(7), Status: models.SUCCESS, ProcessedAt: start.Add(3 * time.Hour), Closure: &models.AccountClosure{PayoutAccountID: "receiver"}},
+		{ID: "later", AccountID: "receiver", Type: models.DEPOSIT, Amount: models.NewMoney(5), Status: models.SUCCESS, ProcessedAt: end},
+	} {
+		require.NoError(t, repo.Create(ctx, tx))
+	}
+
+	settled, err := repo.GetSettled(ctx, "receiver", start, end)
+	require.NoError(t, err)
+	var ids []string
+	for _, tx := range settled {
+		ids = append(ids, tx.ID)
+	}
+	assert.Equal(t, []string{"deposit", "reversal", "payout"}, ids)
+
+	settled, err = repo.GetSettled(ctx, "receiver", time.Time{}, start)
+	require.NoError(t, err)
+	require.Len(t, settled, 1)
+	assert.Equal(t, transfer.ID, settled[0].ID)
+}
+
+func TestTransactionRepository_CountPending(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	due := end.Add(-time.Minute)
+	later := end.Add(time.Hour)
+
+	for _, tx := range []*models.Transaction{
+		{ID: "pending", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.PENDING, CreatedAt: start.Add(time.Hour)},
+		{ID: "settled", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.SUCCESS, CreatedAt: start.Add(time.Hour)},
+		{ID: "tomorrow", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.PENDING, CreatedAt: end},
+		{ID: "due", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.SCHEDULED, CreatedAt: start.AddDate(0, 0, -5), ExecuteAt: &due},
+		{ID: "later", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.SCHEDULED, CreatedAt: start, ExecuteAt: &later},
+	} {
+		require.NoError(t, repo.Create(ctx, tx))
+	}
+
+	pending, err := repo.CountPending(ctx, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+}
+
+func TestTransactionRepository_GetUnchained(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, tx := range []*models.Transaction{
+		{ID: "later", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.SUCCESS, ProcessedAt: now.Add(time.Minute)},
+		{ID: "failed", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.FAILED, ProcessedAt: now},
+		{ID: "pending", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.PENDING},
+		{ID: "chained", AccountID: "account", Type: models.DEPOSIT, Amount: models.NewMoney(1), Status: models.SUCCESS, ProcessedAt: now},
+	} {
+		require.NoError(t, repo.Create(ctx, tx))
+	}
+	require.NoError(t, repo.MarkChained(ctx, "chained", now))
+
+	unchained, err := repo.GetUnchained(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unchained, 2)
+	assert.Equal(t, "failed", unchained[0].ID)
+	assert.Equal(t, "later", unchained[1].ID)
+
+	limited, err := repo.GetUnchained(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
@@ -3,13 +3,17 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
 type AccountRepository struct {
 	db *gorm.DB
 }
@@ -31,17 +35,60 @@ func (r *AccountRepository) GetByID(ctx context.Context, id uuid.UUID) (models.A
 
 	if err := r.db.WithContext(ctx).First(&account, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.Account{}, errors.New("account not found")
+			return models.Account{}, models.ErrAccountNotFound
 		}
 		return models.Account{}, err
 	}
 	return account, nil
 }
 
+// Create stores the account together with the journal of its opening balance.
 func (r *AccountRepository) Create(ctx context.Context, account *models.Account) error {
-	return r.db.WithContext(ctx).Create(account).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		if account.Balance == 0 {
+			return nil
+		}
+		opening := models.NewOpeningJournal(account)
+		return insertJournal(tx, &opening)
+	})
 }
-func (r *AccountRepository) Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error {
+
+// ChangeBalance adds delta to the account's balance in a single conditional
+// UPDATE and returns the new balance. A negative delta is only applied if it
+// is covered by the available balance, the balance less funds held for
+// authorizations plus the overdraft limit, so concurrent debits cannot take
+// the account past its limit; otherwise
+// it returns ErrInsufficientFunds and changes nothing. Money moved
+// between accounts must go through LedgerRepository.PostJournal, which uses
+// this inside its transaction.
+func (r *AccountRepository) ChangeBalance(ctx context.Context, id uuid.UUID, delta models.Money) (models.Money, error) {
+	var balances []models.Money
+	result := r.db.WithContext(ctx).Raw(
+		`UPDATE accounts SET balance = balance + ?, updated_at = ?
+		WHERE id = ? AND (? >= 0 OR balance - held_balance + overdraft_limit + ? >= 0)
+		RETURNING balance`,
+		delta, time.Now(), id, delta, delta,
+	).Scan(&balances)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if len(balances) == 1 {
+		return balances[0], nil
+	}
+
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return 0, err
+	}
+	return 0, ErrInsufficientFunds
+}
+
+// Update applies the changes only if the account is still at the expected
+// version, and bumps the version. It returns models.ErrStaleVersion if the
+// account has changed since that version was read.
+func (r *AccountRepository) Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error {
 	updateData := map[string]interface{}{}
 
 	if updates.FirstName != nil {
@@ -53,22 +100,141 @@ func (r *AccountRepository) Update(ctx context.Context, id uuid.UUID, updates mo
 	if updates.Phone != nil {
 		updateData["phone"] = *updates.Phone
 	}
-	if updates.Balance != nil {
-		updateData["balance"] = *updates.Balance
-	}
 
 	updateData["updated_at"] = time.Now()
+	updateData["version"] = gorm.Expr("version + 1")
 
-	result := r.db.WithContext(ctx).Model(&models.Account{}).Where("id = ?", id).Updates(updateData)
+	result := r.db.WithContext(ctx).Model(&models.Account{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
 	if result.RowsAffected == 0 {
-		return errors.New("account not found")
+		return r.versionConflict(ctx, id)
 	}
-	return result.Error
+	return nil
 }
-func (r *AccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.Account{}, "id = ?", id)
-	if result.RowsAffected == 0 {
-		return errors.New("account not found")
+
+// SetOverdraftLimit changes the account's overdraft limit and records the
+// change, with the limit it replaced, in the same database transaction.
+// Lowering the limit below what is already used leaves the balance as it is
+// but blocks further debits.
+func (r *AccountRepository) SetOverdraftLimit(ctx context.Context, change *models.OverdraftLimitChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := lockAccount(tx, change.AccountID)
+		if err != nil {
+			return err
+		}
+
+		change.PreviousLimit = account.OverdraftLimit
+		err = tx.Model(&account).Updates(map[string]interface{}{
+			"overdraft_limit": change.NewLimit,
+			"updated_at":      time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *AccountRepository) GetOverdraftLimitChanges(ctx context.Context, id uuid.UUID) ([]models.OverdraftLimitChange, error) {
+	var changes []models.OverdraftLimitChange
+	err := r.db.WithContext(ctx).
+		Where("account_id = ?", id).
+		Order("created_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
+
+// ChangeStatus moves the account to change.ToStatus if its current status
+// allows it, and records the change in the same database transaction.
+// Accounts are not closed here but with Close, which pays out their balance.
+func (r *AccountRepository) ChangeStatus(ctx context.Context, change *models.AccountStatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := lockAccount(tx, change.AccountID)
+		if err != nil {
+			return err
+		}
+		if !account.Status.CanTransitionTo(change.ToStatus) || change.ToStatus == models.AccountClosed {
+			return fmt.Errorf("%w: %s to %s", models.ErrInvalidStatusTransition, account.Status, change.ToStatus)
+		}
+
+		change.FromStatus = account.Status
+		err = tx.Model(&account).Updates(map[string]interface{}{
+			"status":     change.ToStatus,
+			"updated_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// Close posts the journal paying out a closing account's balance and closes
+// the account, in one database transaction. The journal must pay out exactly
+// the current balance, with no funds held, or ErrClosureBalanceChanged is
+// returned and nothing changes. Posting the same closure again returns
+// ErrJournalAlreadyPosted.
+func (r *AccountRepository) Close(ctx context.Context, journal *models.Journal, change *models.AccountStatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := insertJournal(tx, journal); err != nil {
+			return err
+		}
+
+		account, err := lockAccount(tx, change.AccountID)
+		if err != nil {
+			return err
+		}
+		if !account.Status.CanTransitionTo(models.AccountClosed) {
+			return fmt.Errorf("%w: %s to %s", models.ErrInvalidStatusTransition, account.Status, models.AccountClosed)
+		}
+		payout := -journal.NetChange(models.CustomerLedgerAccount(account.ID))
+		if account.HeldBalance != 0 || account.Balance != payout {
+			return models.ErrClosureBalanceChanged
+		}
+		if err := applyJournal(tx, journal); err != nil {
+			return err
+		}
+
+		change.FromStatus = account.Status
+		change.ToStatus = models.AccountClosed
+		err = tx.Model(&account).Updates(map[string]interface{}{
+			"status":     models.AccountClosed,
+			"updated_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *AccountRepository) GetStatusChanges(ctx context.Context, id uuid.UUID) ([]models.AccountStatusChange, error) {
+	var changes []models.AccountStatusChange
+	err := r.db.WithContext(ctx).
+		Where("account_id = ?", id).
+		Order("created_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
+
+func lockAccount(tx *gorm.DB, id uuid.UUID) (models.Account, error) {
+	var account models.Account
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Account{}, models.ErrAccountNotFound
+	}
+	return account, err
+}
+
+// versionConflict explains why a versioned write matched no rows: either the
+// account does not exist or it is at a different version.
+func (r *AccountRepository) versionConflict(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
 	}
-	return result.Error
+	return models.ErrStaleVersion
 }
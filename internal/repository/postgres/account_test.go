@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	ledgerdb "github.com/RajVerma97/golang-banking-ledger/internal/db"
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/docker/go-connections/nat"
 	"github.com/google/uuid"
@@ -19,6 +22,11 @@ import (
 )
 
 func setupRepo(t *testing.T) (*AccountRepository, func()) {
+	db, cleanup := setupDB(t)
+	return NewAccountRepository(db), cleanup
+}
+
+func setupDB(t *testing.T) (*gorm.DB, func()) {
 	ctx := context.Background()
 
 	req := testcontainers.ContainerRequest{
@@ -73,13 +81,13 @@ func setupRepo(t *testing.T) (*AccountRepository, func()) {
 	require.NoError(t, err, "Failed to create UUID extension")
 
 	
-	err = db.AutoMigrate(&models.Account{})
+	err = ledgerdb.Migrate(db)
 	require.NoError(t, err)
 
 	
 	t.Logf("Successfully connected to PostgreSQL at: %s:%s", host, port.Port())
 
-	return NewAccountRepository(db), func() {
+	return db, func() {
 		if err := postgresContainer.Terminate(ctx); err != nil {
 			t.Fatalf("failed to terminate container: %s", err)
 		}
@@ -108,7 +116,7 @@ func TestAccountRepository_GetAll(t *testing.T) {
 			LastName:      "Doe",
 			Email:         "john@example.com",
 			Phone:         "1234567890",
-			Balance:       100.0,
+			Balance:       models.NewMoney(100),
 		}
 		account2 := models.Account{
 			ID:            uuid.New(),
@@ -117,7 +125,7 @@ func TestAccountRepository_GetAll(t *testing.T) {
 			LastName:      "Smith",
 			Email:         "jane@example.com",
 			Phone:         "0987654321",
-			Balance:       200.0,
+			Balance:       models.NewMoney(200),
 		}
 
 		require.NoError(t, repo.Create(ctx, &account1))
@@ -145,7 +153,7 @@ func TestAccountRepository_GetByID(t *testing.T) {
 			LastName:  "Brown",
 			Email:     "alice@example.com",
 			Phone:     "5555555555",
-			Balance:   300.0,
+			Balance:   models.NewMoney(300),
 		}
 		require.NoError(t, repo.Create(ctx, &account))
 
@@ -181,7 +189,7 @@ func TestAccountRepository_Create(t *testing.T) {
 		LastName:  "Green",
 		Email:     "bob@example.com",
 		Phone:     "4444444444",
-		Balance:   400.0,
+		Balance:   models.NewMoney(400),
 	}
 
 	err := repo.Create(ctx, &account)
@@ -205,22 +213,20 @@ func TestAccountRepository_Update(t *testing.T) {
 			LastName:  "Name",
 			Email:     "original@example.com",
 			Phone:     "1111111111",
-			Balance:   100.0,
+			Balance:   models.NewMoney(100),
 		}
 		require.NoError(t, repo.Create(ctx, &account))
 
 		newFirstName := "Updated"
 		newLastName := "Surname"
 		newPhone := "2222222222"
-		newBalance := 200.0
 		updates := models.AccountUpdate{
 			FirstName: &newFirstName,
 			LastName:  &newLastName,
 			Phone:     &newPhone,
-			Balance:   &newBalance,
 		}
 
-		err := repo.Update(ctx, account.ID, updates)
+		err := repo.Update(ctx, account.ID, 1, updates)
 		require.NoError(t, err)
 
 		updatedAccount, err := repo.GetByID(ctx, account.ID)
@@ -228,8 +234,32 @@ func TestAccountRepository_Update(t *testing.T) {
 		assert.Equal(t, newFirstName, updatedAccount.FirstName)
 		assert.Equal(t, newLastName, updatedAccount.LastName)
 		assert.Equal(t, newPhone, updatedAccount.Phone)
-		assert.Equal(t, newBalance, updatedAccount.Balance)
+		assert.Equal(t, models.NewMoney(100), updatedAccount.Balance)
 		assert.False(t, updatedAccount.UpdatedAt.IsZero())
+		assert.Equal(t, int64(2), updatedAccount.Version)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		repo, cleanup := setupRepo(t)
+		defer cleanup()
+		ctx := context.Background()
+
+		account := models.Account{
+			ID:        uuid.New(),
+			FirstName: "Versioned",
+			Email:     "versioned@example.com",
+		}
+		require.NoError(t, repo.Create(ctx, &account))
+
+		require.NoError(t, repo.Update(ctx, account.ID, 1, models.AccountUpdate{FirstName: strPtr("First")}))
+
+		err := repo.Update(ctx, account.ID, 1, models.AccountUpdate{FirstName: strPtr("Second")})
+		assert.ErrorIs(t, err, models.ErrStaleVersion)
+
+		updatedAccount, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "First", updatedAccount.FirstName)
+		assert.Equal(t, int64(2), updatedAccount.Version)
 	})
 
 	t.Run("partial update", func(t *testing.T) {
@@ -243,18 +273,16 @@ func TestAccountRepository_Update(t *testing.T) {
 			LastName:  "Update",
 			Email:     "partial@example.com",
 			Phone:     "3333333333",
-			Balance:   150.0,
+			Balance:   models.NewMoney(150),
 		}
 		require.NoError(t, repo.Create(ctx, &account))
 
 		newFirstName := "PartiallyUpdated"
-		newBalance := 250.0
 		updates := models.AccountUpdate{
 			FirstName: &newFirstName,
-			Balance:   &newBalance,
 		}
 
-		err := repo.Update(ctx, account.ID, updates)
+		err := repo.Update(ctx, account.ID, 1, updates)
 		require.NoError(t, err)
 
 		updatedAccount, err := repo.GetByID(ctx, account.ID)
@@ -262,7 +290,7 @@ func TestAccountRepository_Update(t *testing.T) {
 		assert.Equal(t, newFirstName, updatedAccount.FirstName)
 		assert.Equal(t, "Update", updatedAccount.LastName)  
 		assert.Equal(t, "3333333333", updatedAccount.Phone) 
-		assert.Equal(t, newBalance, updatedAccount.Balance)
+		assert.Equal(t, models.NewMoney(150), updatedAccount.Balance)
 	})
 
 	t.Run("non-existing account", func(t *testing.T) {
@@ -271,51 +299,251 @@ func TestAccountRepository_Update(t *testing.T) {
 		ctx := context.Background()
 
 		updates := models.AccountUpdate{FirstName: strPtr("NonExistent")}
-		err := repo.Update(ctx, uuid.New(), updates)
+		err := repo.Update(ctx, uuid.New(), 1, updates)
 		require.Error(t, err)
 		assert.EqualError(t, err, "account not found")
 	})
 }
 
-func TestAccountRepository_Delete(t *testing.T) {
-	t.Run("existing account", func(t *testing.T) {
-		repo, cleanup := setupRepo(t)
-		defer cleanup()
-		ctx := context.Background()
+func strPtr(s string) *string {
+	return &s
+}
 
-		account := models.Account{
-			ID:        uuid.New(),
-			FirstName: "Delete",
-			LastName:  "Me",
-			Email:     "delete@example.com",
-			Phone:     "9999999999",
-			Balance:   500.0,
-		}
-		require.NoError(t, repo.Create(ctx, &account))
+func TestAccountRepository_ChangeBalance(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Carol",
+		Email:     "carol@example.com",
+		Balance:   models.NewMoney(100),
+	}
+	require.NoError(t, repo.Create(ctx, &account))
 
-		err := repo.Delete(ctx, account.ID)
+	t.Run("credit and debit", func(t *testing.T) {
+		balance, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(50))
 		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), balance)
 
-		_, err = repo.GetByID(ctx, account.ID)
-		require.Error(t, err)
-		assert.EqualError(t, err, "account not found")
+		balance, err = repo.ChangeBalance(ctx, account.ID, models.NewMoney(-150))
+		require.NoError(t, err)
+		assert.Equal(t, models.Money(0), balance)
 	})
 
-	t.Run("non-existing account", func(t *testing.T) {
-		repo, cleanup := setupRepo(t)
-		defer cleanup()
-		ctx := context.Background()
+	t.Run("insufficient funds", func(t *testing.T) {
+		_, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(-1))
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+	})
 
-		err := repo.Delete(ctx, uuid.New())
-		require.Error(t, err)
+	t.Run("non-existing account", func(t *testing.T) {
+		_, err := repo.ChangeBalance(ctx, uuid.New(), models.NewMoney(10))
 		assert.EqualError(t, err, "account not found")
 	})
+
+	t.Run("concurrent debits cannot overdraw", func(t *testing.T) {
+		_, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(100))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		var succeeded atomic.Int32
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(-30)); err == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(3), succeeded.Load())
+		updated, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(10), updated.Balance)
+	})
 }
 
-func strPtr(s string) *string {
-	return &s
+func TestAccountRepository_SetOverdraftLimit(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Dave",
+		Email:     "dave@example.com",
+		Balance:   models.NewMoney(20),
+	}
+	require.NoError(t, repo.Create(ctx, &account))
+
+	setLimit := func(t *testing.T, limit models.Money) *models.OverdraftLimitChange {
+		change := &models.OverdraftLimitChange{
+			ID:         uuid.New(),
+			AccountID:  account.ID,
+			NewLimit:   limit,
+			Reason:     "approved credit line",
+			OperatorID: "ops-1",
+		}
+		require.NoError(t, repo.SetOverdraftLimit(ctx, change))
+		return change
+	}
+
+	t.Run("debits may use the overdraft", func(t *testing.T) {
+		change := setLimit(t, models.NewMoney(50))
+		assert.Equal(t, models.Money(0), change.PreviousLimit)
+
+		balance, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(-60))
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(-40), balance)
+
+		_, err = repo.ChangeBalance(ctx, account.ID, models.NewMoney(-11))
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		updated, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(10), updated.AvailableBalance)
+		assert.Equal(t, models.NewMoney(40), updated.OverdraftUsed())
+	})
+
+	t.Run("lowering the limit blocks further debits", func(t *testing.T) {
+		change := setLimit(t, 0)
+		assert.Equal(t, models.NewMoney(50), change.PreviousLimit)
+
+		_, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(-1))
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		changes, err := repo.GetOverdraftLimitChanges(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Len(t, changes, 2)
+	})
+
+	t.Run("non-existing account", func(t *testing.T) {
+		err := repo.SetOverdraftLimit(ctx, &models.OverdraftLimitChange{ID: uuid.New(), AccountID: uuid.New()})
+		assert.ErrorIs(t, err, models.ErrAccountNotFound)
+	})
 }
 
-func float64Ptr(f float64) *float64 {
-	return &f
+func TestAccountRepository_ChangeStatus(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Erin",
+		Email:     "erin@example.com",
+		Balance:   models.NewMoney(10),
+	}
+	require.NoError(t, repo.Create(ctx, &account))
+
+	changeStatus := func(status models.AccountStatus) (*models.AccountStatusChange, error) {
+		change := &models.AccountStatusChange{
+			ID:         uuid.New(),
+			AccountID:  account.ID,
+			ToStatus:   status,
+			Reason:     "review",
+			OperatorID: "ops-1",
+		}
+		return change, repo.ChangeStatus(ctx, change)
+	}
+
+	t.Run("freeze and unfreeze", func(t *testing.T) {
+		change, err := changeStatus(models.AccountFrozen)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccountActive, change.FromStatus)
+
+		updated, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccountFrozen, updated.Status)
+
+		_, err = changeStatus(models.AccountDormant)
+		assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+
+		_, err = changeStatus(models.AccountActive)
+		require.NoError(t, err)
+	})
+
+	t.Run("cannot close", func(t *testing.T) {
+		_, err := repo.ChangeBalance(ctx, account.ID, models.NewMoney(-10))
+		require.NoError(t, err)
+		_, err = changeStatus(models.AccountClosed)
+		assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+
+		updated, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccountActive, updated.Status)
+
+		changes, err := repo.GetStatusChanges(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Len(t, changes, 2)
+	})
+}
+
+func TestAccountRepository_Close(t *testing.T) {
+	repo, cleanup := setupRepo(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Frank",
+		Email:     "frank@example.com",
+		Balance:   models.NewMoney(40),
+	}
+	payout := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Grace",
+		Email:     "grace@example.com",
+	}
+	require.NoError(t, repo.Create(ctx, &account))
+	require.NoError(t, repo.Create(ctx, &payout))
+
+	closure := func(amount models.Money) models.Journal {
+		journal, err := models.NewTransactionJournal(&models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.CLOSURE,
+			Amount:    amount,
+			Currency:  models.DefaultCurrency,
+			AccountID: account.ID.String(),
+			Closure:   &models.AccountClosure{PayoutAccountID: payout.ID.String(), Reason: "customer request"},
+		})
+		require.NoError(t, err)
+		return journal
+	}
+	change := func() *models.AccountStatusChange {
+		return &models.AccountStatusChange{ID: uuid.New(), AccountID: account.ID, Reason: "customer request"}
+	}
+
+	t.Run("balance changed since the request", func(t *testing.T) {
+		journal := closure(models.NewMoney(30))
+		err := repo.Close(ctx, &journal, change())
+		assert.ErrorIs(t, err, models.ErrClosureBalanceChanged)
+
+		unchanged, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccountActive, unchanged.Status)
+		assert.Equal(t, models.NewMoney(40), unchanged.Balance)
+	})
+
+	t.Run("pays out and closes", func(t *testing.T) {
+		journal := closure(models.NewMoney(40))
+		statusChange := change()
+		require.NoError(t, repo.Close(ctx, &journal, statusChange))
+		assert.Equal(t, models.AccountActive, statusChange.FromStatus)
+
+		closed, err := repo.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccountClosed, closed.Status)
+		assert.Equal(t, models.Money(0), closed.Balance)
+
+		credited, err := repo.GetByID(ctx, payout.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(40), credited.Balance)
+
+		err = repo.Close(ctx, &journal, change())
+		assert.ErrorIs(t, err, ErrJournalAlreadyPosted)
+	})
 }
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BalanceSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewBalanceSnapshotRepository(db *gorm.DB) *BalanceSnapshotRepository {
+	return &BalanceSnapshotRepository{db: db}
+}
+
+// Create records the snapshot unless the account already has one taken at the
+// same time, and reports whether it did.
+func (r *BalanceSnapshotRepository) Create(ctx context.Context, snapshot *models.BalanceSnapshot) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(snapshot)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetLatest returns the account's latest snapshot taken at or before at.
+func (r *BalanceSnapshotRepository) GetLatest(ctx context.Context, accountID uuid.UUID, at time.Time) (models.BalanceSnapshot, error) {
+	var snapshot models.BalanceSnapshot
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND taken_at <= ?", accountID, at).
+		Order("taken_at DESC").
+		First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.BalanceSnapshot{}, models.ErrBalanceSnapshotNotFound
+	}
+	return snapshot, err
+}
+
+// GetRange returns the account's snapshots taken at or after from and before
+// until, oldest first.
+func (r *BalanceSnapshotRepository) GetRange(ctx context.Context, accountID uuid.UUID, from, until time.Time) ([]models.BalanceSnapshot, error) {
+	snapshots := []models.BalanceSnapshot{}
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND taken_at >= ? AND taken_at < ?", accountID, from, until).
+		Order("taken_at ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
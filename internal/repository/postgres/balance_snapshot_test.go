@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceSnapshotRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewBalanceSnapshotRepository(db)
+	accountID := uuid.New()
+	first := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 1)
+
+	for _, snapshot := range []models.BalanceSnapshot{
+		{AccountID: accountID, TakenAt: first, Balance: models.NewMoney(100), Currency: models.DefaultCurrency},
+		{AccountID: accountID, TakenAt: second, Balance: models.NewMoney(150), Currency: models.DefaultCurrency, DayTotals: models.DayTotals{Deposits: models.NewMoney(50), DepositCount: 1}},
+	} {
+		created, err := repo.Create(ctx, &snapshot)
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	t.Run("taking a snapshot again changes nothing", func(t *testing.T) {
+		created, err := repo.Create(ctx, &models.BalanceSnapshot{AccountID: accountID, TakenAt: first, Balance: models.NewMoney(1), Currency: models.DefaultCurrency})
+		require.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("latest snapshot by a moment", func(t *testing.T) {
+		snapshot, err := repo.GetLatest(ctx, accountID, second.Add(-time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(100), snapshot.Balance)
+
+		snapshot, err = repo.GetLatest(ctx, accountID, second)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), snapshot.Balance)
+
+		_, err = repo.GetLatest(ctx, accountID, first.Add(-time.Second))
+		assert.ErrorIs(t, err, models.ErrBalanceSnapshotNotFound)
+	})
+
+	t.Run("snapshots in a range", func(t *testing.T) {
+		snapshots, err := repo.GetRange(ctx, accountID, first.Add(time.Second), second.Add(time.Second))
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, models.NewMoney(50), snapshots[0].Deposits)
+		assert.Equal(t, 1, snapshots[0].DepositCount)
+	})
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BusinessDayRepository struct {
+	db *gorm.DB
+}
+
+func NewBusinessDayRepository(db *gorm.DB) *BusinessDayRepository {
+	return &BusinessDayRepository{db: db}
+}
+
+// Start records that the day's close has started, unless it already has.
+func (r *BusinessDayRepository) Start(ctx context.Context, day *models.BusinessDay) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(day).Error
+}
+
+func (r *BusinessDayRepository) Get(ctx context.Context, date time.Time) (models.BusinessDay, error) {
+	var day models.BusinessDay
+	err := r.db.WithContext(ctx).First(&day, "date = ?", date).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.BusinessDay{}, models.ErrBusinessDayNotFound
+	}
+	return day, err
+}
+
+// GetLatest returns the latest day whose close has started.
+func (r *BusinessDayRepository) GetLatest(ctx context.Context) (models.BusinessDay, error) {
+	var day models.BusinessDay
+	err := r.db.WithContext(ctx).Order("date DESC").First(&day).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.BusinessDay{}, models.ErrBusinessDayNotFound
+	}
+	return day, err
+}
+
+// GetAll returns the business days, latest first.
+func (r *BusinessDayRepository) GetAll(ctx context.Context) ([]models.BusinessDay, error) {
+	days := []models.BusinessDay{}
+	err := r.db.WithContext(ctx).Order("date DESC").Find(&days).Error
+	return days, err
+}
+
+// Close records the day as closed with its totals.
+func (r *BusinessDayRepository) Close(ctx context.Context, day *models.BusinessDay) error {
+	result := r.db.WithContext(ctx).Model(&models.BusinessDay{}).
+		Where("date = ?", day.Date).
+		Select("Status", "Accounts", "Deposits", "DepositCount", "Withdrawals", "WithdrawalCount", "Credits", "Debits", "TransactionCount", "ClosedAt").
+		Updates(day)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrBusinessDayNotFound
+	}
+	return nil
+}
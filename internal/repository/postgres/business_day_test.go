@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessDayRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewBusinessDayRepository(db)
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	_, err := repo.GetLatest(ctx)
+	assert.ErrorIs(t, err, models.ErrBusinessDayNotFound)
+
+	day := models.BusinessDay{Date: date, Status: models.BusinessDayClosing, StartedAt: date.AddDate(0, 0, 1)}
+	require.NoError(t, repo.Start(ctx, &day))
+	require.NoError(t, repo.Start(ctx, &models.BusinessDay{Date: date, Status: models.BusinessDayClosing, StartedAt: time.Now()}))
+
+	stored, err := repo.Get(ctx, date)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessDayClosing, stored.Status)
+	assert.True(t, stored.StartedAt.Equal(day.StartedAt))
+
+	closedAt := date.AddDate(0, 0, 1).Add(time.Hour)
+	day.Status = models.BusinessDayClosed
+	day.ClosedAt = &closedAt
+	day.Accounts = 2
+	day.DayTotals = models.DayTotals{Deposits: models.NewMoney(40), DepositCount: 1, Credits: models.NewMoney(40), TransactionCount: 1}
+	require.NoError(t, repo.Close(ctx, &day))
+
+	latest, err := repo.GetLatest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.BusinessDayClosed, latest.Status)
+	assert.Equal(t, 2, latest.Accounts)
+	assert.Equal(t, models.NewMoney(40), latest.Deposits)
+
+	err = repo.Close(ctx, &models.BusinessDay{Date: date.AddDate(0, 0, 1)})
+	assert.ErrorIs(t, err, models.ErrBusinessDayNotFound)
+}
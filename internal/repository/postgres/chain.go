@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ChainRepository struct {
+	db *gorm.DB
+}
+
+func NewChainRepository(db *gorm.DB) *ChainRepository {
+	return &ChainRepository{db: db}
+}
+
+// Append links the transaction to the end of the account's chain and returns
+// its link, or returns the link it already has. Two appends to the same chain
+// at once conflict on the sequence, and the one that loses fails rather than
+// forking the chain.
+func (r *ChainRepository) Append(ctx context.Context, accountID string, tx *models.Transaction, now time.Time) (models.ChainLink, error) {
+	var link models.ChainLink
+	err := r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		err := db.First(&link, "account_id = ? AND transaction_id = ?", accountID, tx.ID).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var previous *models.ChainLink
+		head, err := getHead(db, accountID)
+		if err == nil {
+			previous = &head
+		} else if !errors.Is(err, models.ErrChainLinkNotFound) {
+			return err
+		}
+		if link, err = models.NewChainLink(previous, accountID, tx, now); err != nil {
+			return err
+		}
+		return db.Create(&link).Error
+	})
+	if err != nil {
+		return models.ChainLink{}, err
+	}
+	return link, nil
+}
+
+func getHead(db *gorm.DB, accountID string) (models.ChainLink, error) {
+	var link models.ChainLink
+	err := db.Where("account_id = ?", accountID).Order("sequence DESC").First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ChainLink{}, models.ErrChainLinkNotFound
+	}
+	return link, err
+}
+
+// GetLinks returns the account's chain in order.
+func (r *ChainRepository) GetLinks(ctx context.Context, accountID string) ([]models.ChainLink, error) {
+	links := []models.ChainLink{}
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("sequence").Find(&links).Error
+	return links, err
+}
+
+// GetHeads returns the head of every account's chain, ordered by account.
+func (r *ChainRepository) GetHeads(ctx context.Context) ([]models.ChainHead, error) {
+	heads := []models.ChainHead{}
+	err := r.db.WithContext(ctx).Model(&models.ChainLink{}).
+		Select("DISTINCT ON (account_id) account_id, sequence, hash").
+		Order("account_id, sequence DESC").
+		Scan(&heads).Error
+	return heads, err
+}
+
+func (r *ChainRepository) CreateCheckpoint(ctx context.Context, checkpoint *models.ChainCheckpoint) error {
+	return r.db.WithContext(ctx).Create(checkpoint).Error
+}
+
+func (r *ChainRepository) GetCheckpoint(ctx context.Context, id uuid.UUID) (models.ChainCheckpoint, error) {
+	var checkpoint models.ChainCheckpoint
+	err := r.db.WithContext(ctx).First(&checkpoint, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ChainCheckpoint{}, models.ErrChainCheckpointNotFound
+	}
+	return checkpoint, err
+}
+
+func (r *ChainRepository) GetLatestCheckpoint(ctx context.Context) (models.ChainCheckpoint, error) {
+	var checkpoint models.ChainCheckpoint
+	err := r.db.WithContext(ctx).Order("created_at DESC").First(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ChainCheckpoint{}, models.ErrChainCheckpointNotFound
+	}
+	return checkpoint, err
+}
+
+// GetCheckpoints returns the latest checkpoints first, at most limit of them,
+// without their heads.
+func (r *ChainRepository) GetCheckpoints(ctx context.Context, limit int) ([]models.ChainCheckpoint, error) {
+	checkpoints := []models.ChainCheckpoint{}
+	err := r.db.WithContext(ctx).Omit("Heads").Order("created_at DESC").Limit(limit).Find(&checkpoints).Error
+	return checkpoints, err
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewChainRepository(db)
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	sender, receiver := uuid.NewString(), uuid.NewString()
+	deposit := &models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(40), AccountID: sender, Status: models.SUCCESS, ProcessedAt: now}
+	transfer := &models.Transaction{ID: uuid.NewString(), Type: models.TRANSFER, Amount: models.NewMoney(10), AccountID: sender, DestinationAccountID: receiver, Status: models.SUCCESS, ProcessedAt: now}
+
+	first, err := repo.Append(ctx, sender, deposit, now)
+	require.NoError(t, err)
+	second, err := repo.Append(ctx, sender, transfer, now)
+	require.NoError(t, err)
+	received, err := repo.Append(ctx, receiver, transfer, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.Equal(t, first.Hash, second.PreviousHash)
+	assert.Equal(t, int64(1), received.Sequence)
+
+	again, err := repo.Append(ctx, sender, transfer, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, second.Sequence, again.Sequence)
+	assert.Equal(t, second.Hash, again.Hash)
+
+	links, err := repo.GetLinks(ctx, sender)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, deposit.ID, links[0].TransactionID)
+
+	heads, err := repo.GetHeads(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.ChainHead{
+		{AccountID: sender, Sequence: 2, Hash: second.Hash},
+		{AccountID: receiver, Sequence: 1, Hash: received.Hash},
+	}, heads)
+
+	_, err = repo.GetLatestCheckpoint(ctx)
+	assert.ErrorIs(t, err, models.ErrChainCheckpointNotFound)
+
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	checkpoint := models.NewChainCheckpoint(heads, key, time.Now())
+	require.NoError(t, repo.CreateCheckpoint(ctx, &checkpoint))
+
+	latest, err := repo.GetLatestCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.ID, latest.ID)
+	assert.True(t, latest.Verify(key.Public().(ed25519.PublicKey)))
+
+	checkpoints, err := repo.GetCheckpoints(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+	assert.Empty(t, checkpoints[0].Heads)
+
+	_, err = repo.GetCheckpoint(ctx, uuid.New())
+	assert.ErrorIs(t, err, models.ErrChainCheckpointNotFound)
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeeRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeRuleRepository(db *gorm.DB) *FeeRuleRepository {
+	return &FeeRuleRepository{db: db}
+}
+
+func (r *FeeRuleRepository) Create(ctx context.Context, rule *models.FeeRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *FeeRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (models.FeeRule, error) {
+	var rule models.FeeRule
+	err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.FeeRule{}, models.ErrFeeRuleNotFound
+	}
+	return rule, err
+}
+
+func (r *FeeRuleRepository) GetAll(ctx context.Context) ([]models.FeeRule, error) {
+	rules := []models.FeeRule{}
+	err := r.db.WithContext(ctx).
+		Order("kind ASC, priority DESC, created_at ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetActive returns the active rules of a kind, in the order they take
+// precedence.
+func (r *FeeRuleRepository) GetActive(ctx context.Context, kind models.FeeKind) ([]models.FeeRule, error) {
+	var rules []models.FeeRule
+	err := r.db.WithContext(ctx).
+		Where("kind = ? AND active", kind).
+		Order("priority DESC, created_at ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// Update applies change to the rule while it is locked and saves the result.
+func (r *FeeRuleRepository) Update(ctx context.Context, id uuid.UUID, change func(*models.FeeRule) error) (models.FeeRule, error) {
+	var rule models.FeeRule
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&rule, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrFeeRuleNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := change(&rule); err != nil {
+			return err
+		}
+		return tx.Save(&rule).Error
+	})
+	if err != nil {
+		return models.FeeRule{}, err
+	}
+	return rule, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRuleRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewFeeRuleRepository(db)
+	rule, err := models.NewFeeRule(models.FeeRuleRequest{
+		Name:            "Withdrawal",
+		Kind:            models.FeeKindTransaction,
+		Method:          models.FeeTiered,
+		TransactionType: models.WITHDRAWL,
+		Currency:        models.DefaultCurrency,
+		Tiers:           []models.FeeTier{{Rate: models.Rate(1_000_000)}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, &rule))
+
+	t.Run("active rules", func(t *testing.T) {
+		rules, err := repo.GetActive(ctx, models.FeeKindTransaction)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, rule.Tiers, rules[0].Tiers)
+
+		rules, err = repo.GetActive(ctx, models.FeeKindMaintenance)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		_, err := repo.Update(ctx, rule.ID, func(r *models.FeeRule) error {
+			r.Active = false
+			return nil
+		})
+		require.NoError(t, err)
+
+		rules, err := repo.GetActive(ctx, models.FeeKindTransaction)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+
+		stored, err := repo.GetByID(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Active)
+	})
+}
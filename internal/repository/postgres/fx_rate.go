@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FXRateRepository struct {
+	db *gorm.DB
+}
+
+func NewFXRateRepository(db *gorm.DB) *FXRateRepository {
+	return &FXRateRepository{db: db}
+}
+
+// Create stores a rate. It returns ErrFXRateExists, and keeps the stored
+// rate, if the pair already has one taking effect at the same time.
+func (r *FXRateRepository) Create(ctx context.Context, rate *models.FXRate) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(rate)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s/%s at %s", models.ErrFXRateExists, rate.BaseCurrency, rate.QuoteCurrency, rate.EffectiveAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// GetEffective returns the most recent rate for the pair that had taken effect
+// at the given time.
+func (r *FXRateRepository) GetEffective(ctx context.Context, base, quote models.Currency, at time.Time) (models.FXRate, error) {
+	var rate models.FXRate
+	err := r.db.WithContext(ctx).
+		Where("base_currency = ? AND quote_currency = ? AND effective_at <= ?", base, quote, at).
+		Order("effective_at DESC").
+		First(&rate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.FXRate{}, fmt.Errorf("no FX rate for %s/%s", base, quote)
+	}
+	return rate, err
+}
+
+func (r *FXRateRepository) GetAll(ctx context.Context) ([]models.FXRate, error) {
+	var rates []models.FXRate
+	err := r.db.WithContext(ctx).
+		Order("base_currency, quote_currency, effective_at DESC").
+		Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFXRateRepository_GetEffective(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewFXRateRepository(db)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	older := &models.FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 90000000, EffectiveAt: day, Source: "test"}
+	newer := &models.FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 91000000, EffectiveAt: day.Add(24 * time.Hour), Source: "test"}
+	require.NoError(t, repo.Create(ctx, older))
+	require.NoError(t, repo.Create(ctx, newer))
+
+	t.Run("latest rate in effect", func(t *testing.T) {
+		rate, err := repo.GetEffective(ctx, "USD", "EUR", day.Add(36*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, models.Rate(91000000), rate.Rate)
+	})
+
+	t.Run("rate in effect at an earlier time", func(t *testing.T) {
+		rate, err := repo.GetEffective(ctx, "USD", "EUR", day.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, models.Rate(90000000), rate.Rate)
+	})
+
+	t.Run("no rate yet", func(t *testing.T) {
+		_, err := repo.GetEffective(ctx, "USD", "EUR", day.Add(-time.Hour))
+		assert.Error(t, err)
+	})
+
+	t.Run("reloading a rate keeps the first", func(t *testing.T) {
+		duplicate := &models.FXRate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 99000000, EffectiveAt: day, Source: "test"}
+		assert.ErrorIs(t, repo.Create(ctx, duplicate), models.ErrFXRateExists)
+
+		rates, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, rates, 2)
+	})
+}
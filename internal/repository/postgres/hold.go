@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HoldRepository struct {
+	db *gorm.DB
+}
+
+func NewHoldRepository(db *gorm.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// Place records the hold and adds it to the account's held balance, provided
+// the available balance covers it; otherwise it returns ErrInsufficientFunds.
+// Placing the same hold again changes nothing.
+func (r *HoldRepository) Place(ctx context.Context, hold *models.Hold) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(hold)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		result = tx.Exec(
+			`UPDATE accounts SET held_balance = held_balance + ?, updated_at = ?
+			WHERE id = ? AND balance - held_balance + overdraft_limit >= ?`,
+			hold.Amount, time.Now(), hold.AccountID, hold.Amount,
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			if _, err := NewAccountRepository(tx).GetByID(ctx, hold.AccountID); err != nil {
+				return err
+			}
+			return ErrInsufficientFunds
+		}
+		return nil
+	})
+}
+
+func (r *HoldRepository) GetByID(ctx context.Context, id string) (models.Hold, error) {
+	var hold models.Hold
+	err := r.db.WithContext(ctx).First(&hold, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Hold{}, models.ErrHoldNotFound
+	}
+	return hold, err
+}
+
+func (r *HoldRepository) GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Hold, error) {
+	var holds []models.Hold
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND status = ?", accountID, models.HoldActive).
+		Order("created_at ASC").
+		Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// ClaimCapture reserves an active, unexpired hold for one capture transaction
+// so that it cannot be captured twice, voided or expired while the capture is
+// being processed.
+func (r *HoldRepository) ClaimCapture(ctx context.Context, holdID, captureTransactionID string, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Hold{}).
+		Where("id = ? AND status = ? AND capture_transaction_id IS NULL AND expires_at > ?", holdID, models.HoldActive, now).
+		Updates(map[string]interface{}{
+			"capture_transaction_id": captureTransactionID,
+			"updated_at":             now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetByID(ctx, holdID); err != nil {
+			return err
+		}
+		return models.ErrHoldNotActive
+	}
+	return nil
+}
+
+// ReleaseCaptureClaim undoes ClaimCapture for a capture that failed.
+func (r *HoldRepository) ReleaseCaptureClaim(ctx context.Context, holdID, captureTransactionID string) error {
+	return r.db.WithContext(ctx).Model(&models.Hold{}).
+		Where("id = ? AND status = ? AND capture_transaction_id = ?", holdID, models.HoldActive, captureTransactionID).
+		Updates(map[string]interface{}{
+			"capture_transaction_id": nil,
+			"updated_at":             time.Now(),
+		}).Error
+}
+
+// Capture settles a hold claimed by the capture whose journal is given: it
+// posts the journal, marks the hold captured and releases the whole hold from
+// the held balance, in one database transaction. Capturing again returns
+// ErrJournalAlreadyPosted.
+func (r *HoldRepository) Capture(ctx context.Context, holdID string, journal *models.Journal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hold, err := lockHold(tx, holdID)
+		if err != nil {
+			return err
+		}
+		if hold.Status == models.HoldCaptured && hold.CaptureTransactionID != nil && *hold.CaptureTransactionID == journal.ID {
+			return ErrJournalAlreadyPosted
+		}
+		if hold.Status != models.HoldActive || hold.CaptureTransactionID == nil || *hold.CaptureTransactionID != journal.ID {
+			return models.ErrHoldNotActive
+		}
+
+		captured := journal.NetChange(models.CustomerLedgerAccount(hold.AccountID))
+		if err := finishHold(tx, &hold, models.HoldCaptured, -captured); err != nil {
+			return err
+		}
+		if err := insertJournal(tx, journal); err != nil {
+			return err
+		}
+		return applyJournal(tx, journal)
+	})
+}
+
+// Release ends an active hold that has no capture in progress without
+// moving any money, for a void or an expiry.
+func (r *HoldRepository) Release(ctx context.Context, holdID string, status models.HoldStatus) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		hold, err := lockHold(tx, holdID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != models.HoldActive || hold.CaptureTransactionID != nil {
+			return models.ErrHoldNotActive
+		}
+		return finishHold(tx, &hold, status, 0)
+	})
+}
+
+// ExpireDue releases the active holds that have expired and returns how many
+// it released.
+func (r *HoldRepository) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&models.Hold{}).
+		Where("status = ? AND expires_at <= ? AND capture_transaction_id IS NULL", models.HoldActive, now).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := r.Release(ctx, id, models.HoldExpired)
+		if errors.Is(err, models.ErrHoldNotActive) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+func lockHold(tx *gorm.DB, holdID string) (models.Hold, error) {
+	var hold models.Hold
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, "id = ?", holdID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Hold{}, models.ErrHoldNotFound
+	}
+	return hold, err
+}
+
+func finishHold(tx *gorm.DB, hold *models.Hold, status models.HoldStatus, captured models.Money) error {
+	now := time.Now()
+	err := tx.Model(hold).Updates(map[string]interface{}{
+		"status":          status,
+		"captured_amount": captured,
+		"updated_at":      now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec(
+		"UPDATE accounts SET held_balance = held_balance - ?, updated_at = ? WHERE id = ?",
+		hold.Amount, now, hold.AccountID,
+	).Error
+}
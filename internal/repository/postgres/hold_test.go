@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	accounts := NewAccountRepository(db)
+	holds := NewHoldRepository(db)
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Hold",
+		Email:     "hold@example.com",
+		Balance:   models.NewMoney(100),
+		Currency:  models.DefaultCurrency,
+	}
+	require.NoError(t, accounts.Create(ctx, &account))
+
+	placeHold := func(t *testing.T, amount models.Money, expiresAt time.Time) models.Hold {
+		hold := models.Hold{
+			ID:        uuid.New().String(),
+			AccountID: account.ID,
+			Amount:    amount,
+			Currency:  models.DefaultCurrency,
+			Status:    models.HoldActive,
+			ExpiresAt: expiresAt,
+		}
+		require.NoError(t, holds.Place(ctx, &hold))
+		return hold
+	}
+	balances := func(t *testing.T) (models.Money, models.Money) {
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		return updated.Balance, updated.AvailableBalance
+	}
+
+	t.Run("place reduces the available balance", func(t *testing.T) {
+		hold := placeHold(t, models.NewMoney(30), time.Now().Add(time.Hour))
+		balance, available := balances(t)
+		assert.Equal(t, models.NewMoney(100), balance)
+		assert.Equal(t, models.NewMoney(70), available)
+
+		require.NoError(t, holds.Release(ctx, hold.ID, models.HoldVoided))
+		_, available = balances(t)
+		assert.Equal(t, models.NewMoney(100), available)
+
+		assert.ErrorIs(t, holds.Release(ctx, hold.ID, models.HoldVoided), models.ErrHoldNotActive)
+	})
+
+	t.Run("place beyond the available balance is rejected", func(t *testing.T) {
+		hold := models.Hold{
+			ID:        uuid.New().String(),
+			AccountID: account.ID,
+			Amount:    models.NewMoney(101),
+			Currency:  models.DefaultCurrency,
+			Status:    models.HoldActive,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		assert.ErrorIs(t, holds.Place(ctx, &hold), ErrInsufficientFunds)
+	})
+
+	t.Run("partial capture settles and releases the rest", func(t *testing.T) {
+		hold := placeHold(t, models.NewMoney(40), time.Now().Add(time.Hour))
+		capture := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.CAPTURE,
+			Amount:    models.NewMoney(25),
+			AccountID: account.ID.String(),
+			Currency:  models.DefaultCurrency,
+			HoldID:    hold.ID,
+		}
+		journal, err := models.NewTransactionJournal(capture)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, holds.Capture(ctx, hold.ID, &journal), models.ErrHoldNotActive)
+		require.NoError(t, holds.ClaimCapture(ctx, hold.ID, capture.ID, time.Now()))
+		assert.ErrorIs(t, holds.Release(ctx, hold.ID, models.HoldVoided), models.ErrHoldNotActive)
+
+		require.NoError(t, holds.Capture(ctx, hold.ID, &journal))
+		balance, available := balances(t)
+		assert.Equal(t, models.NewMoney(75), balance)
+		assert.Equal(t, models.NewMoney(75), available)
+
+		captured, err := holds.GetByID(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.HoldCaptured, captured.Status)
+		assert.Equal(t, models.NewMoney(25), captured.CapturedAmount)
+
+		assert.ErrorIs(t, holds.Capture(ctx, hold.ID, &journal), ErrJournalAlreadyPosted)
+	})
+
+	t.Run("expired holds are released", func(t *testing.T) {
+		hold := placeHold(t, models.NewMoney(10), time.Now().Add(-time.Minute))
+		active := placeHold(t, models.NewMoney(5), time.Now().Add(time.Hour))
+
+		expired, err := holds.ExpireDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+
+		released, err := holds.GetByID(ctx, hold.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.HoldExpired, released.Status)
+
+		remaining, err := holds.GetActiveByAccountID(ctx, account.ID)
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, active.ID, remaining[0].ID)
+	})
+}
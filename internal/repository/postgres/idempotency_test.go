@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewIdempotencyRepository(db)
+	newKey := func(key string, ttl time.Duration) *models.IdempotencyKey {
+		return &models.IdempotencyKey{Key: key, Fingerprint: "fp", ExpiresAt: time.Now().Add(ttl)}
+	}
+
+	t.Run("reserve, complete and replay", func(t *testing.T) {
+		_, reserved, err := repo.Reserve(ctx, newKey("key-1", time.Hour))
+		require.NoError(t, err)
+		assert.True(t, reserved)
+
+		existing, reserved, err := repo.Reserve(ctx, newKey("key-1", time.Hour))
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.False(t, existing.Completed)
+
+		require.NoError(t, repo.Complete(ctx, "key-1", http.StatusCreated, []byte(`{"id":"tx-1"}`)))
+
+		existing, reserved, err = repo.Reserve(ctx, newKey("key-1", time.Hour))
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.True(t, existing.Completed)
+		assert.Equal(t, http.StatusCreated, existing.StatusCode)
+		assert.Equal(t, `{"id":"tx-1"}`, string(existing.ResponseBody))
+	})
+
+	t.Run("released key can be reserved again", func(t *testing.T) {
+		_, _, err := repo.Reserve(ctx, newKey("key-2", time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, repo.Release(ctx, "key-2"))
+
+		_, reserved, err := repo.Reserve(ctx, newKey("key-2", time.Hour))
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("expired key is replaced", func(t *testing.T) {
+		_, _, err := repo.Reserve(ctx, newKey("key-3", -time.Minute))
+		require.NoError(t, err)
+
+		_, reserved, err := repo.Reserve(ctx, newKey("key-3", time.Hour))
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InterestRepository struct {
+	db *gorm.DB
+}
+
+func NewInterestRepository(db *gorm.DB) *InterestRepository {
+	return &InterestRepository{db: db}
+}
+
+func (r *InterestRepository) GetConfig(ctx context.Context, scope string) (models.InterestConfig, error) {
+	var config models.InterestConfig
+	err := r.db.WithContext(ctx).First(&config, "scope = ?", scope).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.InterestConfig{}, models.ErrInterestConfigNotFound
+	}
+	return config, err
+}
+
+// SaveConfig creates the scope's interest configuration or replaces it.
+func (r *InterestRepository) SaveConfig(ctx context.Context, config *models.InterestConfig) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(config).Error
+}
+
+func (r *InterestRepository) DeleteConfig(ctx context.Context, scope string) error {
+	result := r.db.WithContext(ctx).Delete(&models.InterestConfig{}, "scope = ?", scope)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrInterestConfigNotFound
+	}
+	return nil
+}
+
+// Accrue records the accrual unless the account has already accrued for that
+// day, and reports whether it did.
+func (r *InterestRepository) Accrue(ctx context.Context, accrual *models.InterestAccrual) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(accrual)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetLastAccrualDate returns the latest day any account accrued interest for,
+// leaving out carried remainders, or ErrInterestAccrualNotFound if none has.
+func (r *InterestRepository) GetLastAccrualDate(ctx context.Context) (time.Time, error) {
+	var date sql.NullTime
+	err := r.db.WithContext(ctx).Model(&models.InterestAccrual{}).
+		Where("carried = ?", false).
+		Select("MAX(date)").
+		Row().Scan(&date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !date.Valid {
+		return time.Time{}, models.ErrInterestAccrualNotFound
+	}
+	return date.Time.UTC(), nil
+}
+
+// GetUnposted returns the account's accruals that have not been posted yet,
+// oldest first.
+func (r *InterestRepository) GetUnposted(ctx context.Context, accountID uuid.UUID) ([]models.InterestAccrual, error) {
+	accruals := []models.InterestAccrual{}
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND transaction_id IS NULL", accountID).
+		Order("date ASC, carried ASC").
+		Find(&accruals).Error
+	return accruals, err
+}
+
+// GetAccountsWithUnposted returns the accounts that have accrued interest not
+// yet posted.
+func (r *InterestRepository) GetAccountsWithUnposted(ctx context.Context) ([]uuid.UUID, error) {
+	var accountIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.InterestAccrual{}).
+		Where("transaction_id IS NULL").
+		Distinct().
+		Pluck("account_id", &accountIDs).Error
+	return accountIDs, err
+}
+
+// MarkPosted links the account's unposted accruals dated before periodEnd to
+// the transaction that posted them, and carries the part of them it did not
+// pay into an accrual dated periodEnd. Marking them again changes nothing.
+func (r *InterestRepository) MarkPosted(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string, carried models.Money) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.InterestAccrual{}).
+			Where("account_id = ? AND transaction_id IS NULL AND date < ?", accountID, periodEnd).
+			Update("transaction_id", transactionID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 || carried == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.InterestAccrual{
+			AccountID: accountID,
+			Date:      periodEnd,
+			Carried:   true,
+			Amount:    carried,
+		}).Error
+	})
+}
+
+// ReleasePosting undoes MarkPosted for a transaction that failed: the accruals
+// it was to pay are unposted again, and the remainder carried from them is
+// dropped, so that the period is posted again.
+func (r *InterestRepository) ReleasePosting(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("account_id = ? AND date = ? AND carried AND transaction_id IS NULL", accountID, periodEnd).
+			Delete(&models.InterestAccrual{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.InterestAccrual{}).
+			Where("account_id = ? AND transaction_id = ?", accountID, transactionID).
+			Update("transaction_id", nil).Error
+	})
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewInterestRepository(db)
+	accountID := uuid.New()
+
+	t.Run("save and replace config", func(t *testing.T) {
+		scope := models.ProductInterestScope("SAVINGS")
+		config := &models.InterestConfig{Scope: scope, AnnualRate: models.Rate(4_000_000), DayCount: models.DayCountActual365, Compounding: models.CompoundMonthly}
+		require.NoError(t, repo.SaveConfig(ctx, config))
+
+		config.AnnualRate = models.Rate(4_500_000)
+		require.NoError(t, repo.SaveConfig(ctx, config))
+
+		stored, err := repo.GetConfig(ctx, scope)
+		require.NoError(t, err)
+		assert.Equal(t, models.Rate(4_500_000), stored.AnnualRate)
+
+		require.NoError(t, repo.DeleteConfig(ctx, scope))
+		_, err = repo.GetConfig(ctx, scope)
+		assert.ErrorIs(t, err, models.ErrInterestConfigNotFound)
+	})
+
+	day := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("accrue once per day", func(t *testing.T) {
+		_, err := repo.GetLastAccrualDate(ctx)
+		assert.ErrorIs(t, err, models.ErrInterestAccrualNotFound)
+
+		accrual := &models.InterestAccrual{AccountID: accountID, Date: day, Balance: models.NewMoney(1000), AnnualRate: models.Rate(3_650_000), Amount: models.Money(1_000)}
+		inserted, err := repo.Accrue(ctx, accrual)
+		require.NoError(t, err)
+		assert.True(t, inserted)
+
+		again := *accrual
+		again.Amount = models.Money(2_000)
+		inserted, err = repo.Accrue(ctx, &again)
+		require.NoError(t, err)
+		assert.False(t, inserted)
+
+		unposted, err := repo.GetUnposted(ctx, accountID)
+		require.NoError(t, err)
+		require.Len(t, unposted, 1)
+		assert.Equal(t, models.Money(1_000), unposted[0].Amount)
+
+		last, err := repo.GetLastAccrualDate(ctx)
+		require.NoError(t, err)
+		assert.True(t, day.Equal(last))
+	})
+
+	t.Run("mark posted", func(t *testing.T) {
+		_, err := repo.Accrue(ctx, &models.InterestAccrual{AccountID: accountID, Date: day.AddDate(0, 0, 1), Amount: models.Money(1_000)})
+		require.NoError(t, err)
+
+		accounts, err := repo.GetAccountsWithUnposted(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{accountID}, accounts)
+
+		require.NoError(t, repo.MarkPosted(ctx, accountID, day.AddDate(0, 0, 1), "interest-march", models.Money(20)))
+		require.NoError(t, repo.MarkPosted(ctx, accountID, day.AddDate(0, 0, 1), "interest-march", models.Money(20)))
+		unposted, err := repo.GetUnposted(ctx, accountID)
+		require.NoError(t, err)
+		require.Len(t, unposted, 2)
+		for _, accrual := range unposted {
+			assert.True(t, accrual.Date.Equal(day.AddDate(0, 0, 1)))
+		}
+		assert.False(t, unposted[0].Carried)
+		assert.True(t, unposted[1].Carried)
+		assert.Equal(t, models.Money(20), unposted[1].Amount)
+	})
+
+	t.Run("release a failed posting", func(t *testing.T) {
+		require.NoError(t, repo.ReleasePosting(ctx, accountID, day.AddDate(0, 0, 1), "interest-march"))
+		unposted, err := repo.GetUnposted(ctx, accountID)
+		require.NoError(t, err)
+		require.Len(t, unposted, 2)
+		assert.True(t, unposted[0].Date.Equal(day))
+		assert.False(t, unposted[1].Carried)
+	})
+}
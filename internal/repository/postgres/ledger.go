@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrJournalAlreadyPosted = errors.New("journal already posted")
+
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// PostJournal records the journal's postings and applies their net change to
+// the balance of every customer account involved, in one database transaction.
+// A journal can only be posted once; posting it again returns
+// ErrJournalAlreadyPosted and changes nothing.
+func (r *LedgerRepository) PostJournal(ctx context.Context, journal *models.Journal) error {
+	return r.PostJournals(ctx, journal)
+}
+
+// PostJournals posts several journals together, such as a transaction's and
+// that of the fee charged for it: either all of them are posted or none is.
+// It returns ErrJournalAlreadyPosted if any of them was posted before.
+func (r *LedgerRepository) PostJournals(ctx context.Context, journals ...*models.Journal) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, journal := range journals {
+			if err := insertJournal(tx, journal); err != nil {
+				return err
+			}
+			if err := applyJournal(tx, journal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetJournal returns a posted journal with its postings.
+func (r *LedgerRepository) GetJournal(ctx context.Context, id string) (models.Journal, error) {
+	var journal models.Journal
+	err := r.db.WithContext(ctx).Preload("Postings").First(&journal, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Journal{}, models.ErrJournalNotFound
+	}
+	return journal, err
+}
+
+// GetJournalIDs returns the IDs of the journals posted to a ledger account.
+func (r *LedgerRepository) GetJournalIDs(ctx context.Context, ledgerAccount string) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&models.Posting{}).
+		Distinct("journal_id").
+		Where("ledger_account = ?", ledgerAccount).
+		Pluck("journal_id", &ids).Error
+	return ids, err
+}
+
+func (r *LedgerRepository) GetPostings(ctx context.Context, ledgerAccount string) ([]models.Posting, error) {
+	var postings []models.Posting
+	err := r.db.WithContext(ctx).
+		Where("ledger_account = ?", ledgerAccount).
+		Order("created_at ASC").
+		Find(&postings).Error
+	if err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
+
+// GetBalanceAt sums the postings made to a ledger account before the given
+// time, crediting positively.
+func (r *LedgerRepository) GetBalanceAt(ctx context.Context, ledgerAccount string, at time.Time) (models.Money, error) {
+	var balance models.Money
+	err := r.db.WithContext(ctx).Model(&models.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", models.CREDIT).
+		Where("ledger_account = ? AND created_at < ?", ledgerAccount, at).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// SumPostings totals the postings made to a ledger account at or after from and
+// before until, crediting positively, and counts them.
+func (r *LedgerRepository) SumPostings(ctx context.Context, ledgerAccount string, from, until time.Time) (models.Money, int, error) {
+	var result struct {
+		Total models.Money
+		Count int
+	}
+	err := r.db.WithContext(ctx).Model(&models.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0) AS total, COUNT(*) AS count", models.CREDIT).
+		Where("ledger_account = ? AND created_at >= ? AND created_at < ?", ledgerAccount, from, until).
+		Scan(&result).Error
+	return result.Total, result.Count, err
+}
+
+func insertJournal(tx *gorm.DB, journal *models.Journal) error {
+	if err := journal.Validate(); err != nil {
+		return err
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit("Postings").Create(journal)
+	if result.Error != nil {
+		return fmt.Errorf("failed to record journal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrJournalAlreadyPosted
+	}
+
+	if err := tx.Create(&journal.Postings).Error; err != nil {
+		return fmt.Errorf("failed to record postings: %w", err)
+	}
+	return nil
+}
+
+func applyJournal(tx *gorm.DB, journal *models.Journal) error {
+	accounts := NewAccountRepository(tx)
+	for _, ledgerAccount := range journal.LedgerAccounts() {
+		if models.IsSystemLedgerAccount(ledgerAccount) {
+			continue
+		}
+
+		accountID, err := uuid.Parse(ledgerAccount)
+		if err != nil {
+			return fmt.Errorf("invalid ledger account %s: %w", ledgerAccount, err)
+		}
+
+		if _, err := accounts.ChangeBalance(tx.Statement.Context, accountID, journal.NetChange(ledgerAccount)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
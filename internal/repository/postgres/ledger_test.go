@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerRepository_PostJournal(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	accounts := NewAccountRepository(db)
+	ledger := NewLedgerRepository(db)
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Ledger",
+		Email:     "ledger@example.com",
+		Balance:   models.NewMoney(100),
+		Currency:  models.DefaultCurrency,
+	}
+	require.NoError(t, accounts.Create(ctx, &account))
+
+	tx := &models.Transaction{
+		ID:        uuid.New().String(),
+		Type:      models.DEPOSIT,
+		Amount:    models.NewMoney(50),
+		AccountID: account.ID.String(),
+		Currency:  models.DefaultCurrency,
+	}
+	journal, err := models.NewTransactionJournal(tx)
+	require.NoError(t, err)
+
+	t.Run("posts and applies the journal", func(t *testing.T) {
+		require.NoError(t, ledger.PostJournal(ctx, &journal))
+
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), updated.Balance)
+
+		postings, err := ledger.GetPostings(ctx, models.CustomerLedgerAccount(account.ID))
+		require.NoError(t, err)
+		assert.Len(t, postings, 2)
+		assert.Equal(t, updated.Balance, models.PostingsBalance(postings, models.CustomerLedgerAccount(account.ID)))
+	})
+
+	t.Run("posting twice is rejected", func(t *testing.T) {
+		err := ledger.PostJournal(ctx, &journal)
+		assert.ErrorIs(t, err, ErrJournalAlreadyPosted)
+
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), updated.Balance)
+	})
+
+	t.Run("transfer to missing account changes nothing", func(t *testing.T) {
+		transfer, err := models.NewTransactionJournal(&models.Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 models.TRANSFER,
+			Amount:               models.NewMoney(20),
+			AccountID:            account.ID.String(),
+			DestinationAccountID: uuid.New().String(),
+			Currency:             models.DefaultCurrency,
+		})
+		require.NoError(t, err)
+
+		err = ledger.PostJournal(ctx, &transfer)
+		require.Error(t, err)
+
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), updated.Balance)
+	})
+
+	t.Run("withdrawal beyond the balance changes nothing", func(t *testing.T) {
+		withdrawal, err := models.NewTransactionJournal(&models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.WITHDRAWL,
+			Amount:    models.NewMoney(500),
+			AccountID: account.ID.String(),
+			Currency:  models.DefaultCurrency,
+		})
+		require.NoError(t, err)
+
+		err = ledger.PostJournal(ctx, &withdrawal)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		postings, err := ledger.GetPostings(ctx, models.CustomerLedgerAccount(account.ID))
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(150), models.PostingsBalance(postings, models.CustomerLedgerAccount(account.ID)))
+	})
+
+	t.Run("unbalanced journal", func(t *testing.T) {
+		unbalanced := models.Journal{
+			ID: uuid.New().String(),
+			Postings: []models.Posting{
+				{LedgerAccount: models.CashLedgerAccount, Direction: models.DEBIT, Amount: models.NewMoney(10), Currency: models.DefaultCurrency},
+				{LedgerAccount: models.CustomerLedgerAccount(account.ID), Direction: models.CREDIT, Amount: models.NewMoney(20), Currency: models.DefaultCurrency},
+			},
+		}
+		err := ledger.PostJournal(ctx, &unbalanced)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unbalanced")
+	})
+}
+
+func TestLedgerRepository_PostJournals(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	accounts := NewAccountRepository(db)
+	ledger := NewLedgerRepository(db)
+
+	account := models.Account{
+		ID:        uuid.New(),
+		FirstName: "Fee",
+		Email:     "fee@example.com",
+		Balance:   models.NewMoney(100),
+		Currency:  models.DefaultCurrency,
+	}
+	require.NoError(t, accounts.Create(ctx, &account))
+
+	journal := func(txType models.TransactionType, amount models.Money) *models.Journal {
+		tx := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      txType,
+			Amount:    amount,
+			AccountID: account.ID.String(),
+			Currency:  models.DefaultCurrency,
+		}
+		if txType == models.FEE {
+			tx.Fee = &models.FeeCharge{RuleID: uuid.New()}
+		}
+		j, err := models.NewTransactionJournal(tx)
+		require.NoError(t, err)
+		return &j
+	}
+
+	t.Run("a fee that cannot be paid posts nothing", func(t *testing.T) {
+		err := ledger.PostJournals(ctx, journal(models.WITHDRAWL, models.NewMoney(99)), journal(models.FEE, models.NewMoney(2)))
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(100), updated.Balance)
+	})
+
+	t.Run("posts the transaction with its fee", func(t *testing.T) {
+		require.NoError(t, ledger.PostJournals(ctx, journal(models.WITHDRAWL, models.NewMoney(50)), journal(models.FEE, models.NewMoney(2))))
+
+		updated, err := accounts.GetByID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(48), updated.Balance)
+
+		income, err := ledger.GetPostings(ctx, models.FeeIncomeLedgerAccount)
+		require.NoError(t, err)
+		assert.Len(t, income, 1)
+	})
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LimitRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitRepository(db *gorm.DB) *LimitRepository {
+	return &LimitRepository{db: db}
+}
+
+func (r *LimitRepository) Get(ctx context.Context, scope string) (models.TransactionLimits, error) {
+	var limits models.TransactionLimits
+	err := r.db.WithContext(ctx).First(&limits, "scope = ?", scope).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.TransactionLimits{}, models.ErrLimitsNotFound
+	}
+	return limits, err
+}
+
+// Save creates or replaces the limits of their scope.
+func (r *LimitRepository) Save(ctx context.Context, limits *models.TransactionLimits) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(limits).Error
+}
+
+func (r *LimitRepository) Delete(ctx context.Context, scope string) error {
+	result := r.db.WithContext(ctx).Delete(&models.TransactionLimits{}, "scope = ?", scope)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrLimitsNotFound
+	}
+	return nil
+}
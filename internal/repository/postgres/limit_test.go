@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewLimitRepository(db)
+	scope := models.TierLimitScope(models.TierPremium)
+
+	t.Run("missing limits", func(t *testing.T) {
+		_, err := repo.Get(ctx, scope)
+		assert.ErrorIs(t, err, models.ErrLimitsNotFound)
+	})
+
+	t.Run("save replaces limits", func(t *testing.T) {
+		require.NoError(t, repo.Save(ctx, &models.TransactionLimits{Scope: scope, DailyCount: 5}))
+		require.NoError(t, repo.Save(ctx, &models.TransactionLimits{Scope: scope, DailyCount: 7, PerMinuteCount: 2}))
+
+		limits, err := repo.Get(ctx, scope)
+		require.NoError(t, err)
+		assert.Equal(t, 7, limits.DailyCount)
+		assert.Equal(t, 2, limits.PerMinuteCount)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, scope))
+		assert.ErrorIs(t, repo.Delete(ctx, scope), models.ErrLimitsNotFound)
+	})
+}
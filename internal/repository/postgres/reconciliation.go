@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+func (r *ReconciliationRepository) CreateReport(ctx context.Context, report *models.ReconciliationReport) error {
+	return r.db.WithContext(ctx).Create(report).Error
+}
+
+func (r *ReconciliationRepository) GetReport(ctx context.Context, id uuid.UUID) (models.ReconciliationReport, error) {
+	var report models.ReconciliationReport
+	err := r.db.WithContext(ctx).First(&report, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ReconciliationReport{}, models.ErrReconciliationNotFound
+	}
+	return report, err
+}
+
+// GetReports returns the latest reports first, at most limit of them.
+func (r *ReconciliationRepository) GetReports(ctx context.Context, limit int) ([]models.ReconciliationReport, error) {
+	reports := []models.ReconciliationReport{}
+	err := r.db.WithContext(ctx).Order("started_at DESC").Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// RecordCase adds a report's discrepancy to the account's open investigation
+// case, opening one if it has none.
+func (r *ReconciliationRepository) RecordCase(ctx context.Context, reportID uuid.UUID, discrepancy *models.Discrepancy, now time.Time) (models.InvestigationCase, error) {
+	var investigation models.InvestigationCase
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&investigation, "account_id = ? AND status = ?", discrepancy.AccountID, models.CaseOpen).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			investigation = models.NewInvestigationCase(reportID, discrepancy, now)
+			return tx.Create(&investigation).Error
+		}
+		if err != nil {
+			return err
+		}
+		investigation.Update(reportID, discrepancy)
+		return tx.Save(&investigation).Error
+	})
+	if err != nil {
+		return models.InvestigationCase{}, err
+	}
+	return investigation, nil
+}
+
+func (r *ReconciliationRepository) GetCase(ctx context.Context, id uuid.UUID) (models.InvestigationCase, error) {
+	var investigation models.InvestigationCase
+	err := r.db.WithContext(ctx).First(&investigation, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.InvestigationCase{}, models.ErrCaseNotFound
+	}
+	return investigation, err
+}
+
+// GetCases returns the investigation cases with the given status, or all of
+// them without one, latest first.
+func (r *ReconciliationRepository) GetCases(ctx context.Context, status models.CaseStatus) ([]models.InvestigationCase, error) {
+	cases := []models.InvestigationCase{}
+	query := r.db.WithContext(ctx).Order("opened_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&cases).Error
+	return cases, err
+}
+
+func (r *ReconciliationRepository) UpdateCase(ctx context.Context, id uuid.UUID, change func(*models.InvestigationCase) error) (models.InvestigationCase, error) {
+	var investigation models.InvestigationCase
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&investigation, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrCaseNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := change(&investigation); err != nil {
+			return err
+		}
+		return tx.Save(&investigation).Error
+	})
+	if err != nil {
+		return models.InvestigationCase{}, err
+	}
+	return investigation, nil
+}
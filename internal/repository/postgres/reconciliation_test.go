@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewReconciliationRepository(db)
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	accountID := uuid.New()
+	discrepancy := models.Discrepancy{
+		AccountID:  accountID,
+		Currency:   models.DefaultCurrency,
+		Expected:   models.NewMoney(145),
+		Actual:     models.NewMoney(150),
+		Ledger:     models.NewMoney(150),
+		Difference: models.NewMoney(5),
+		Transactions: []models.DiscrepancyTransaction{
+			{ID: "tx", Type: models.DEPOSIT, Status: models.SUCCESS, Amount: models.NewMoney(5), Issue: models.IssueSucceededNotPosted},
+		},
+	}
+
+	first := models.ReconciliationReport{ID: uuid.New(), StartedAt: now, FinishedAt: now, AccountsChecked: 1, OpenCases: true, Discrepancies: []models.Discrepancy{discrepancy}}
+	opened, err := repo.RecordCase(ctx, first.ID, &discrepancy, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateReport(ctx, &first))
+
+	second := models.ReconciliationReport{ID: uuid.New(), StartedAt: now.Add(time.Hour), FinishedAt: now.Add(time.Hour), AccountsChecked: 1, OpenCases: true, Discrepancies: []models.Discrepancy{}}
+	updated, err := repo.RecordCase(ctx, second.ID, &discrepancy, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateReport(ctx, &second))
+	assert.Equal(t, opened.ID, updated.ID)
+	assert.Equal(t, []uuid.UUID{first.ID, second.ID}, updated.ReportIDs)
+
+	reports, err := repo.GetReports(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, second.ID, reports[0].ID)
+
+	stored, err := repo.GetReport(ctx, first.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Discrepancies, 1)
+	assert.Equal(t, discrepancy.Transactions, stored.Discrepancies[0].Transactions)
+
+	_, err = repo.GetReport(ctx, uuid.New())
+	assert.ErrorIs(t, err, models.ErrReconciliationNotFound)
+
+	resolved, err := repo.UpdateCase(ctx, opened.ID, func(investigation *models.InvestigationCase) error {
+		return investigation.Resolve(models.CaseResolution{Resolution: "posted the missing deposit", OperatorID: "ops-1"}, now)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.CaseResolved, resolved.Status)
+
+	reopened, err := repo.RecordCase(ctx, uuid.New(), &discrepancy, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, opened.ID, reopened.ID)
+
+	open, err := repo.GetCases(ctx, models.CaseOpen)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, reopened.ID, open[0].ID)
+
+	all, err := repo.GetCases(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	_, err = repo.GetCase(ctx, uuid.New())
+	assert.ErrorIs(t, err, models.ErrCaseNotFound)
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StandingOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewStandingOrderRepository(db *gorm.DB) *StandingOrderRepository {
+	return &StandingOrderRepository{db: db}
+}
+
+func (r *StandingOrderRepository) Create(ctx context.Context, order *models.StandingOrder) error {
+	return r.db.WithContext(ctx).Create(order).Error
+}
+
+func (r *StandingOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := r.db.WithContext(ctx).First(&order, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.StandingOrder{}, models.ErrStandingOrderNotFound
+	}
+	return order, err
+}
+
+func (r *StandingOrderRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.StandingOrder, error) {
+	orders := []models.StandingOrder{}
+	err := r.db.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// Update applies change to the standing order while it is locked, so it
+// cannot interleave with a run of the same order, and saves the result.
+func (r *StandingOrderRepository) Update(ctx context.Context, id uuid.UUID, change func(*models.StandingOrder) error) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if order, err = lockStandingOrder(tx, id); err != nil {
+			return err
+		}
+		if err := change(&order); err != nil {
+			return err
+		}
+		return tx.Save(&order).Error
+	})
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+	return order, nil
+}
+
+// GetDue returns active standing orders whose next occurrence is due by now.
+func (r *StandingOrderRepository) GetDue(ctx context.Context, now time.Time) ([]models.StandingOrder, error) {
+	var orders []models.StandingOrder
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_run_at <= ?", models.StandingOrderActive, now).
+		Order("next_run_at ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// RecordRun saves the run of the occurrence due at scheduledFor together with
+// the order as advanced past it. It returns ErrStandingOrderChanged, saving
+// nothing, if the order was run, paused or changed since it was read: its
+// UpdatedAt must still be the one read.
+func (r *StandingOrderRepository) RecordRun(ctx context.Context, order *models.StandingOrder, scheduledFor time.Time, run *models.StandingOrderRun) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.StandingOrder{}).
+			Where("id = ? AND status = ? AND next_run_at = ? AND updated_at = ?", order.ID, models.StandingOrderActive, scheduledFor, order.UpdatedAt).
+			Updates(map[string]interface{}{
+				"next_run_at":          order.NextRunAt,
+				"run_count":            order.RunCount,
+				"consecutive_failures": order.ConsecutiveFailures,
+				"status":               order.Status,
+				"updated_at":           time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrStandingOrderChanged
+		}
+		return tx.Create(run).Error
+	})
+}
+
+// GetPendingRuns returns the runs whose transactions have not been settled.
+func (r *StandingOrderRepository) GetPendingRuns(ctx context.Context) ([]models.StandingOrderRun, error) {
+	var runs []models.StandingOrderRun
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.StandingOrderRunPending).
+		Order("scheduled_for ASC").
+		Find(&runs).Error
+	return runs, err
+}
+
+// SettleRun records the outcome of a pending run's transaction and counts it
+// towards its order's consecutive failures, suspending the order once
+// maxFailures runs in a row have failed. Settling a run twice changes nothing.
+func (r *StandingOrderRepository) SettleRun(ctx context.Context, run *models.StandingOrderRun, maxFailures int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		order, err := lockStandingOrder(tx, run.StandingOrderID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.StandingOrderRun{}).
+			Where("id = ? AND status = ?", run.ID, models.StandingOrderRunPending).
+			Updates(map[string]interface{}{
+				"status":         run.Status,
+				"error":          run.Error,
+				"transaction_id": run.TransactionID,
+				"updated_at":     time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		order.RecordOutcome(run.Status == models.StandingOrderRunSuccess, maxFailures)
+		return tx.Model(&order).Updates(map[string]interface{}{
+			"consecutive_failures": order.ConsecutiveFailures,
+			"status":               order.Status,
+			"updated_at":           time.Now(),
+		}).Error
+	})
+}
+
+func (r *StandingOrderRepository) GetRuns(ctx context.Context, orderID uuid.UUID) ([]models.StandingOrderRun, error) {
+	runs := []models.StandingOrderRun{}
+	err := r.db.WithContext(ctx).
+		Where("standing_order_id = ?", orderID).
+		Order("scheduled_for DESC").
+		Find(&runs).Error
+	return runs, err
+}
+
+func lockStandingOrder(tx *gorm.DB, id uuid.UUID) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.StandingOrder{}, models.ErrStandingOrderNotFound
+	}
+	return order, err
+}
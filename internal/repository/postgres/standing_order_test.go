@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandingOrderRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewStandingOrderRepository(db)
+	due := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+	order, err := models.NewStandingOrder(models.StandingOrderCreate{
+		AccountID: uuid.NewString(),
+		Type:      models.DEPOSIT,
+		Amount:    models.NewMoney(25),
+		Frequency: models.FrequencyWeekly,
+		StartAt:   due,
+	})
+	require.NoError(t, err)
+	order.Currency = models.DefaultCurrency
+	require.NoError(t, repo.Create(ctx, &order))
+
+	t.Run("due orders", func(t *testing.T) {
+		orders, err := repo.GetDue(ctx, time.Now())
+		require.NoError(t, err)
+		require.Len(t, orders, 1)
+		assert.Equal(t, order.ID, orders[0].ID)
+	})
+
+	run := &models.StandingOrderRun{
+		ID:              uuid.New(),
+		StandingOrderID: order.ID,
+		ScheduledFor:    due,
+		TransactionID:   order.RunTransactionID(due),
+		Status:          models.StandingOrderRunPending,
+	}
+
+	t.Run("record a run once", func(t *testing.T) {
+		read, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+
+		// An order changed since it was read is not run.
+		changed := read
+		changed.UpdatedAt = changed.UpdatedAt.Add(-time.Second)
+		changed.Advance()
+		err = repo.RecordRun(ctx, &changed, due, run)
+		assert.ErrorIs(t, err, models.ErrStandingOrderChanged)
+
+		advanced := read
+		advanced.Advance()
+		require.NoError(t, repo.RecordRun(ctx, &advanced, due, run))
+
+		stale := read
+		stale.Advance()
+		err = repo.RecordRun(ctx, &stale, due, &models.StandingOrderRun{
+			ID:              uuid.New(),
+			StandingOrderID: order.ID,
+			ScheduledFor:    due,
+			Status:          models.StandingOrderRunPending,
+		})
+		assert.ErrorIs(t, err, models.ErrStandingOrderChanged)
+
+		stored, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stored.RunCount)
+
+		orders, err := repo.GetDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+	})
+
+	t.Run("settle a failed run", func(t *testing.T) {
+		pending, err := repo.GetPendingRuns(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+
+		run.Status = models.StandingOrderRunFailed
+		run.Error = "transaction failed"
+		require.NoError(t, repo.SettleRun(ctx, run, 1))
+		require.NoError(t, repo.SettleRun(ctx, run, 1))
+
+		stored, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stored.ConsecutiveFailures)
+		assert.Equal(t, models.StandingOrderSuspended, stored.Status)
+
+		runs, err := repo.GetRuns(ctx, order.ID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, models.StandingOrderRunFailed, runs[0].Status)
+	})
+
+	t.Run("update while locked", func(t *testing.T) {
+		active := models.StandingOrderActive
+		updated, err := repo.Update(ctx, order.ID, func(o *models.StandingOrder) error {
+			return o.Apply(models.StandingOrderUpdate{Status: &active}, time.Now())
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.StandingOrderActive, updated.Status)
+		assert.Equal(t, 0, updated.ConsecutiveFailures)
+
+		_, err = repo.Update(ctx, uuid.New(), func(*models.StandingOrder) error { return nil })
+		assert.ErrorIs(t, err, models.ErrStandingOrderNotFound)
+	})
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StatementRepository struct {
+	db *gorm.DB
+}
+
+func NewStatementRepository(db *gorm.DB) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+// Create stores the statement and its documents in one database transaction,
+// unless the account already has a statement for the period, and reports
+// whether it did.
+func (r *StatementRepository) Create(ctx context.Context, statement *models.Statement) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit("Documents").Create(statement)
+		if result.Error != nil {
+			return fmt.Errorf("failed to record statement: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(&statement.Documents).Error; err != nil {
+			return fmt.Errorf("failed to record statement documents: %w", err)
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+func (r *StatementRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Statement, error) {
+	var statement models.Statement
+	err := r.db.WithContext(ctx).First(&statement, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Statement{}, models.ErrStatementNotFound
+	}
+	return statement, err
+}
+
+func (r *StatementRepository) GetByPeriod(ctx context.Context, accountID uuid.UUID, period string) (models.Statement, error) {
+	var statement models.Statement
+	err := r.db.WithContext(ctx).First(&statement, "account_id = ? AND period = ?", accountID, period).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Statement{}, models.ErrStatementNotFound
+	}
+	return statement, err
+}
+
+// GetByAccountID returns the account's statements, latest first, without
+// their lines.
+func (r *StatementRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Statement, error) {
+	statements := []models.Statement{}
+	err := r.db.WithContext(ctx).
+		Omit("Lines").
+		Where("account_id = ?", accountID).
+		Order("period_start DESC").
+		Find(&statements).Error
+	return statements, err
+}
+
+// GetDocument returns the statement rendered in the given format.
+func (r *StatementRepository) GetDocument(ctx context.Context, statementID uuid.UUID, format models.StatementFormat) (models.StatementDocument, error) {
+	var document models.StatementDocument
+	err := r.db.WithContext(ctx).First(&document, "statement_id = ? AND format = ?", statementID, format).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.StatementDocument{}, models.ErrStatementNotFound
+	}
+	return document, err
+}
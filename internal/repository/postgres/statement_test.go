@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementRepository(t *testing.T) {
+	db, cleanup := setupDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repo := NewStatementRepository(db)
+	account := &models.Account{ID: uuid.New(), Currency: models.DefaultCurrency}
+	start, end, err := models.StatementPeriod("2025-03")
+	require.NoError(t, err)
+
+	newStatement := func(opening models.Money) models.Statement {
+		statement := models.NewStatement(account, "2025-03", start, end, opening, nil, end.Add(time.Hour))
+		statement.ID = uuid.New()
+		statement.Documents, err = statement.RenderDocuments()
+		require.NoError(t, err)
+		return statement
+	}
+
+	statement := newStatement(models.NewMoney(100))
+	created, err := repo.Create(ctx, &statement)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	t.Run("a period is only stored once", func(t *testing.T) {
+		again := newStatement(models.NewMoney(1))
+		created, err := repo.Create(ctx, &again)
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		stored, err := repo.GetByPeriod(ctx, account.ID, "2025-03")
+		require.NoError(t, err)
+		assert.Equal(t, statement.ID, stored.ID)
+		assert.Equal(t, models.NewMoney(100), stored.OpeningBalance)
+	})
+
+	t.Run("documents are returned as stored", func(t *testing.T) {
+		document, err := repo.GetDocument(ctx, statement.ID, models.StatementCSV)
+		require.NoError(t, err)
+		assert.Equal(t, statement.Documents[1].Content, document.Content)
+		assert.Equal(t, statement.Documents[1].Checksum, document.Checksum)
+	})
+
+	t.Run("listing omits lines", func(t *testing.T) {
+		statements, err := repo.GetByAccountID(ctx, account.ID)
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+		assert.Nil(t, statements[0].Lines)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, models.ErrStatementNotFound)
+		_, err = repo.GetByPeriod(ctx, account.ID, "2025-04")
+		assert.ErrorIs(t, err, models.ErrStatementNotFound)
+	})
+}
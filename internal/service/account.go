@@ -17,15 +17,13 @@ type AccountRepository interface {
 	GetAll(ctx context.Context) (models.Accounts, error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Account, error)
 	Create(ctx context.Context, account *models.Account) error
-	Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error
 }
 type AccountServiceInterface interface {
 	GetAll(ctx context.Context) (models.Accounts, error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Account, error)
 	Create(ctx context.Context, account *models.Account) error
-	Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error
 }
 
 var _ AccountServiceInterface = (*AccountService)(nil)
@@ -56,16 +54,9 @@ func (s *AccountService) Create(ctx context.Context, account *models.Account) er
 
 }
 
-func (s *AccountService) Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error {
+func (s *AccountService) Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.accountRepo.Update(ctx, id, updates)
-
-}
-
-func (s *AccountService) Delete(ctx context.Context, id uuid.UUID) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	return s.accountRepo.Delete(ctx, id)
+	return s.accountRepo.Update(ctx, id, version, updates)
 
 }
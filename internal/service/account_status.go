@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type AccountStatusService struct {
+	statusRepo AccountStatusRepository
+}
+
+type AccountStatusRepository interface {
+	ChangeStatus(ctx context.Context, change *models.AccountStatusChange) error
+	GetStatusChanges(ctx context.Context, id uuid.UUID) ([]models.AccountStatusChange, error)
+}
+
+func NewAccountStatusService(statusRepo AccountStatusRepository) *AccountStatusService {
+	return &AccountStatusService{statusRepo: statusRepo}
+}
+
+// ChangeStatus moves the account to a new status on behalf of an operator and
+// returns the audit record of the change.
+func (s *AccountStatusService) ChangeStatus(ctx context.Context, accountID uuid.UUID, request models.AccountStatusUpdate, operatorID string) (*models.AccountStatusChange, error) {
+	if err := request.Validate(operatorID); err != nil {
+		return nil, err
+	}
+
+	change := &models.AccountStatusChange{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		ToStatus:   request.Status,
+		Reason:     request.Reason,
+		OperatorID: operatorID,
+	}
+	if err := s.statusRepo.ChangeStatus(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *AccountStatusService) GetStatusChanges(ctx context.Context, accountID uuid.UUID) ([]models.AccountStatusChange, error) {
+	return s.statusRepo.GetStatusChanges(ctx, accountID)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatusService_ChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	request := models.AccountStatusUpdate{Status: models.AccountFrozen, Reason: "suspected fraud"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewAccountStatusService(mockAccountRepo)
+
+		mockAccountRepo.On("ChangeStatus", ctx, mock.MatchedBy(func(change *models.AccountStatusChange) bool {
+			return change.AccountID == accountID && change.ToStatus == models.AccountFrozen && change.OperatorID == "ops-1"
+		})).Return(nil)
+
+		change, err := service.ChangeStatus(ctx, accountID, request, "ops-1")
+		require.NoError(t, err)
+		assert.Equal(t, request.Reason, change.Reason)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing Operator", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewAccountStatusService(mockAccountRepo)
+
+		_, err := service.ChangeStatus(ctx, accountID, request, "")
+		assert.ErrorIs(t, err, models.ErrInvalidStatusUpdate)
+		mockAccountRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Transition Not Allowed", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewAccountStatusService(mockAccountRepo)
+
+		mockAccountRepo.On("ChangeStatus", ctx, mock.Anything).Return(models.ErrInvalidStatusTransition)
+
+		_, err := service.ChangeStatus(ctx, accountID, request, "ops-1")
+		assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+}
@@ -72,23 +72,9 @@ func TestAccountService_Update(t *testing.T) {
 		FirstName: stringPtr("Jane"),
 	}
 
-	mockRepo.On("Update", ctx, id, updates).Return(nil)
+	mockRepo.On("Update", ctx, id, int64(1), updates).Return(nil)
 
-	err := service.Update(ctx, id, updates)
-	assert.NoError(t, err)
-	mockRepo.AssertExpectations(t)
-}
-
-func TestAccountService_Delete(t *testing.T) {
-	mockRepo := new(mocks.MockAccountRepository)
-	service := NewAccountService(mockRepo)
-
-	ctx := context.Background()
-	id := uuid.New()
-
-	mockRepo.On("Delete", ctx, id).Return(nil)
-
-	err := service.Delete(ctx, id)
+	err := service.Update(ctx, id, 1, updates)
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
@@ -128,17 +114,9 @@ func TestAccountService_ErrorCases(t *testing.T) {
 
 	t.Run("Update Error", func(t *testing.T) {
 		updates := models.AccountUpdate{FirstName: stringPtr("Jane")}
-		mockRepo.On("Update", ctx, id, updates).Return(testErr)
-
-		err := service.Update(ctx, id, updates)
-		assert.Error(t, err)
-		assert.Equal(t, testErr, err)
-	})
-
-	t.Run("Delete Error", func(t *testing.T) {
-		mockRepo.On("Delete", ctx, id).Return(testErr)
+		mockRepo.On("Update", ctx, id, int64(1), updates).Return(testErr)
 
-		err := service.Delete(ctx, id)
+		err := service.Update(ctx, id, 1, updates)
 		assert.Error(t, err)
 		assert.Equal(t, testErr, err)
 	})
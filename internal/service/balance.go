@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAsOf = errors.New("invalid point in time")
+
+type BalanceService struct {
+	snapshotRepo BalanceSnapshotRepository
+	postingRepo  PostingSumRepository
+	accountRepo  AccountRepository
+}
+
+type BalanceSnapshotRepository interface {
+	Create(ctx context.Context, snapshot *models.BalanceSnapshot) (bool, error)
+	GetLatest(ctx context.Context, accountID uuid.UUID, at time.Time) (models.BalanceSnapshot, error)
+	GetRange(ctx context.Context, accountID uuid.UUID, from, until time.Time) ([]models.BalanceSnapshot, error)
+}
+
+type PostingSumRepository interface {
+	SumPostings(ctx context.Context, ledgerAccount string, from, until time.Time) (models.Money, int, error)
+}
+
+func NewBalanceService(snapshotRepo BalanceSnapshotRepository, postingRepo PostingSumRepository, accountRepo AccountRepository) *BalanceService {
+	return &BalanceService{
+		snapshotRepo: snapshotRepo,
+		postingRepo:  postingRepo,
+		accountRepo:  accountRepo,
+	}
+}
+
+// GetBalanceAt returns what the account's balance was at asOf, which must not
+// be in the future.
+func (s *BalanceService) GetBalanceAt(ctx context.Context, accountID uuid.UUID, asOf time.Time) (models.PointInTimeBalance, error) {
+	if asOf.After(time.Now()) {
+		return models.PointInTimeBalance{}, fmt.Errorf("%w: %s is in the future", ErrInvalidAsOf, asOf.Format(time.RFC3339))
+	}
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return models.PointInTimeBalance{}, err
+	}
+
+	balance, snapshot, applied, err := s.balanceAt(ctx, accountID, asOf)
+	if err != nil {
+		return models.PointInTimeBalance{}, err
+	}
+	return models.PointInTimeBalance{
+		AccountID:       accountID,
+		Currency:        account.Currency,
+		AsOf:            asOf,
+		Balance:         balance,
+		Snapshot:        snapshot,
+		PostingsApplied: applied,
+	}, nil
+}
+
+// balanceAt works out the balance at a moment from the latest snapshot taken
+// by then, and the snapshot it started from, if any.
+func (s *BalanceService) balanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (models.Money, *models.BalanceSnapshot, int, error) {
+	var from time.Time
+	var balance models.Money
+	var start *models.BalanceSnapshot
+
+	snapshot, err := s.snapshotRepo.GetLatest(ctx, accountID, at)
+	if err == nil {
+		from, balance, start = snapshot.TakenAt, snapshot.Balance, &snapshot
+	} else if !errors.Is(err, models.ErrBalanceSnapshotNotFound) {
+		return 0, nil, 0, err
+	}
+
+	change, applied, err := s.postingRepo.SumPostings(ctx, models.CustomerLedgerAccount(accountID), from, at)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	return balance + change, start, applied, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceService_GetBalanceAt(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	ledgerAccount := models.CustomerLedgerAccount(accountID)
+	asOf := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	setup := func() (*BalanceService, *mocks.MockBalanceSnapshotRepository, *mocks.MockLedgerRepository) {
+		mockSnapshotRepo := new(mocks.MockBalanceSnapshotRepository)
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency}, nil)
+		return NewBalanceService(mockSnapshotRepo, mockLedgerRepo, mockAccountRepo), mockSnapshotRepo, mockLedgerRepo
+	}
+
+	t.Run("Starts From The Latest Snapshot", func(t *testing.T) {
+		service, mockSnapshotRepo, mockLedgerRepo := setup()
+		snapshot := models.BalanceSnapshot{AccountID: accountID, TakenAt: models.SnapshotTime(asOf), Balance: models.NewMoney(500)}
+		mockSnapshotRepo.On("GetLatest", ctx, accountID, asOf).Return(snapshot, nil)
+		mockLedgerRepo.On("SumPostings", ctx, ledgerAccount, snapshot.TakenAt, asOf).Return(-models.NewMoney(120), 3, nil)
+
+		balance, err := service.GetBalanceAt(ctx, accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(380), balance.Balance)
+		assert.Equal(t, 3, balance.PostingsApplied)
+		require.NotNil(t, balance.Snapshot)
+		assert.Equal(t, snapshot.TakenAt, balance.Snapshot.TakenAt)
+	})
+
+	t.Run("Without A Snapshot", func(t *testing.T) {
+		service, mockSnapshotRepo, mockLedgerRepo := setup()
+		mockSnapshotRepo.On("GetLatest", ctx, accountID, asOf).Return(models.BalanceSnapshot{}, models.ErrBalanceSnapshotNotFound)
+		mockLedgerRepo.On("SumPostings", ctx, ledgerAccount, time.Time{}, asOf).Return(models.NewMoney(75), 2, nil)
+
+		balance, err := service.GetBalanceAt(ctx, accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(75), balance.Balance)
+		assert.Nil(t, balance.Snapshot)
+	})
+
+	t.Run("Future", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.GetBalanceAt(ctx, accountID, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidAsOf)
+	})
+}
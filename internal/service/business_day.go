@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type BusinessDayService struct {
+	dayRepo         BusinessDayRepository
+	snapshotRepo    BalanceSnapshotRepository
+	postingRepo     PostingSumRepository
+	accountRepo     AccountRepository
+	transactionRepo BusinessDayTransactionRepository
+}
+
+type BusinessDayRepository interface {
+	Start(ctx context.Context, day *models.BusinessDay) error
+	Get(ctx context.Context, date time.Time) (models.BusinessDay, error)
+	GetLatest(ctx context.Context) (models.BusinessDay, error)
+	GetAll(ctx context.Context) ([]models.BusinessDay, error)
+	Close(ctx context.Context, day *models.BusinessDay) error
+}
+
+type BusinessDayTransactionRepository interface {
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+	GetSettled(ctx context.Context, accountID string, from, until time.Time) ([]models.Transaction, error)
+	CountPending(ctx context.Context, from, until time.Time) (int, error)
+}
+
+func NewBusinessDayService(dayRepo BusinessDayRepository, snapshotRepo BalanceSnapshotRepository, postingRepo PostingSumRepository, accountRepo AccountRepository, transactionRepo BusinessDayTransactionRepository) *BusinessDayService {
+	return &BusinessDayService{
+		dayRepo:         dayRepo,
+		snapshotRepo:    snapshotRepo,
+		postingRepo:     postingRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (s *BusinessDayService) GetDays(ctx context.Context) ([]models.BusinessDay, error) {
+	return s.dayRepo.GetAll(ctx)
+}
+
+func (s *BusinessDayService) GetDay(ctx context.Context, date time.Time) (models.BusinessDay, error) {
+	return s.dayRepo.Get(ctx, models.BusinessDate(date))
+}
+
+// CloseDay closes the business day containing date: it snapshots the closing
+// balance and the day's totals of every account opened by the end of the day,
+// then records the day as closed with the sum of their totals. Days are closed
+// one after another, and a day is not closed while any of its transactions
+// are still pending. A close that failed partway is resumed by closing the
+// day again, which keeps the snapshots already taken; closing a closed day
+// returns it unchanged.
+func (s *BusinessDayService) CloseDay(ctx context.Context, date time.Time, now time.Time) (models.BusinessDay, error) {
+	day := models.BusinessDay{Date: models.BusinessDate(date)}
+	end := day.End()
+	if now.Before(end) {
+		return models.BusinessDay{}, fmt.Errorf("%w: %s has not ended", models.ErrInvalidBusinessDay, day.Date.Format(time.DateOnly))
+	}
+
+	existing, err := s.dayRepo.Get(ctx, day.Date)
+	switch {
+	case err == nil && existing.Status == models.BusinessDayClosed:
+		return existing, nil
+	case err == nil:
+		day = existing
+	case errors.Is(err, models.ErrBusinessDayNotFound):
+		if err := s.checkNext(ctx, day.Date); err != nil {
+			return models.BusinessDay{}, err
+		}
+		day.Status = models.BusinessDayClosing
+		day.StartedAt = now
+	default:
+		return models.BusinessDay{}, err
+	}
+
+	pending, err := s.transactionRepo.CountPending(ctx, day.Date, end)
+	if err != nil {
+		return models.BusinessDay{}, err
+	}
+	if pending > 0 {
+		return models.BusinessDay{}, fmt.Errorf("%w: %d transactions from %s are still pending", models.ErrBusinessDayPending, pending, day.Date.Format(time.DateOnly))
+	}
+	if err := s.dayRepo.Start(ctx, &day); err != nil {
+		return models.BusinessDay{}, fmt.Errorf("failed to start closing %s: %w", day.Date.Format(time.DateOnly), err)
+	}
+
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return models.BusinessDay{}, err
+	}
+	day.Accounts = 0
+	day.DayTotals = models.DayTotals{}
+	for i := range accounts {
+		account := &accounts[i]
+		if !account.CreatedAt.Before(end) {
+			continue
+		}
+		snapshot, err := s.closeAccount(ctx, account, day.Date, end)
+		if err != nil {
+			return models.BusinessDay{}, fmt.Errorf("failed to close account %s: %w", account.ID, err)
+		}
+		day.Accounts++
+		day.Merge(snapshot.DayTotals)
+	}
+
+	closedAt := now
+	day.Status = models.BusinessDayClosed
+	day.ClosedAt = &closedAt
+	if err := s.dayRepo.Close(ctx, &day); err != nil {
+		return models.BusinessDay{}, err
+	}
+	return day, nil
+}
+
+// CloseDue closes every business day that has ended by now, oldest first,
+// starting after the last day closed, or with yesterday if none has been. It
+// stops at the first day it cannot close and returns how many it closed.
+func (s *BusinessDayService) CloseDue(ctx context.Context, now time.Time) (int, error) {
+	next := models.BusinessDate(now).AddDate(0, 0, -1)
+	latest, err := s.dayRepo.GetLatest(ctx)
+	if err == nil {
+		next = latest.Date
+		if latest.Status == models.BusinessDayClosed {
+			next = latest.End()
+		}
+	} else if !errors.Is(err, models.ErrBusinessDayNotFound) {
+		return 0, err
+	}
+
+	closed := 0
+	for day := (models.BusinessDay{Date: next}); !now.Before(day.End()); day.Date = day.End() {
+		if _, err := s.CloseDay(ctx, day.Date, now); err != nil {
+			return closed, err
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// checkNext makes sure date is the day after the last one closed.
+func (s *BusinessDayService) checkNext(ctx context.Context, date time.Time) error {
+	latest, err := s.dayRepo.GetLatest(ctx)
+	if errors.Is(err, models.ErrBusinessDayNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if latest.Status != models.BusinessDayClosed {
+		return fmt.Errorf("%w: %s is still closing", models.ErrInvalidBusinessDay, latest.Date.Format(time.DateOnly))
+	}
+	if !latest.End().Equal(date) {
+		return fmt.Errorf("%w: the next day to close is %s", models.ErrInvalidBusinessDay, latest.End().Format(time.DateOnly))
+	}
+	return nil
+}
+
+// closeAccount snapshots the account at the end of the day, from its previous
+// snapshot, or returns the snapshot an earlier attempt already took.
+func (s *BusinessDayService) closeAccount(ctx context.Context, account *models.Account, start, end time.Time) (models.BalanceSnapshot, error) {
+	var from time.Time
+	var balance models.Money
+	previous, err := s.snapshotRepo.GetLatest(ctx, account.ID, end)
+	switch {
+	case err == nil && previous.TakenAt.Equal(end):
+		return previous, nil
+	case err == nil:
+		from, balance = previous.TakenAt, previous.Balance
+	case !errors.Is(err, models.ErrBalanceSnapshotNotFound):
+		return models.BalanceSnapshot{}, err
+	}
+
+	change, _, err := s.postingRepo.SumPostings(ctx, models.CustomerLedgerAccount(account.ID), from, end)
+	if err != nil {
+		return models.BalanceSnapshot{}, err
+	}
+	settled, err := settledTransactions(ctx, s.transactionRepo, account.ID, start, end)
+	if err != nil {
+		return models.BalanceSnapshot{}, err
+	}
+
+	snapshot := models.BalanceSnapshot{
+		AccountID: account.ID,
+		TakenAt:   end,
+		Balance:   balance + change,
+		Currency:  account.Currency,
+	}
+	for _, tx := range settled {
+		snapshot.Add(tx)
+	}
+	created, err := s.snapshotRepo.Create(ctx, &snapshot)
+	if err != nil {
+		return models.BalanceSnapshot{}, err
+	}
+	if !created {
+		return s.snapshotRepo.GetLatest(ctx, account.ID, end)
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots returns the account's end-of-day snapshots taken at or after
+// from and before until, oldest first.
+func (s *BusinessDayService) GetSnapshots(ctx context.Context, accountID uuid.UUID, from, until time.Time) ([]models.BalanceSnapshot, error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.snapshotRepo.GetRange(ctx, accountID, from, until)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessDayService_CloseDay(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	end := date.AddDate(0, 0, 1)
+	now := end.Add(time.Hour)
+
+	account := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: date.AddDate(0, -1, 0)}
+	resumed := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: date.AddDate(0, -1, 0)}
+	opened := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: end}
+	deposit := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(40), Currency: models.DefaultCurrency, AccountID: account.ID.String(), Status: models.SUCCESS, ProcessedAt: date.Add(time.Hour)}
+
+	setup := func() (*BusinessDayService, *mocks.MockBusinessDayRepository, *mocks.MockBalanceSnapshotRepository, *mocks.MockLedgerRepository, *mocks.MockTransactionRepository) {
+		mockDayRepo := new(mocks.MockBusinessDayRepository)
+		mockSnapshotRepo := new(mocks.MockBalanceSnapshotRepository)
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{account, resumed, opened}, nil)
+		return NewBusinessDayService(mockDayRepo, mockSnapshotRepo, mockLedgerRepo, mockAccountRepo, mockTransactionRepo), mockDayRepo, mockSnapshotRepo, mockLedgerRepo, mockTransactionRepo
+	}
+
+	t.Run("Snapshots Every Account And Resumes", func(t *testing.T) {
+		service, mockDayRepo, mockSnapshotRepo, mockLedgerRepo, mockTransactionRepo := setup()
+		mockDayRepo.On("Get", ctx, date).Return(models.BusinessDay{Date: date, Status: models.BusinessDayClosing, StartedAt: end}, nil)
+		mockTransactionRepo.On("CountPending", ctx, date, end).Return(0, nil)
+		mockDayRepo.On("Start", ctx, mock.AnythingOfType("*models.BusinessDay")).Return(nil)
+
+		previous := models.BalanceSnapshot{AccountID: account.ID, TakenAt: date, Balance: models.NewMoney(100)}
+		mockSnapshotRepo.On("GetLatest", ctx, account.ID, end).Return(previous, nil)
+		mockLedgerRepo.On("SumPostings", ctx, models.CustomerLedgerAccount(account.ID), date, end).Return(models.NewMoney(40), 1, nil)
+		mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), date, end).Return([]models.Transaction{deposit}, nil)
+		mockSnapshotRepo.On("Create", ctx, mock.MatchedBy(func(snapshot *models.BalanceSnapshot) bool {
+			return snapshot.AccountID == account.ID && snapshot.TakenAt.Equal(end) && snapshot.Balance == models.NewMoney(140) && snapshot.DepositCount == 1
+		})).Return(true, nil)
+
+		taken := models.BalanceSnapshot{AccountID: resumed.ID, TakenAt: end, Balance: models.NewMoney(5), DayTotals: models.DayTotals{Debits: models.NewMoney(5), TransactionCount: 1}}
+		mockSnapshotRepo.On("GetLatest", ctx, resumed.ID, end).Return(taken, nil)
+
+		mockDayRepo.On("Close", ctx, mock.AnythingOfType("*models.BusinessDay")).Return(nil)
+
+		day, err := service.CloseDay(ctx, date.Add(5*time.Hour), now)
+		require.NoError(t, err)
+		assert.Equal(t, models.BusinessDayClosed, day.Status)
+		assert.Equal(t, 2, day.Accounts)
+		assert.Equal(t, models.NewMoney(40), day.Deposits)
+		assert.Equal(t, models.NewMoney(5), day.Debits)
+		assert.Equal(t, 2, day.TransactionCount)
+		assert.Equal(t, end, day.StartedAt)
+		require.NotNil(t, day.ClosedAt)
+		mockSnapshotRepo.AssertNumberOfCalls(t, "Create", 1)
+		mockTransactionRepo.AssertNotCalled(t, "GetSettled", ctx, resumed.ID.String(), date, end)
+	})
+
+	t.Run("Refuses While Transactions Are Pending", func(t *testing.T) {
+		service, mockDayRepo, _, _, mockTransactionRepo := setup()
+		mockDayRepo.On("Get", ctx, date).Return(models.BusinessDay{}, models.ErrBusinessDayNotFound)
+		mockDayRepo.On("GetLatest", ctx).Return(models.BusinessDay{}, models.ErrBusinessDayNotFound)
+		mockTransactionRepo.On("CountPending", ctx, date, end).Return(2, nil)
+
+		_, err := service.CloseDay(ctx, date, now)
+		assert.ErrorIs(t, err, models.ErrBusinessDayPending)
+		mockDayRepo.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Closes Days In Order", func(t *testing.T) {
+		service, mockDayRepo, _, _, _ := setup()
+		mockDayRepo.On("Get", ctx, date).Return(models.BusinessDay{}, models.ErrBusinessDayNotFound)
+		mockDayRepo.On("GetLatest", ctx).Return(models.BusinessDay{Date: date.AddDate(0, 0, -3), Status: models.BusinessDayClosed}, nil)
+
+		_, err := service.CloseDay(ctx, date, now)
+		assert.ErrorIs(t, err, models.ErrInvalidBusinessDay)
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		service, mockDayRepo, _, _, mockTransactionRepo := setup()
+		closed := models.BusinessDay{Date: date, Status: models.BusinessDayClosed, Accounts: 7}
+		mockDayRepo.On("Get", ctx, date).Return(closed, nil)
+
+		day, err := service.CloseDay(ctx, date, now)
+		require.NoError(t, err)
+		assert.Equal(t, 7, day.Accounts)
+		mockTransactionRepo.AssertNotCalled(t, "CountPending", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Day Not Ended", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		_, err := service.CloseDay(ctx, date, end.Add(-time.Minute))
+		assert.ErrorIs(t, err, models.ErrInvalidBusinessDay)
+	})
+}
+
+func TestBusinessDayService_CloseDue(t *testing.T) {
+	ctx := context.Background()
+	last := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, time.March, 12, 8, 0, 0, 0, time.UTC)
+
+	mockDayRepo := new(mocks.MockBusinessDayRepository)
+	mockAccountRepo := new(mocks.MockAccountRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockDayRepo.On("GetLatest", ctx).Return(models.BusinessDay{Date: last, Status: models.BusinessDayClosed}, nil).Once()
+	mockDayRepo.On("Get", ctx, last.AddDate(0, 0, 1)).Return(models.BusinessDay{}, models.ErrBusinessDayNotFound)
+	mockDayRepo.On("GetLatest", ctx).Return(models.BusinessDay{Date: last, Status: models.BusinessDayClosed}, nil).Once()
+	mockTransactionRepo.On("CountPending", ctx, last.AddDate(0, 0, 1), last.AddDate(0, 0, 2)).Return(0, nil)
+	mockDayRepo.On("Start", ctx, mock.AnythingOfType("*models.BusinessDay")).Return(nil)
+	mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{}, nil)
+	mockDayRepo.On("Close", ctx, mock.AnythingOfType("*models.BusinessDay")).Return(nil)
+
+	service := NewBusinessDayService(mockDayRepo, new(mocks.MockBalanceSnapshotRepository), new(mocks.MockLedgerRepository), mockAccountRepo, mockTransactionRepo)
+	closed, err := service.CloseDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	mockDayRepo.AssertNumberOfCalls(t, "Close", 1)
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// chainBatchSize is how many transactions LinkPending links at a time.
+	chainBatchSize = 100
+
+	// DefaultChainCheckpoints is how many checkpoints are listed by default.
+	DefaultChainCheckpoints = 50
+)
+
+type ChainService struct {
+	chainRepo       ChainRepository
+	transactionRepo ChainTransactionRepository
+	signingKey      ed25519.PrivateKey
+}
+
+type ChainRepository interface {
+	Append(ctx context.Context, accountID string, tx *models.Transaction, now time.Time) (models.ChainLink, error)
+	GetLinks(ctx context.Context, accountID string) ([]models.ChainLink, error)
+	GetHeads(ctx context.Context) ([]models.ChainHead, error)
+	CreateCheckpoint(ctx context.Context, checkpoint *models.ChainCheckpoint) error
+	GetCheckpoint(ctx context.Context, id uuid.UUID) (models.ChainCheckpoint, error)
+	GetLatestCheckpoint(ctx context.Context) (models.ChainCheckpoint, error)
+	GetCheckpoints(ctx context.Context, limit int) ([]models.ChainCheckpoint, error)
+}
+
+type ChainTransactionRepository interface {
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+	GetUnchained(ctx context.Context, limit int) ([]models.Transaction, error)
+	MarkChained(ctx context.Context, id string, chainedAt time.Time) error
+}
+
+// NewChainService returns a service that signs checkpoints with signingKey.
+// Without a key no checkpoints are taken, and chains are verified without
+// them.
+func NewChainService(chainRepo ChainRepository, transactionRepo ChainTransactionRepository, signingKey ed25519.PrivateKey) *ChainService {
+	return &ChainService{
+		chainRepo:       chainRepo,
+		transactionRepo: transactionRepo,
+		signingKey:      signingKey,
+	}
+}
+
+// LinkPending links every final transaction not linked yet into the chain of
+// each account it involves, and returns how many it linked. A transaction is
+// marked as linked only once it is in all of them, so one that failed partway
+// is linked again, into the chains it is missing from, on the next run.
+func (s *ChainService) LinkPending(ctx context.Context, now time.Time) (int, error) {
+	linked := 0
+	for {
+		transactions, err := s.transactionRepo.GetUnchained(ctx, chainBatchSize)
+		if err != nil {
+			return linked, err
+		}
+		for i := range transactions {
+			tx := &transactions[i]
+			if err := s.link(ctx, tx, now); err != nil {
+				return linked, fmt.Errorf("failed to link transaction %s: %w", tx.ID, err)
+			}
+			linked++
+		}
+		if len(transactions) < chainBatchSize {
+			return linked, nil
+		}
+	}
+}
+
+func (s *ChainService) link(ctx context.Context, tx *models.Transaction, now time.Time) error {
+	accountIDs, err := s.chainAccounts(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range accountIDs {
+		if _, err := s.chainRepo.Append(ctx, accountID, tx, now); err != nil {
+			return err
+		}
+	}
+	return s.transactionRepo.MarkChained(ctx, tx.ID, now)
+}
+
+// chainAccounts returns the accounts whose chains the transaction belongs to:
+// the accounts it names, and for a reversal the account that received the
+// transfer it undoes.
+func (s *ChainService) chainAccounts(ctx context.Context, tx *models.Transaction) ([]string, error) {
+	accountIDs := []string{tx.AccountID}
+	add := func(accountID string) {
+		for _, id := range accountIDs {
+			if id == accountID {
+				return
+			}
+		}
+		if accountID != "" {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+
+	add(tx.DestinationAccountID)
+	if tx.Closure != nil {
+		add(tx.Closure.PayoutAccountID)
+	}
+	if tx.Type == models.REVERSAL {
+		original, err := s.transactionRepo.GetByID(ctx, tx.ReversalOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transaction %s reversed by %s: %w", tx.ReversalOf, tx.ID, err)
+		}
+		add(original.DestinationAccountID)
+	}
+	return accountIDs, nil
+}
+
+// Verify walks the account's chain from its first link and reports the first
+// link that does not verify: one out of sequence, not following the link
+// before it, edited, or whose transaction has been removed or edited since it
+// was linked. The chain must also still hold the head recorded by the latest
+// checkpoint, which must carry a valid signature.
+func (s *ChainService) Verify(ctx context.Context, accountID string) (models.ChainVerification, error) {
+	verification := models.ChainVerification{AccountID: accountID}
+	links, err := s.chainRepo.GetLinks(ctx, accountID)
+	if err != nil {
+		return models.ChainVerification{}, err
+	}
+	verification.Links = len(links)
+	if len(links) > 0 {
+		head := links[len(links)-1]
+		verification.Head = &models.ChainHead{AccountID: accountID, Sequence: head.Sequence, Hash: head.Hash}
+	}
+
+	var previous *models.ChainLink
+	for i := range links {
+		link := &links[i]
+		brk, err := s.checkLink(ctx, previous, link)
+		if err != nil {
+			return models.ChainVerification{}, err
+		}
+		if brk != nil {
+			verification.Break = brk
+			return verification, nil
+		}
+		previous = link
+	}
+
+	brk, err := s.checkCheckpoint(ctx, &verification, links)
+	if err != nil {
+		return models.ChainVerification{}, err
+	}
+	verification.Break = brk
+	verification.Valid = brk == nil
+	return verification, nil
+}
+
+// VerifyAll verifies the chain of every account that has one, or had one at
+// the latest checkpoint.
+func (s *ChainService) VerifyAll(ctx context.Context) ([]models.ChainVerification, error) {
+	heads, err := s.chainRepo.GetHeads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.signingKey != nil {
+		checkpoint, err := s.chainRepo.GetLatestCheckpoint(ctx)
+		if err != nil && !errors.Is(err, models.ErrChainCheckpointNotFound) {
+			return nil, err
+		}
+		heads = append(heads, checkpoint.Heads...)
+	}
+
+	verified := make(map[string]bool, len(heads))
+	verifications := make([]models.ChainVerification, 0, len(heads))
+	for _, head := range heads {
+		if verified[head.AccountID] {
+			continue
+		}
+		verified[head.AccountID] = true
+		verification, err := s.Verify(ctx, head.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the chain of account %s: %w", head.AccountID, err)
+		}
+		verifications = append(verifications, verification)
+	}
+	return verifications, nil
+}
+
+func (s *ChainService) checkLink(ctx context.Context, previous, link *models.ChainLink) (*models.ChainBreak, error) {
+	brk := &models.ChainBreak{Sequence: link.Sequence, TransactionID: link.TransactionID}
+	sequence, previousHash := int64(1), ""
+	if previous != nil {
+		sequence, previousHash = previous.Sequence+1, previous.Hash
+	}
+
+	switch {
+	case link.Sequence != sequence:
+		brk.Reason, brk.Expected, brk.Actual = models.BreakSequenceGap, fmt.Sprint(sequence), fmt.Sprint(link.Sequence)
+	case link.PreviousHash != previousHash:
+		brk.Reason, brk.Expected, brk.Actual = models.BreakPreviousHash, previousHash, link.PreviousHash
+	case link.ComputeHash() != link.Hash:
+		brk.Reason, brk.Expected, brk.Actual = models.BreakLinkHash, link.ComputeHash(), link.Hash
+	default:
+		tx, err := s.transactionRepo.GetByID(ctx, link.TransactionID)
+		if errors.Is(err, models.ErrTransactionNotFound) {
+			brk.Reason = models.BreakTransactionMissing
+			return brk, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		contentHash, err := models.TransactionContentHash(tx)
+		if err != nil {
+			return nil, err
+		}
+		if contentHash == link.ContentHash {
+			return nil, nil
+		}
+		brk.Reason, brk.Expected, brk.Actual = models.BreakContentHash, link.ContentHash, contentHash
+	}
+	return brk, nil
+}
+
+// checkCheckpoint checks the chain against the head the latest checkpoint
+// recorded for it. It is skipped without a signing key to check the
+// checkpoint's signature with.
+func (s *ChainService) checkCheckpoint(ctx context.Context, verification *models.ChainVerification, links []models.ChainLink) (*models.ChainBreak, error) {
+	if s.signingKey == nil {
+		return nil, nil
+	}
+	checkpoint, err := s.chainRepo.GetLatestCheckpoint(ctx)
+	if errors.Is(err, models.ErrChainCheckpointNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	verification.CheckpointID = &checkpoint.ID
+
+	if !checkpoint.Verify(s.signingKey.Public().(ed25519.PublicKey)) {
+		return &models.ChainBreak{Reason: models.BreakCheckpointSignature}, nil
+	}
+	head, ok := checkpoint.Head(verification.AccountID)
+	if !ok {
+		return nil, nil
+	}
+	brk := &models.ChainBreak{Sequence: head.Sequence, Reason: models.BreakCheckpoint, Expected: head.Hash}
+	if head.Sequence < 1 || head.Sequence > int64(len(links)) {
+		return brk, nil
+	}
+	link := links[head.Sequence-1]
+	if link.Hash != head.Hash {
+		brk.TransactionID, brk.Actual = link.TransactionID, link.Hash
+		return brk, nil
+	}
+	return nil, nil
+}
+
+// Checkpoint signs and stores the current head of every chain.
+func (s *ChainService) Checkpoint(ctx context.Context, now time.Time) (models.ChainCheckpoint, error) {
+	if s.signingKey == nil {
+		return models.ChainCheckpoint{}, models.ErrChainSigningKeyMissing
+	}
+	heads, err := s.chainRepo.GetHeads(ctx)
+	if err != nil {
+		return models.ChainCheckpoint{}, err
+	}
+	checkpoint := models.NewChainCheckpoint(heads, s.signingKey, now)
+	if err := s.chainRepo.CreateCheckpoint(ctx, &checkpoint); err != nil {
+		return models.ChainCheckpoint{}, err
+	}
+	return checkpoint, nil
+}
+
+func (s *ChainService) GetCheckpoint(ctx context.Context, id uuid.UUID) (models.ChainCheckpoint, error) {
+	return s.chainRepo.GetCheckpoint(ctx, id)
+}
+
+func (s *ChainService) GetCheckpoints(ctx context.Context) ([]models.ChainCheckpoint, error) {
+	return s.chainRepo.GetCheckpoints(ctx, DefaultChainCheckpoints)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainService_LinkPending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	sender, receiver := uuid.NewString(), uuid.NewString()
+	transfer := models.Transaction{ID: uuid.NewString(), Type: models.TRANSFER, Amount: models.NewMoney(30), AccountID: sender, DestinationAccountID: receiver, Status: models.SUCCESS, ProcessedAt: now}
+	reversal := models.Transaction{ID: uuid.NewString(), Type: models.REVERSAL, Amount: models.NewMoney(30), AccountID: sender, ReversalOf: transfer.ID, Status: models.SUCCESS, ProcessedAt: now}
+
+	mockChainRepo := new(mocks.MockChainRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockTransactionRepo.On("GetUnchained", ctx, chainBatchSize).Return([]models.Transaction{transfer, reversal}, nil)
+	mockTransactionRepo.On("GetByID", ctx, transfer.ID).Return(&transfer, nil)
+	mockChainRepo.On("Append", ctx, mock.Anything, mock.AnythingOfType("*models.Transaction"), now).Return(models.ChainLink{}, nil)
+	mockTransactionRepo.On("MarkChained", ctx, mock.Anything, now).Return(nil)
+	service := NewChainService(mockChainRepo, mockTransactionRepo, nil)
+
+	linked, err := service.LinkPending(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, linked)
+	mockChainRepo.AssertNumberOfCalls(t, "Append", 4)
+	for _, tx := range []*models.Transaction{&transfer, &reversal} {
+		mockChainRepo.AssertCalled(t, "Append", ctx, sender, tx, now)
+		mockChainRepo.AssertCalled(t, "Append", ctx, receiver, tx, now)
+		mockTransactionRepo.AssertCalled(t, "MarkChained", ctx, tx.ID, now)
+	}
+}
+
+func TestChainService_Verify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	accountID := uuid.NewString()
+	deposit := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(40), AccountID: accountID, Status: models.SUCCESS, ProcessedAt: now}
+	withdrawal := models.Transaction{ID: uuid.NewString(), Type: models.WITHDRAWL, Amount: models.NewMoney(10), AccountID: accountID, Status: models.SUCCESS, ProcessedAt: now}
+
+	first, err := models.NewChainLink(nil, accountID, &deposit, now)
+	require.NoError(t, err)
+	second, err := models.NewChainLink(&first, accountID, &withdrawal, now)
+	require.NoError(t, err)
+	_, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	setup := func(withdrawal models.Transaction) (*ChainService, *mocks.MockChainRepository, *mocks.MockTransactionRepository) {
+		mockChainRepo := new(mocks.MockChainRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockChainRepo.On("GetLinks", ctx, accountID).Return([]models.ChainLink{first, second}, nil)
+		mockTransactionRepo.On("GetByID", ctx, deposit.ID).Return(&deposit, nil)
+		mockTransactionRepo.On("GetByID", ctx, withdrawal.ID).Return(&withdrawal, nil)
+		return NewChainService(mockChainRepo, mockTransactionRepo, key), mockChainRepo, mockTransactionRepo
+	}
+
+	t.Run("Valid Chain", func(t *testing.T) {
+		service, mockChainRepo, _ := setup(withdrawal)
+		checkpoint := models.NewChainCheckpoint([]models.ChainHead{{AccountID: accountID, Sequence: 2, Hash: second.Hash}}, key, now)
+		mockChainRepo.On("GetLatestCheckpoint", ctx).Return(checkpoint, nil)
+
+		verification, err := service.Verify(ctx, accountID)
+		require.NoError(t, err)
+		assert.True(t, verification.Valid)
+		assert.Equal(t, 2, verification.Links)
+		assert.Equal(t, second.Hash, verification.Head.Hash)
+		assert.Equal(t, &checkpoint.ID, verification.CheckpointID)
+	})
+
+	t.Run("Edited Transaction", func(t *testing.T) {
+		edited := withdrawal
+		edited.Amount = models.NewMoney(1)
+		service, _, _ := setup(edited)
+
+		verification, err := service.Verify(ctx, accountID)
+		require.NoError(t, err)
+		assert.False(t, verification.Valid)
+		require.NotNil(t, verification.Break)
+		assert.Equal(t, int64(2), verification.Break.Sequence)
+		assert.Equal(t, withdrawal.ID, verification.Break.TransactionID)
+		assert.Equal(t, models.BreakContentHash, verification.Break.Reason)
+	})
+
+	t.Run("Removed Transaction", func(t *testing.T) {
+		mockChainRepo := new(mocks.MockChainRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockChainRepo.On("GetLinks", ctx, accountID).Return([]models.ChainLink{first, second}, nil)
+		mockTransactionRepo.On("GetByID", ctx, deposit.ID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		service := NewChainService(mockChainRepo, mockTransactionRepo, key)
+
+		verification, err := service.Verify(ctx, accountID)
+		require.NoError(t, err)
+		assert.False(t, verification.Valid)
+		assert.Equal(t, int64(1), verification.Break.Sequence)
+		assert.Equal(t, models.BreakTransactionMissing, verification.Break.Reason)
+	})
+
+	t.Run("Rewritten Chain", func(t *testing.T) {
+		service, mockChainRepo, _ := setup(withdrawal)
+		checkpoint := models.NewChainCheckpoint([]models.ChainHead{{AccountID: accountID, Sequence: 2, Hash: "rewritten"}}, key, now)
+		mockChainRepo.On("GetLatestCheckpoint", ctx).Return(checkpoint, nil)
+
+		verification, err := service.Verify(ctx, accountID)
+		require.NoError(t, err)
+		assert.False(t, verification.Valid)
+		assert.Equal(t, models.BreakCheckpoint, verification.Break.Reason)
+		assert.Equal(t, second.Hash, verification.Break.Actual)
+	})
+
+	t.Run("Forged Checkpoint", func(t *testing.T) {
+		service, mockChainRepo, _ := setup(withdrawal)
+		_, forger, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		mockChainRepo.On("GetLatestCheckpoint", ctx).Return(models.NewChainCheckpoint(nil, forger, now), nil)
+
+		verification, err := service.Verify(ctx, accountID)
+		require.NoError(t, err)
+		assert.False(t, verification.Valid)
+		assert.Equal(t, models.BreakCheckpointSignature, verification.Break.Reason)
+	})
+}
+
+func TestChainService_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	heads := []models.ChainHead{{AccountID: uuid.NewString(), Sequence: 3, Hash: "head"}}
+
+	t.Run("Signs Heads", func(t *testing.T) {
+		_, key, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		mockChainRepo := new(mocks.MockChainRepository)
+		mockChainRepo.On("GetHeads", ctx).Return(heads, nil)
+		mockChainRepo.On("CreateCheckpoint", ctx, mock.AnythingOfType("*models.ChainCheckpoint")).Return(nil)
+		service := NewChainService(mockChainRepo, nil, key)
+
+		checkpoint, err := service.Checkpoint(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, heads, checkpoint.Heads)
+		assert.True(t, checkpoint.Verify(key.Public().(ed25519.PublicKey)))
+	})
+
+	t.Run("Without Signing Key", func(t *testing.T) {
+		mockChainRepo := new(mocks.MockChainRepository)
+		service := NewChainService(mockChainRepo, nil, nil)
+
+		_, err := service.Checkpoint(ctx, now)
+		assert.ErrorIs(t, err, models.ErrChainSigningKeyMissing)
+		mockChainRepo.AssertNotCalled(t, "GetHeads", mock.Anything)
+	})
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type ClosureService struct {
+	accountRepo     AccountRepository
+	statusRepo      AccountStatusRepository
+	transactionRepo ClosureTransactionRepository
+}
+
+type ClosureTransactionRepository interface {
+	Create(ctx context.Context, tx *models.Transaction) error
+	HasPending(ctx context.Context, accountID string) (bool, error)
+}
+
+func NewClosureService(accountRepo AccountRepository, statusRepo AccountStatusRepository, transactionRepo ClosureTransactionRepository) *ClosureService {
+	return &ClosureService{
+		accountRepo:     accountRepo,
+		statusRepo:      statusRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Close starts closing an account that was at the given version. An empty
+// account is closed at once and nil is returned. Otherwise its balance is
+// paid out by the returned CLOSURE transaction, and the worker closes the
+// account when it posts it. The account keeps its row and history either way.
+func (s *ClosureService) Close(ctx context.Context, accountID uuid.UUID, version int64, request models.AccountClosureRequest, operatorID string) (*models.Transaction, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Version != version {
+		return nil, models.ErrStaleVersion
+	}
+	if account.Status == models.AccountClosed {
+		return nil, models.ErrAccountClosed
+	}
+	if account.Balance < 0 {
+		return nil, models.ErrAccountOverdrawn
+	}
+
+	pending, err := s.transactionRepo.HasPending(ctx, accountID.String())
+	if err != nil {
+		return nil, err
+	}
+	if pending || account.HeldBalance != 0 {
+		return nil, models.ErrPendingTransactions
+	}
+
+	closure := &models.AccountClosure{Reason: request.Reason, OperatorID: operatorID}
+	if request.PayoutAccountID != "" {
+		if err := s.checkPayoutAccount(ctx, &account, request.PayoutAccountID); err != nil {
+			return nil, err
+		}
+		closure.PayoutAccountID = request.PayoutAccountID
+	}
+
+	if account.Balance == 0 {
+		change := &models.AccountStatusChange{
+			ID:         uuid.New(),
+			AccountID:  accountID,
+			ToStatus:   models.AccountClosed,
+			Reason:     request.Reason,
+			OperatorID: operatorID,
+		}
+		return nil, s.statusRepo.ChangeStatus(ctx, change)
+	}
+
+	tx := &models.Transaction{
+		ID:        uuid.New().String(),
+		Type:      models.CLOSURE,
+		Amount:    account.Balance,
+		Currency:  account.Currency,
+		AccountID: accountID.String(),
+		Closure:   closure,
+		Status:    models.PENDING,
+	}
+	if err := account.CheckTransaction(tx); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tx.CreatedAt = now
+	tx.UpdatedAt = now
+	tx.Outbox = models.NewOutbox(now)
+	if err := s.transactionRepo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (s *ClosureService) checkPayoutAccount(ctx context.Context, account *models.Account, payoutAccountID string) error {
+	payoutID, err := uuid.Parse(payoutAccountID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid payout account ID", models.ErrInvalidClosure)
+	}
+	if payoutID == account.ID {
+		return fmt.Errorf("%w: the payout account must be another account", models.ErrInvalidClosure)
+	}
+	payout, err := s.accountRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		return fmt.Errorf("%w: payout account: %v", models.ErrInvalidClosure, err)
+	}
+	if err := payout.CheckIncoming(); err != nil {
+		return fmt.Errorf("%w: payout %v", models.ErrInvalidClosure, err)
+	}
+	if payout.Currency != account.Currency {
+		return fmt.Errorf("%w: the payout account must be in %s", models.ErrInvalidClosure, account.Currency)
+	}
+	return nil
+}
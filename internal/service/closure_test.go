@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosureService_Close(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	payoutID := uuid.New()
+	request := models.AccountClosureRequest{PayoutAccountID: payoutID.String(), Reason: "customer request"}
+
+	newService := func() (*ClosureService, *mocks.MockAccountRepository, *mocks.MockTransactionRepository) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		return NewClosureService(mockAccountRepo, mockAccountRepo, mockTransactionRepo), mockAccountRepo, mockTransactionRepo
+	}
+	account := func(balance int64) models.Account {
+		return models.Account{
+			ID:       accountID,
+			Balance:  models.NewMoney(balance),
+			Currency: models.DefaultCurrency,
+			Status:   models.AccountActive,
+			Version:  3,
+		}
+	}
+	payout := models.Account{ID: payoutID, Currency: models.DefaultCurrency, Status: models.AccountActive}
+
+	t.Run("Pays Out The Balance", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(120), nil)
+		mockAccountRepo.On("GetByID", ctx, payoutID).Return(payout, nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(false, nil)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.Type == models.CLOSURE && tx.Amount == models.NewMoney(120) && tx.Outbox != nil
+		})).Return(nil)
+
+		tx, err := service.Close(ctx, accountID, 3, request, "ops-1")
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		assert.Equal(t, payoutID.String(), tx.Closure.PayoutAccountID)
+		assert.Equal(t, "ops-1", tx.Closure.OperatorID)
+		mockAccountRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Empty Account Closes At Once", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(0), nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(false, nil)
+		mockAccountRepo.On("ChangeStatus", ctx, mock.MatchedBy(func(change *models.AccountStatusChange) bool {
+			return change.AccountID == accountID && change.ToStatus == models.AccountClosed
+		})).Return(nil)
+
+		tx, err := service.Close(ctx, accountID, 3, models.AccountClosureRequest{Reason: "customer request"}, "")
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Stale Version", func(t *testing.T) {
+		service, mockAccountRepo, _ := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(120), nil)
+
+		_, err := service.Close(ctx, accountID, 2, request, "")
+		assert.ErrorIs(t, err, models.ErrStaleVersion)
+	})
+
+	t.Run("Pending Transactions", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(120), nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(true, nil)
+
+		_, err := service.Close(ctx, accountID, 3, request, "")
+		assert.ErrorIs(t, err, models.ErrPendingTransactions)
+	})
+
+	t.Run("Funds On Hold", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		held := account(120)
+		held.HeldBalance = models.NewMoney(20)
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(held, nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(false, nil)
+
+		_, err := service.Close(ctx, accountID, 3, request, "")
+		assert.ErrorIs(t, err, models.ErrPendingTransactions)
+	})
+
+	t.Run("Overdrawn", func(t *testing.T) {
+		service, mockAccountRepo, _ := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(-5), nil)
+
+		_, err := service.Close(ctx, accountID, 3, request, "")
+		assert.ErrorIs(t, err, models.ErrAccountOverdrawn)
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		service, mockAccountRepo, _ := newService()
+		closed := account(0)
+		closed.Status = models.AccountClosed
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(closed, nil)
+
+		_, err := service.Close(ctx, accountID, 3, request, "")
+		assert.ErrorIs(t, err, models.ErrAccountClosed)
+	})
+
+	t.Run("Payout Account In Another Currency", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		euros := payout
+		euros.Currency = "EUR"
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(120), nil)
+		mockAccountRepo.On("GetByID", ctx, payoutID).Return(euros, nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(false, nil)
+
+		_, err := service.Close(ctx, accountID, 3, request, "")
+		assert.ErrorIs(t, err, models.ErrInvalidClosure)
+	})
+
+	t.Run("Payout To Itself", func(t *testing.T) {
+		service, mockAccountRepo, mockTransactionRepo := newService()
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(account(120), nil)
+		mockTransactionRepo.On("HasPending", ctx, accountID.String()).Return(false, nil)
+
+		_, err := service.Close(ctx, accountID, 3, models.AccountClosureRequest{PayoutAccountID: accountID.String(), Reason: "customer request"}, "")
+		assert.ErrorIs(t, err, models.ErrInvalidClosure)
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type FeeService struct {
+	feeRepo      FeeRuleRepository
+	accountRepo  AccountRepository
+	transactions TransactionCreator
+}
+
+type FeeRuleRepository interface {
+	Create(ctx context.Context, rule *models.FeeRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.FeeRule, error)
+	GetAll(ctx context.Context) ([]models.FeeRule, error)
+	GetActive(ctx context.Context, kind models.FeeKind) ([]models.FeeRule, error)
+	Update(ctx context.Context, id uuid.UUID, change func(*models.FeeRule) error) (models.FeeRule, error)
+}
+
+func NewFeeService(feeRepo FeeRuleRepository, accountRepo AccountRepository, transactions TransactionCreator) *FeeService {
+	return &FeeService{
+		feeRepo:      feeRepo,
+		accountRepo:  accountRepo,
+		transactions: transactions,
+	}
+}
+
+func (s *FeeService) CreateRule(ctx context.Context, request models.FeeRuleRequest) (models.FeeRule, error) {
+	rule, err := models.NewFeeRule(request)
+	if err != nil {
+		return models.FeeRule{}, err
+	}
+	if err := s.feeRepo.Create(ctx, &rule); err != nil {
+		return models.FeeRule{}, err
+	}
+	return rule, nil
+}
+
+func (s *FeeService) GetRule(ctx context.Context, id uuid.UUID) (models.FeeRule, error) {
+	return s.feeRepo.GetByID(ctx, id)
+}
+
+func (s *FeeService) GetRules(ctx context.Context) ([]models.FeeRule, error) {
+	return s.feeRepo.GetAll(ctx)
+}
+
+// UpdateRule replaces a rule's terms. Fees already charged keep the amounts
+// they were charged at.
+func (s *FeeService) UpdateRule(ctx context.Context, id uuid.UUID, request models.FeeRuleRequest) (models.FeeRule, error) {
+	return s.feeRepo.Update(ctx, id, func(rule *models.FeeRule) error {
+		return rule.Apply(request)
+	})
+}
+
+// DeactivateRule stops a rule from charging any more fees.
+func (s *FeeService) DeactivateRule(ctx context.Context, id uuid.UUID) (models.FeeRule, error) {
+	return s.feeRepo.Update(ctx, id, func(rule *models.FeeRule) error {
+		rule.Active = false
+		return nil
+	})
+}
+
+// ChargeMaintenance charges every open account its maintenance fee for the
+// last month that has ended by now, and returns how many it charged. Accounts
+// opened after that month are not charged for it, and an account is charged
+// at most once a month however often this runs.
+func (s *FeeService) ChargeMaintenance(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.feeRepo.GetActive(ctx, models.FeeKindMaintenance)
+	if err != nil {
+		return 0, err
+	}
+	if len(rules) == 0 {
+		return 0, nil
+	}
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	period, periodEnd := models.MaintenancePeriod(now)
+	charged := 0
+	for i := range accounts {
+		account := &accounts[i]
+		if account.Status == models.AccountClosed || !account.CreatedAt.Before(periodEnd) {
+			continue
+		}
+		rule := models.MaintenanceFeeRule(rules, account)
+		if rule == nil {
+			continue
+		}
+
+		id := models.MaintenanceFeeID(account.ID, period)
+		_, err := s.transactions.GetByID(ctx, id)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, models.ErrTransactionNotFound) {
+			return charged, err
+		}
+		fee := models.NewFeeTransaction(id, account, rule.Compute(0), models.FeeCharge{RuleID: rule.ID, Period: period}, now)
+		if err := s.transactions.Create(ctx, fee); err != nil {
+			log.Printf("Failed to charge account %s its maintenance fee for %s: %v", account.ID, period, err)
+			continue
+		}
+		charged++
+	}
+	return charged, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	queue_mocks "github.com/RajVerma97/golang-banking-ledger/pkg/queue/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeService_CreateRule(t *testing.T) {
+	ctx := context.Background()
+	mockFeeRepo := new(mocks.MockFeeRuleRepository)
+	mockFeeRepo.On("Create", ctx, mock.Anything).Return(nil)
+	service := NewFeeService(mockFeeRepo, nil, nil)
+	request := models.FeeRuleRequest{
+		Name:       "Monthly maintenance",
+		Kind:       models.FeeKindMaintenance,
+		Method:     models.FeeFlat,
+		Currency:   models.DefaultCurrency,
+		FlatAmount: models.NewMoney(5),
+	}
+
+	rule, err := service.CreateRule(ctx, request)
+	require.NoError(t, err)
+	assert.True(t, rule.Active)
+
+	request.Method = models.FeePercentage
+	_, err = service.CreateRule(ctx, request)
+	assert.ErrorIs(t, err, models.ErrInvalidFeeRule)
+	mockFeeRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestFeeService_DeactivateRule(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	mockFeeRepo := new(mocks.MockFeeRuleRepository)
+	mockFeeRepo.On("Update", ctx, id).Return(models.FeeRule{ID: id, Active: true}, nil)
+	service := NewFeeService(mockFeeRepo, nil, nil)
+
+	rule, err := service.DeactivateRule(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, rule.Active)
+}
+
+func TestFeeService_ChargeMaintenance(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.April, 1, 3, 0, 0, 0, time.UTC)
+	opened := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	charged := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Tier: models.TierStandard, Status: models.AccountActive, CreatedAt: opened}
+	premium := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Tier: models.TierPremium, Status: models.AccountActive, CreatedAt: opened}
+	closed := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Tier: models.TierStandard, Status: models.AccountClosed, CreatedAt: opened}
+	recent := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Tier: models.TierStandard, Status: models.AccountActive, CreatedAt: now}
+	rule := models.FeeRule{
+		ID:         uuid.New(),
+		Kind:       models.FeeKindMaintenance,
+		Method:     models.FeeFlat,
+		Tier:       models.TierStandard,
+		Currency:   models.DefaultCurrency,
+		FlatAmount: models.NewMoney(5),
+		Active:     true,
+	}
+	feeID := models.MaintenanceFeeID(charged.ID, "2025-03")
+
+	setup := func() (*FeeService, *mocks.MockTransactionRepository) {
+		mockFeeRepo := new(mocks.MockFeeRuleRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockFeeRepo.On("GetActive", ctx, models.FeeKindMaintenance).Return([]models.FeeRule{rule}, nil)
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{charged, premium, closed, recent}, nil)
+		mockAccountRepo.On("GetByID", ctx, charged.ID).Return(charged, nil)
+		transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+		return NewFeeService(mockFeeRepo, mockAccountRepo, transactions), mockTransactionRepo
+	}
+
+	t.Run("Charges The Ended Month", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		mockTransactionRepo.On("GetByID", ctx, feeID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.ID == feeID && tx.Type == models.FEE && tx.Amount == models.NewMoney(5) &&
+				tx.Fee != nil && tx.Fee.RuleID == rule.ID && tx.Fee.Period == "2025-03" && tx.Outbox != nil
+		})).Return(nil).Once()
+
+		count, err := service.ChargeMaintenance(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Already Charged", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		mockTransactionRepo.On("GetByID", ctx, feeID).Return(&models.Transaction{ID: feeID}, nil)
+
+		count, err := service.ChargeMaintenance(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
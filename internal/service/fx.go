@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+)
+
+type FXService struct {
+	fxRateRepo FXRateRepository
+}
+
+type FXRateRepository interface {
+	Create(ctx context.Context, rate *models.FXRate) error
+	GetEffective(ctx context.Context, base, quote models.Currency, at time.Time) (models.FXRate, error)
+	GetAll(ctx context.Context) ([]models.FXRate, error)
+}
+
+func NewFXService(fxRateRepo FXRateRepository) *FXService {
+	return &FXService{fxRateRepo: fxRateRepo}
+}
+
+func (s *FXService) CreateRate(ctx context.Context, request models.FXRateCreate, source string) (*models.FXRate, error) {
+	base, err := models.ParseCurrency(request.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := models.ParseCurrency(request.QuoteCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if base == quote {
+		return nil, errors.New("base and quote currencies must differ")
+	}
+	if request.Rate <= 0 {
+		return nil, errors.New("rate must be greater than 0")
+	}
+	if request.SpreadBps < 0 || request.SpreadBps >= 10000 {
+		return nil, errors.New("spread must be between 0 and 9999 basis points")
+	}
+	if request.EffectiveAt.IsZero() {
+		return nil, errors.New("effective time is required")
+	}
+
+	rate := &models.FXRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          request.Rate,
+		SpreadBps:     request.SpreadBps,
+		EffectiveAt:   request.EffectiveAt.UTC(),
+		Source:        source,
+	}
+	if err := s.fxRateRepo.Create(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *FXService) GetRates(ctx context.Context) ([]models.FXRate, error) {
+	return s.fxRateRepo.GetAll(ctx)
+}
+
+// LoadFile loads a JSON array of rates from a local file. Rates that were
+// already loaded are left untouched, so the same file can be loaded on every
+// start.
+func (s *FXService) LoadFile(ctx context.Context, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read FX rates file: %w", err)
+	}
+
+	var requests []models.FXRateCreate
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return 0, fmt.Errorf("failed to parse FX rates file: %w", err)
+	}
+
+	for i, request := range requests {
+		_, err := s.CreateRate(ctx, request, "file:"+path)
+		if err != nil && !errors.Is(err, models.ErrFXRateExists) {
+			return i, fmt.Errorf("rate %d in %s: %w", i, path, err)
+		}
+	}
+	return len(requests), nil
+}
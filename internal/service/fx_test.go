@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFXService_CreateRate(t *testing.T) {
+	ctx := context.Background()
+	effectiveAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockFXRateRepository)
+		service := NewFXService(mockRepo)
+
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(rate *models.FXRate) bool {
+			return rate.BaseCurrency == "USD" && rate.QuoteCurrency == "EUR" && rate.Source == "admin"
+		})).Return(nil)
+
+		rate, err := service.CreateRate(ctx, models.FXRateCreate{
+			BaseCurrency:  "usd",
+			QuoteCurrency: "EUR",
+			Rate:          models.Rate(90000000),
+			SpreadBps:     25,
+			EffectiveAt:   effectiveAt,
+		}, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, 25, rate.SpreadBps)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Validation Errors", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request models.FXRateCreate
+		}{
+			{name: "Invalid Currency", request: models.FXRateCreate{BaseCurrency: "XXY", QuoteCurrency: "EUR", Rate: 1, EffectiveAt: effectiveAt}},
+			{name: "Same Currency", request: models.FXRateCreate{BaseCurrency: "EUR", QuoteCurrency: "EUR", Rate: 1, EffectiveAt: effectiveAt}},
+			{name: "Zero Rate", request: models.FXRateCreate{BaseCurrency: "USD", QuoteCurrency: "EUR", EffectiveAt: effectiveAt}},
+			{name: "Spread Too Large", request: models.FXRateCreate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 1, SpreadBps: 10000, EffectiveAt: effectiveAt}},
+			{name: "Missing Effective Time", request: models.FXRateCreate{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockRepo := new(mocks.MockFXRateRepository)
+				service := NewFXService(mockRepo)
+
+				_, err := service.CreateRate(ctx, tt.request, "admin")
+				assert.Error(t, err)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestFXService_LoadFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"baseCurrency": "USD", "quoteCurrency": "EUR", "rate": 0.92, "effectiveAt": "2026-01-01T00:00:00Z"},
+		{"baseCurrency": "EUR", "quoteCurrency": "USD", "rate": "1.0869", "spreadBps": 15, "effectiveAt": "2026-01-01T00:00:00Z"}
+	]`), 0o600))
+
+	mockRepo := new(mocks.MockFXRateRepository)
+	service := NewFXService(mockRepo)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(rate *models.FXRate) bool {
+		return rate.Source == "file:"+path
+	})).Return(nil).Twice()
+
+	loaded, err := service.LoadFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+	mockRepo.AssertExpectations(t)
+
+	// Reloading the file leaves the rates already stored alone.
+	mockRepo.ExpectedCalls = nil
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.FXRate")).Return(models.ErrFXRateExists).Twice()
+	loaded, err = service.LoadFile(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+
+	_, err = service.LoadFile(ctx, filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCaptureAmount = errors.New("invalid capture amount")
+
+type HoldService struct {
+	holdRepo        HoldRepository
+	transactionRepo TransactionRepository
+}
+
+type HoldRepository interface {
+	GetByID(ctx context.Context, id string) (models.Hold, error)
+	GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Hold, error)
+	ClaimCapture(ctx context.Context, holdID, captureTransactionID string, now time.Time) error
+	ReleaseCaptureClaim(ctx context.Context, holdID, captureTransactionID string) error
+	Release(ctx context.Context, holdID string, status models.HoldStatus) error
+}
+
+func NewHoldService(holdRepo HoldRepository, transactionRepo TransactionRepository) *HoldService {
+	return &HoldService{
+		holdRepo:        holdRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Capture creates the CAPTURE transaction that settles an authorization's hold.
+// A zero amount captures the whole hold; a smaller amount captures part of it
+// and the rest is released. The hold is claimed for the capture first, so it
+// cannot be captured twice or voided while the worker settles it.
+func (s *HoldService) Capture(ctx context.Context, authorizationID string, amount models.Money) (*models.Transaction, error) {
+	hold, err := s.holdRepo.GetByID(ctx, authorizationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if hold.Status != models.HoldActive || hold.Expired(now) {
+		return nil, models.ErrHoldNotActive
+	}
+
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	if amount < 0 || amount > hold.Amount {
+		return nil, fmt.Errorf("%w: must be between 0 and the held %s", ErrInvalidCaptureAmount, hold.Amount)
+	}
+	if !amount.HasPrecision(hold.Currency.Decimals()) {
+		return nil, fmt.Errorf("%w: more decimal places than %s allows", ErrInvalidCaptureAmount, hold.Currency)
+	}
+
+	capture := &models.Transaction{
+		ID:        uuid.New().String(),
+		Type:      models.CAPTURE,
+		Amount:    amount,
+		Currency:  hold.Currency,
+		AccountID: hold.AccountID.String(),
+		HoldID:    hold.ID,
+		Status:    models.PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Outbox:    models.NewOutbox(now),
+	}
+
+	if err := s.holdRepo.ClaimCapture(ctx, hold.ID, capture.ID, now); err != nil {
+		return nil, err
+	}
+	if err := s.transactionRepo.Create(ctx, capture); err != nil {
+		if releaseErr := s.holdRepo.ReleaseCaptureClaim(ctx, hold.ID, capture.ID); releaseErr != nil {
+			log.Printf("Failed to release capture claim on hold %s: %v", hold.ID, releaseErr)
+		}
+		return nil, err
+	}
+	return capture, nil
+}
+
+// Void releases an authorization's hold without moving any money.
+func (s *HoldService) Void(ctx context.Context, authorizationID string) (models.Hold, error) {
+	if err := s.holdRepo.Release(ctx, authorizationID, models.HoldVoided); err != nil {
+		return models.Hold{}, err
+	}
+	return s.holdRepo.GetByID(ctx, authorizationID)
+}
+
+func (s *HoldService) GetActiveHolds(ctx context.Context, accountID uuid.UUID) ([]models.Hold, error) {
+	return s.holdRepo.GetActiveByAccountID(ctx, accountID)
+}
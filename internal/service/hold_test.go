@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldService_Capture(t *testing.T) {
+	ctx := context.Background()
+
+	activeHold := func() models.Hold {
+		return models.Hold{
+			ID:        uuid.New().String(),
+			AccountID: uuid.New(),
+			Amount:    models.NewMoney(50),
+			Currency:  models.DefaultCurrency,
+			Status:    models.HoldActive,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+	setup := func() (*HoldService, *mocks.MockHoldRepository, *mocks.MockTransactionRepository) {
+		mockHoldRepo := new(mocks.MockHoldRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		return NewHoldService(mockHoldRepo, mockTransactionRepo), mockHoldRepo, mockTransactionRepo
+	}
+
+	t.Run("Full Capture", func(t *testing.T) {
+		service, mockHoldRepo, mockTransactionRepo := setup()
+		hold := activeHold()
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+		mockHoldRepo.On("ClaimCapture", ctx, hold.ID, mock.Anything, mock.Anything).Return(nil)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.Type == models.CAPTURE && tx.HoldID == hold.ID && tx.Outbox != nil
+		})).Return(nil)
+
+		capture, err := service.Capture(ctx, hold.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, hold.Amount, capture.Amount)
+		assert.Equal(t, hold.AccountID.String(), capture.AccountID)
+		assert.Equal(t, models.PENDING, capture.Status)
+		mockHoldRepo.AssertCalled(t, "ClaimCapture", ctx, hold.ID, capture.ID, mock.Anything)
+	})
+
+	t.Run("Partial Capture", func(t *testing.T) {
+		service, mockHoldRepo, mockTransactionRepo := setup()
+		hold := activeHold()
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+		mockHoldRepo.On("ClaimCapture", ctx, hold.ID, mock.Anything, mock.Anything).Return(nil)
+		mockTransactionRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		capture, err := service.Capture(ctx, hold.ID, models.NewMoney(30))
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(30), capture.Amount)
+	})
+
+	t.Run("More Than Held", func(t *testing.T) {
+		service, mockHoldRepo, _ := setup()
+		hold := activeHold()
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+		_, err := service.Capture(ctx, hold.ID, models.NewMoney(51))
+		assert.ErrorIs(t, err, ErrInvalidCaptureAmount)
+		mockHoldRepo.AssertNotCalled(t, "ClaimCapture", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Hold Not Active", func(t *testing.T) {
+		service, mockHoldRepo, _ := setup()
+		hold := activeHold()
+		hold.Status = models.HoldVoided
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+		_, err := service.Capture(ctx, hold.ID, 0)
+		assert.ErrorIs(t, err, models.ErrHoldNotActive)
+	})
+
+	t.Run("Hold Expired", func(t *testing.T) {
+		service, mockHoldRepo, _ := setup()
+		hold := activeHold()
+		hold.ExpiresAt = time.Now().Add(-time.Minute)
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+		_, err := service.Capture(ctx, hold.ID, 0)
+		assert.ErrorIs(t, err, models.ErrHoldNotActive)
+	})
+
+	t.Run("Failed Create Releases Claim", func(t *testing.T) {
+		service, mockHoldRepo, mockTransactionRepo := setup()
+		hold := activeHold()
+
+		mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+		mockHoldRepo.On("ClaimCapture", ctx, hold.ID, mock.Anything, mock.Anything).Return(nil)
+		mockTransactionRepo.On("Create", ctx, mock.Anything).Return(assert.AnError)
+		mockHoldRepo.On("ReleaseCaptureClaim", ctx, hold.ID, mock.Anything).Return(nil)
+
+		_, err := service.Capture(ctx, hold.ID, 0)
+		assert.ErrorIs(t, err, assert.AnError)
+		mockHoldRepo.AssertExpectations(t)
+	})
+}
+
+func TestHoldService_Void(t *testing.T) {
+	ctx := context.Background()
+	holdID := uuid.New().String()
+
+	t.Run("Success", func(t *testing.T) {
+		mockHoldRepo := new(mocks.MockHoldRepository)
+		service := NewHoldService(mockHoldRepo, new(mocks.MockTransactionRepository))
+
+		mockHoldRepo.On("Release", ctx, holdID, models.HoldVoided).Return(nil)
+		mockHoldRepo.On("GetByID", ctx, holdID).Return(models.Hold{ID: holdID, Status: models.HoldVoided}, nil)
+
+		hold, err := service.Void(ctx, holdID)
+		require.NoError(t, err)
+		assert.Equal(t, models.HoldVoided, hold.Status)
+	})
+
+	t.Run("Not Active", func(t *testing.T) {
+		mockHoldRepo := new(mocks.MockHoldRepository)
+		service := NewHoldService(mockHoldRepo, new(mocks.MockTransactionRepository))
+
+		mockHoldRepo.On("Release", ctx, holdID, models.HoldVoided).Return(models.ErrHoldNotActive)
+
+		_, err := service.Void(ctx, holdID)
+		assert.ErrorIs(t, err, models.ErrHoldNotActive)
+	})
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type InterestService struct {
+	interestRepo InterestRepository
+	accountRepo  AccountRepository
+	dayRepo      BusinessDayRepository
+	snapshotRepo BalanceSnapshotRepository
+	transactions TransactionCreator
+}
+
+type InterestRepository interface {
+	GetConfig(ctx context.Context, scope string) (models.InterestConfig, error)
+	SaveConfig(ctx context.Context, config *models.InterestConfig) error
+	DeleteConfig(ctx context.Context, scope string) error
+	Accrue(ctx context.Context, accrual *models.InterestAccrual) (bool, error)
+	GetLastAccrualDate(ctx context.Context) (time.Time, error)
+	GetUnposted(ctx context.Context, accountID uuid.UUID) ([]models.InterestAccrual, error)
+	GetAccountsWithUnposted(ctx context.Context) ([]uuid.UUID, error)
+	MarkPosted(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string, carried models.Money) error
+	ReleasePosting(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string) error
+}
+
+func NewInterestService(interestRepo InterestRepository, accountRepo AccountRepository, dayRepo BusinessDayRepository, snapshotRepo BalanceSnapshotRepository, transactions TransactionCreator) *InterestService {
+	return &InterestService{
+		interestRepo: interestRepo,
+		accountRepo:  accountRepo,
+		dayRepo:      dayRepo,
+		snapshotRepo: snapshotRepo,
+		transactions: transactions,
+	}
+}
+
+// EffectiveConfig returns the interest terms that apply to the account: its
+// own if it has any, otherwise its product's. It returns
+// ErrInterestConfigNotFound if the account earns no interest.
+func (s *InterestService) EffectiveConfig(ctx context.Context, account *models.Account) (models.InterestConfig, error) {
+	config, err := s.interestRepo.GetConfig(ctx, models.AccountInterestScope(account.ID))
+	if !errors.Is(err, models.ErrInterestConfigNotFound) || account.Product == "" {
+		return config, err
+	}
+	return s.interestRepo.GetConfig(ctx, models.ProductInterestScope(account.Product))
+}
+
+func (s *InterestService) GetStatus(ctx context.Context, accountID uuid.UUID) (models.InterestStatus, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return models.InterestStatus{}, err
+	}
+	status := models.InterestStatus{AccountID: accountID}
+
+	config, err := s.EffectiveConfig(ctx, &account)
+	if err == nil {
+		status.Config = &config
+	} else if !errors.Is(err, models.ErrInterestConfigNotFound) {
+		return models.InterestStatus{}, err
+	}
+
+	if status.Accruals, err = s.interestRepo.GetUnposted(ctx, accountID); err != nil {
+		return models.InterestStatus{}, err
+	}
+	for _, accrual := range status.Accruals {
+		status.AccruedInterest += accrual.Amount
+	}
+	return status, nil
+}
+
+func (s *InterestService) SetAccountConfig(ctx context.Context, accountID uuid.UUID, update models.InterestConfigUpdate) (models.InterestConfig, error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return models.InterestConfig{}, err
+	}
+	return s.saveConfig(ctx, models.AccountInterestScope(accountID), update)
+}
+
+func (s *InterestService) ClearAccountConfig(ctx context.Context, accountID uuid.UUID) error {
+	return s.interestRepo.DeleteConfig(ctx, models.AccountInterestScope(accountID))
+}
+
+func (s *InterestService) GetProductConfig(ctx context.Context, product string) (models.InterestConfig, error) {
+	product, err := models.ParseProduct(product)
+	if err != nil {
+		return models.InterestConfig{}, err
+	}
+	return s.interestRepo.GetConfig(ctx, models.ProductInterestScope(product))
+}
+
+func (s *InterestService) SetProductConfig(ctx context.Context, product string, update models.InterestConfigUpdate) (models.InterestConfig, error) {
+	product, err := models.ParseProduct(product)
+	if err != nil {
+		return models.InterestConfig{}, err
+	}
+	return s.saveConfig(ctx, models.ProductInterestScope(product), update)
+}
+
+func (s *InterestService) saveConfig(ctx context.Context, scope string, update models.InterestConfigUpdate) (models.InterestConfig, error) {
+	config := models.InterestConfig{
+		Scope:       scope,
+		AnnualRate:  update.AnnualRate,
+		DayCount:    update.DayCount,
+		Compounding: update.Compounding,
+	}
+	if err := config.Validate(); err != nil {
+		return models.InterestConfig{}, err
+	}
+	if err := s.interestRepo.SaveConfig(ctx, &config); err != nil {
+		return models.InterestConfig{}, err
+	}
+	return config, nil
+}
+
+// AccrueDue accrues every closed business day not accrued yet, oldest first:
+// from the day after the last day accrued, or from the latest closed day if
+// none has been, up to the latest closed day. Days missed while the job was
+// not running, or closed late, are caught up this way. It returns how many
+// accruals it recorded.
+func (s *InterestService) AccrueDue(ctx context.Context) (int, error) {
+	latest, err := s.dayRepo.GetLatest(ctx)
+	if err == nil && latest.Status != models.BusinessDayClosed {
+		// Days are closed in order, so the one before is the latest closed.
+		latest, err = s.dayRepo.Get(ctx, latest.Date.AddDate(0, 0, -1))
+	}
+	if errors.Is(err, models.ErrBusinessDayNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	next := latest.Date
+	last, err := s.interestRepo.GetLastAccrualDate(ctx)
+	if err == nil {
+		next = last.AddDate(0, 0, 1)
+	} else if !errors.Is(err, models.ErrInterestAccrualNotFound) {
+		return 0, err
+	}
+
+	accrued := 0
+	for day := next; !day.After(latest.Date); day = day.AddDate(0, 0, 1) {
+		n, err := s.AccrueDay(ctx, day)
+		accrued += n
+		if err != nil {
+			return accrued, err
+		}
+	}
+	return accrued, nil
+}
+
+// AccrueDay records the interest every account earning interest made on the
+// UTC day containing day, from the closing balance snapshotted when the
+// business day was closed, and returns how many accruals it recorded. It
+// returns ErrBusinessDayNotClosed until the day has been closed. Days already
+// accrued are skipped, so it can be rerun safely.
+func (s *InterestService) AccrueDay(ctx context.Context, day time.Time) (int, error) {
+	start := models.BusinessDate(day)
+	businessDay, err := s.dayRepo.Get(ctx, start)
+	if err != nil && !errors.Is(err, models.ErrBusinessDayNotFound) {
+		return 0, err
+	}
+	if err != nil || businessDay.Status != models.BusinessDayClosed {
+		return 0, fmt.Errorf("%w: %s", models.ErrBusinessDayNotClosed, start.Format(time.DateOnly))
+	}
+	end := businessDay.End()
+
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	accrued := 0
+	for i := range accounts {
+		account := &accounts[i]
+		if account.Status == models.AccountClosed {
+			continue
+		}
+		config, err := s.EffectiveConfig(ctx, account)
+		if errors.Is(err, models.ErrInterestConfigNotFound) {
+			continue
+		}
+		if err != nil {
+			return accrued, err
+		}
+
+		// Accounts opened after the day have no snapshot of it.
+		snapshot, err := s.snapshotRepo.GetLatest(ctx, account.ID, end)
+		if errors.Is(err, models.ErrBalanceSnapshotNotFound) {
+			continue
+		}
+		if err != nil {
+			return accrued, err
+		}
+		if !snapshot.TakenAt.Equal(end) {
+			continue
+		}
+		balance := snapshot.Balance
+		if config.Compounding == models.CompoundDaily {
+			unposted, err := s.interestRepo.GetUnposted(ctx, account.ID)
+			if err != nil {
+				return accrued, err
+			}
+			for _, accrual := range unposted {
+				if accrual.Date.Before(start) {
+					balance += accrual.Amount
+				}
+			}
+		}
+
+		amount := config.DailyInterest(balance, start)
+		if amount <= 0 {
+			continue
+		}
+		inserted, err := s.interestRepo.Accrue(ctx, &models.InterestAccrual{
+			AccountID:  account.ID,
+			Date:       start,
+			Balance:    balance,
+			AnnualRate: config.AnnualRate,
+			Amount:     amount,
+		})
+		if err != nil {
+			return accrued, err
+		}
+		if inserted {
+			accrued++
+		}
+	}
+	return accrued, nil
+}
+
+// PostDue pays each account the interest it accrued in posting periods that
+// have ended by now, as a DEPOSIT processed by the worker, and returns how
+// many it posted. Amounts are rounded to the currency's minor unit, and what
+// rounding leaves over is carried into the next period, as is an amount that
+// rounds to zero.
+func (s *InterestService) PostDue(ctx context.Context, now time.Time) (int, error) {
+	accountIDs, err := s.interestRepo.GetAccountsWithUnposted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	posted := 0
+	for _, accountID := range accountIDs {
+		ok, err := s.post(ctx, accountID, now)
+		if err != nil {
+			log.Printf("Failed to post interest to account %s: %v", accountID, err)
+			continue
+		}
+		if ok {
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+func (s *InterestService) post(ctx context.Context, accountID uuid.UUID, now time.Time) (bool, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	compounding := models.CompoundMonthly
+	config, err := s.EffectiveConfig(ctx, &account)
+	if err == nil {
+		compounding = config.Compounding
+	} else if !errors.Is(err, models.ErrInterestConfigNotFound) {
+		return false, err
+	}
+	periodEnd := compounding.PeriodStart(now)
+
+	unposted, err := s.interestRepo.GetUnposted(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	var total models.Money
+	count := 0
+	for _, accrual := range unposted {
+		if accrual.Date.Before(periodEnd) {
+			total += accrual.Amount
+			count++
+		}
+	}
+	amount := total.Round(account.Currency.Decimals())
+	if count == 0 || amount <= 0 {
+		return false, nil
+	}
+
+	// A posting interrupted after creating its deposit finds it here and only
+	// marks the accruals it paid. The accruals of a deposit that failed were
+	// released by the worker, and are paid by the next attempt.
+	for attempt := 0; ; attempt++ {
+		txID := models.InterestPostingID(accountID, periodEnd, attempt)
+		deposit, err := s.transactions.GetByID(ctx, txID)
+		if errors.Is(err, models.ErrTransactionNotFound) {
+			deposit = &models.Transaction{
+				ID:        txID,
+				Type:      models.DEPOSIT,
+				Amount:    amount,
+				Currency:  account.Currency,
+				AccountID: accountID.String(),
+				Status:    models.PENDING,
+				CreatedAt: now,
+				UpdatedAt: now,
+				Interest:  &models.InterestPosting{PeriodEnd: periodEnd, Accruals: count},
+			}
+			if err := s.transactions.Create(ctx, deposit); err != nil {
+				return false, fmt.Errorf("failed to create interest deposit: %w", err)
+			}
+		} else if err != nil {
+			return false, err
+		}
+		if deposit.Status == models.FAILED {
+			continue
+		}
+
+		if err := s.interestRepo.MarkPosted(ctx, accountID, periodEnd, txID, total-deposit.Amount); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	queue_mocks "github.com/RajVerma97/golang-banking-ledger/pkg/queue/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestService_EffectiveConfig(t *testing.T) {
+	ctx := context.Background()
+	account := models.Account{ID: uuid.New(), Product: "SAVINGS"}
+	productConfig := models.InterestConfig{Scope: models.ProductInterestScope("SAVINGS"), AnnualRate: models.Rate(4_000_000)}
+
+	t.Run("Account Overrides Product", func(t *testing.T) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		accountConfig := models.InterestConfig{Scope: models.AccountInterestScope(account.ID), AnnualRate: models.Rate(5_000_000)}
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(account.ID)).Return(accountConfig, nil)
+		service := NewInterestService(mockInterestRepo, nil, nil, nil, nil)
+
+		config, err := service.EffectiveConfig(ctx, &account)
+		require.NoError(t, err)
+		assert.Equal(t, accountConfig, config)
+		mockInterestRepo.AssertNotCalled(t, "GetConfig", ctx, productConfig.Scope)
+	})
+
+	t.Run("Falls Back To Product", func(t *testing.T) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(account.ID)).Return(models.InterestConfig{}, models.ErrInterestConfigNotFound)
+		mockInterestRepo.On("GetConfig", ctx, productConfig.Scope).Return(productConfig, nil)
+		service := NewInterestService(mockInterestRepo, nil, nil, nil, nil)
+
+		config, err := service.EffectiveConfig(ctx, &account)
+		require.NoError(t, err)
+		assert.Equal(t, productConfig, config)
+	})
+
+	t.Run("No Product", func(t *testing.T) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		noProduct := models.Account{ID: uuid.New()}
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(noProduct.ID)).Return(models.InterestConfig{}, models.ErrInterestConfigNotFound)
+		service := NewInterestService(mockInterestRepo, nil, nil, nil, nil)
+
+		_, err := service.EffectiveConfig(ctx, &noProduct)
+		assert.ErrorIs(t, err, models.ErrInterestConfigNotFound)
+	})
+}
+
+func TestInterestService_SetProductConfig(t *testing.T) {
+	ctx := context.Background()
+	mockInterestRepo := new(mocks.MockInterestRepository)
+	mockInterestRepo.On("SaveConfig", ctx, mock.Anything).Return(nil)
+	service := NewInterestService(mockInterestRepo, nil, nil, nil, nil)
+	update := models.InterestConfigUpdate{AnnualRate: models.Rate(4_000_000), DayCount: models.DayCountActual365, Compounding: models.CompoundMonthly}
+
+	config, err := service.SetProductConfig(ctx, "savings", update)
+	require.NoError(t, err)
+	assert.Equal(t, "product:SAVINGS", config.Scope)
+
+	update.Compounding = "HOURLY"
+	_, err = service.SetProductConfig(ctx, "savings", update)
+	assert.ErrorIs(t, err, models.ErrInvalidInterestConfig)
+	mockInterestRepo.AssertNumberOfCalls(t, "SaveConfig", 1)
+}
+
+func TestInterestService_AccrueDay(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2025, time.March, 10, 18, 0, 0, 0, time.UTC)
+	start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	earning := models.Account{ID: uuid.New(), Status: models.AccountActive}
+	closed := models.Account{ID: uuid.New(), Status: models.AccountClosed}
+	noInterest := models.Account{ID: uuid.New(), Status: models.AccountActive}
+	opened := models.Account{ID: uuid.New(), Status: models.AccountActive}
+	config := func(compounding models.Compounding) models.InterestConfig {
+		return models.InterestConfig{AnnualRate: models.Rate(3_650_000), DayCount: models.DayCountActual365, Compounding: compounding}
+	}
+
+	newService := func(status models.BusinessDayStatus) (*InterestService, *mocks.MockInterestRepository, *mocks.MockBalanceSnapshotRepository) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockDayRepo := new(mocks.MockBusinessDayRepository)
+		mockSnapshotRepo := new(mocks.MockBalanceSnapshotRepository)
+		if status == "" {
+			mockDayRepo.On("Get", ctx, start).Return(models.BusinessDay{}, models.ErrBusinessDayNotFound)
+		} else {
+			mockDayRepo.On("Get", ctx, start).Return(models.BusinessDay{Date: start, Status: status}, nil)
+		}
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{earning, closed, noInterest, opened}, nil)
+		return NewInterestService(mockInterestRepo, mockAccountRepo, mockDayRepo, mockSnapshotRepo, nil), mockInterestRepo, mockSnapshotRepo
+	}
+	setup := func(compounding models.Compounding) (*InterestService, *mocks.MockInterestRepository) {
+		service, mockInterestRepo, mockSnapshotRepo := newService(models.BusinessDayClosed)
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(earning.ID)).Return(config(compounding), nil)
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(noInterest.ID)).Return(models.InterestConfig{}, models.ErrInterestConfigNotFound)
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(opened.ID)).Return(config(compounding), nil)
+		mockSnapshotRepo.On("GetLatest", ctx, earning.ID, end).Return(models.BalanceSnapshot{AccountID: earning.ID, TakenAt: end, Balance: models.NewMoney(10000)}, nil)
+		// Opened after the day, so the day's close did not snapshot it.
+		mockSnapshotRepo.On("GetLatest", ctx, opened.ID, end).Return(models.BalanceSnapshot{}, models.ErrBalanceSnapshotNotFound)
+		return service, mockInterestRepo
+	}
+
+	t.Run("Waits For The Day To Close", func(t *testing.T) {
+		for _, status := range []models.BusinessDayStatus{"", models.BusinessDayClosing} {
+			service, mockInterestRepo, _ := newService(status)
+
+			_, err := service.AccrueDay(ctx, day)
+			assert.ErrorIs(t, err, models.ErrBusinessDayNotClosed)
+			mockInterestRepo.AssertNotCalled(t, "Accrue", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Accrues From The Day's Snapshot", func(t *testing.T) {
+		service, mockInterestRepo := setup(models.CompoundMonthly)
+		mockInterestRepo.On("Accrue", ctx, mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+			return accrual.AccountID == earning.ID && accrual.Date.Equal(start) &&
+				accrual.Balance == models.NewMoney(10000) && accrual.Amount == models.NewMoney(1)
+		})).Return(true, nil).Once()
+
+		accrued, err := service.AccrueDay(ctx, day)
+		require.NoError(t, err)
+		assert.Equal(t, 1, accrued)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rerun Accrues Nothing New", func(t *testing.T) {
+		service, mockInterestRepo := setup(models.CompoundMonthly)
+		mockInterestRepo.On("Accrue", ctx, mock.Anything).Return(false, nil)
+
+		accrued, err := service.AccrueDay(ctx, day)
+		require.NoError(t, err)
+		assert.Equal(t, 0, accrued)
+	})
+
+	t.Run("Daily Compounding Earns On Unposted Interest", func(t *testing.T) {
+		service, mockInterestRepo := setup(models.CompoundDaily)
+		mockInterestRepo.On("GetUnposted", ctx, earning.ID).Return([]models.InterestAccrual{
+			{AccountID: earning.ID, Date: start.AddDate(0, 0, -1), Amount: models.NewMoney(10000)},
+			{AccountID: earning.ID, Date: start, Amount: models.NewMoney(1)},
+		}, nil)
+		mockInterestRepo.On("Accrue", ctx, mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+			return accrual.Balance == models.NewMoney(20000) && accrual.Amount == models.NewMoney(2)
+		})).Return(true, nil).Once()
+
+		_, err := service.AccrueDay(ctx, day)
+		require.NoError(t, err)
+		mockInterestRepo.AssertExpectations(t)
+	})
+}
+
+func TestInterestService_AccrueDue(t *testing.T) {
+	ctx := context.Background()
+	latest := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	// No account earns interest, so each day accrued only looks up its
+	// business day.
+	setup := func(latestDay models.BusinessDay) (*InterestService, *mocks.MockInterestRepository, *mocks.MockBusinessDayRepository) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockDayRepo := new(mocks.MockBusinessDayRepository)
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{}, nil)
+		mockDayRepo.On("GetLatest", ctx).Return(latestDay, nil)
+		for date := latest.AddDate(0, 0, -5); !date.After(latest); date = date.AddDate(0, 0, 1) {
+			mockDayRepo.On("Get", ctx, date).Return(models.BusinessDay{Date: date, Status: models.BusinessDayClosed}, nil)
+		}
+		return NewInterestService(mockInterestRepo, mockAccountRepo, mockDayRepo, nil, nil), mockInterestRepo, mockDayRepo
+	}
+
+	t.Run("Catches Up Missed Days", func(t *testing.T) {
+		service, mockInterestRepo, mockDayRepo := setup(models.BusinessDay{Date: latest, Status: models.BusinessDayClosed})
+		mockInterestRepo.On("GetLastAccrualDate", ctx).Return(latest.AddDate(0, 0, -3), nil)
+
+		_, err := service.AccrueDue(ctx)
+		require.NoError(t, err)
+		mockDayRepo.AssertNumberOfCalls(t, "Get", 3)
+		for _, date := range []time.Time{latest.AddDate(0, 0, -2), latest.AddDate(0, 0, -1), latest} {
+			mockDayRepo.AssertCalled(t, "Get", ctx, date)
+		}
+	})
+
+	t.Run("Waits For The Latest Day To Close", func(t *testing.T) {
+		service, mockInterestRepo, mockDayRepo := setup(models.BusinessDay{Date: latest, Status: models.BusinessDayClosing})
+		mockInterestRepo.On("GetLastAccrualDate", ctx).Return(latest.AddDate(0, 0, -2), nil)
+
+		_, err := service.AccrueDue(ctx)
+		require.NoError(t, err)
+		mockDayRepo.AssertCalled(t, "Get", ctx, latest.AddDate(0, 0, -1))
+		mockDayRepo.AssertNotCalled(t, "Get", ctx, latest)
+	})
+
+	t.Run("Nothing Accrued Yet", func(t *testing.T) {
+		service, mockInterestRepo, mockDayRepo := setup(models.BusinessDay{Date: latest, Status: models.BusinessDayClosed})
+		mockInterestRepo.On("GetLastAccrualDate", ctx).Return(time.Time{}, models.ErrInterestAccrualNotFound)
+
+		_, err := service.AccrueDue(ctx)
+		require.NoError(t, err)
+		mockDayRepo.AssertNumberOfCalls(t, "Get", 1)
+		mockDayRepo.AssertCalled(t, "Get", ctx, latest)
+	})
+}
+
+func TestInterestService_PostDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.April, 1, 2, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	account := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Status: models.AccountActive}
+	txID := models.InterestPostingID(account.ID, periodEnd, 0)
+
+	setup := func(accruals []models.InterestAccrual) (*InterestService, *mocks.MockInterestRepository, *mocks.MockTransactionRepository) {
+		mockInterestRepo := new(mocks.MockInterestRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo.On("GetByID", ctx, account.ID).Return(account, nil)
+		mockInterestRepo.On("GetAccountsWithUnposted", ctx).Return([]uuid.UUID{account.ID}, nil)
+		mockInterestRepo.On("GetConfig", ctx, models.AccountInterestScope(account.ID)).Return(models.InterestConfig{Compounding: models.CompoundMonthly}, nil)
+		mockInterestRepo.On("GetUnposted", ctx, account.ID).Return(accruals, nil)
+		transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+		return NewInterestService(mockInterestRepo, mockAccountRepo, nil, nil, transactions), mockInterestRepo, mockTransactionRepo
+	}
+
+	t.Run("Posts The Ended Period", func(t *testing.T) {
+		service, mockInterestRepo, mockTransactionRepo := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, 0, -2), Amount: models.Money(6_810)},
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.Money(6_810)},
+			{Date: periodEnd, Amount: models.Money(6_810)},
+		})
+		mockTransactionRepo.On("GetByID", ctx, txID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			// 1.3620 rounded to the cent
+			return tx.ID == txID && tx.Type == models.DEPOSIT && tx.Amount == models.Money(13_600) &&
+				tx.Interest != nil && tx.Interest.Accruals == 2 && tx.Outbox != nil
+		})).Return(nil)
+		// The 0.0020 not paid is carried into the next period.
+		mockInterestRepo.On("MarkPosted", ctx, account.ID, periodEnd, txID, models.Money(20)).Return(nil)
+
+		posted, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, posted)
+		mockTransactionRepo.AssertExpectations(t)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Deposit Already Created", func(t *testing.T) {
+		service, mockInterestRepo, mockTransactionRepo := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.NewMoney(2)},
+		})
+		mockTransactionRepo.On("GetByID", ctx, txID).Return(&models.Transaction{ID: txID, Amount: models.NewMoney(2)}, nil)
+		mockInterestRepo.On("MarkPosted", ctx, account.ID, periodEnd, txID, models.Money(0)).Return(nil)
+
+		_, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Deposit Is Posted Again", func(t *testing.T) {
+		service, mockInterestRepo, mockTransactionRepo := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.NewMoney(2)},
+		})
+		retryID := models.InterestPostingID(account.ID, periodEnd, 1)
+		mockTransactionRepo.On("GetByID", ctx, txID).Return(&models.Transaction{ID: txID, Amount: models.NewMoney(2), Status: models.FAILED}, nil)
+		mockTransactionRepo.On("GetByID", ctx, retryID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.ID == retryID && tx.Amount == models.NewMoney(2)
+		})).Return(nil)
+		mockInterestRepo.On("MarkPosted", ctx, account.ID, periodEnd, retryID, models.Money(0)).Return(nil)
+
+		posted, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, posted)
+		mockTransactionRepo.AssertExpectations(t)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rounding Up Carries A Negative Remainder", func(t *testing.T) {
+		service, mockInterestRepo, mockTransactionRepo := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, 0, -2), Amount: models.Money(6_830)},
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.Money(6_830)},
+		})
+		mockTransactionRepo.On("GetByID", ctx, txID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.Amount == models.Money(13_700)
+		})).Return(nil)
+		mockInterestRepo.On("MarkPosted", ctx, account.ID, periodEnd, txID, models.Money(-40)).Return(nil)
+
+		_, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Carried Remainder Is Posted", func(t *testing.T) {
+		service, mockInterestRepo, mockTransactionRepo := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, -1, 0), Carried: true, Amount: models.Money(60)},
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.Money(6_800)},
+		})
+		mockTransactionRepo.On("GetByID", ctx, txID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.Amount == models.Money(6_900)
+		})).Return(nil)
+		mockInterestRepo.On("MarkPosted", ctx, account.ID, periodEnd, txID, models.Money(-40)).Return(nil)
+
+		_, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		mockInterestRepo.AssertExpectations(t)
+	})
+
+	t.Run("Less Than A Cent Carries Forward", func(t *testing.T) {
+		service, mockInterestRepo, _ := setup([]models.InterestAccrual{
+			{Date: periodEnd.AddDate(0, 0, -1), Amount: models.Money(40)},
+		})
+
+		posted, err := service.PostDue(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, posted)
+		mockInterestRepo.AssertNotCalled(t, "MarkPosted", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type LedgerService struct {
+	ledgerRepo  LedgerRepository
+	accountRepo AccountRepository
+}
+
+type LedgerRepository interface {
+	GetPostings(ctx context.Context, ledgerAccount string) ([]models.Posting, error)
+}
+
+func NewLedgerService(ledgerRepo LedgerRepository, accountRepo AccountRepository) *LedgerService {
+	return &LedgerService{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// GetStatement returns an account's postings and checks the stored balance
+// against the balance proven by those postings.
+func (ls *LedgerService) GetStatement(ctx context.Context, accountID uuid.UUID) (*models.LedgerStatement, error) {
+	account, err := ls.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerAccount := models.CustomerLedgerAccount(accountID)
+	postings, err := ls.ledgerRepo.GetPostings(ctx, ledgerAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	postedBalance := models.PostingsBalance(postings, ledgerAccount)
+	return &models.LedgerStatement{
+		AccountID:     accountID,
+		Currency:      account.Currency,
+		Balance:       account.Balance,
+		PostedBalance: postedBalance,
+		InBalance:     postedBalance == account.Balance,
+		Postings:      postings,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerService_GetStatement(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	ledgerAccount := models.CustomerLedgerAccount(accountID)
+
+	postings := []models.Posting{
+		{LedgerAccount: ledgerAccount, Direction: models.CREDIT, Amount: models.NewMoney(100)},
+		{LedgerAccount: ledgerAccount, Direction: models.DEBIT, Amount: models.NewMoney(30)},
+	}
+
+	t.Run("In Balance", func(t *testing.T) {
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewLedgerService(mockLedgerRepo, mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Balance: models.NewMoney(70)}, nil)
+		mockLedgerRepo.On("GetPostings", ctx, ledgerAccount).Return(postings, nil)
+
+		statement, err := service.GetStatement(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(70), statement.PostedBalance)
+		assert.True(t, statement.InBalance)
+		assert.Len(t, statement.Postings, 2)
+	})
+
+	t.Run("Out Of Balance", func(t *testing.T) {
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewLedgerService(mockLedgerRepo, mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Balance: models.NewMoney(500)}, nil)
+		mockLedgerRepo.On("GetPostings", ctx, ledgerAccount).Return(postings, nil)
+
+		statement, err := service.GetStatement(ctx, accountID)
+		require.NoError(t, err)
+		assert.False(t, statement.InBalance)
+	})
+
+	t.Run("Account Not Found", func(t *testing.T) {
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewLedgerService(mockLedgerRepo, mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{}, assert.AnError)
+
+		_, err := service.GetStatement(ctx, accountID)
+		assert.Error(t, err)
+		mockLedgerRepo.AssertNotCalled(t, "GetPostings")
+	})
+}
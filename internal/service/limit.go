@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type LimitService struct {
+	limitRepo   LimitRepository
+	usageRepo   LimitUsageRepository
+	accountRepo AccountRepository
+}
+
+type LimitRepository interface {
+	Get(ctx context.Context, scope string) (models.TransactionLimits, error)
+	Save(ctx context.Context, limits *models.TransactionLimits) error
+	Delete(ctx context.Context, scope string) error
+}
+
+type LimitUsageRepository interface {
+	GetLimitUsage(ctx context.Context, accountID string, windows models.LimitWindows) (models.LimitUsage, error)
+}
+
+func NewLimitService(limitRepo LimitRepository, usageRepo LimitUsageRepository, accountRepo AccountRepository) *LimitService {
+	return &LimitService{
+		limitRepo:   limitRepo,
+		usageRepo:   usageRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// Check rejects a limited transaction that would take its account over any
+// of its limits, with a *models.LimitExceededError naming the limit. Other
+// transactions are not limited.
+func (s *LimitService) Check(ctx context.Context, account *models.Account, tx *models.Transaction) error {
+	if !tx.Type.Limited() {
+		return nil
+	}
+	limits, err := s.EffectiveLimits(ctx, account)
+	if err != nil {
+		return err
+	}
+	usage, err := s.usageRepo.GetLimitUsage(ctx, account.ID.String(), models.NewLimitWindows(time.Now()))
+	if err != nil {
+		return err
+	}
+	return limits.Check(usage, tx.Amount)
+}
+
+// EffectiveLimits returns the account's own limits if it has any, otherwise
+// those of its tier.
+func (s *LimitService) EffectiveLimits(ctx context.Context, account *models.Account) (models.TransactionLimits, error) {
+	limits, err := s.limitRepo.Get(ctx, models.AccountLimitScope(account.ID))
+	if !errors.Is(err, models.ErrLimitsNotFound) {
+		return limits, err
+	}
+	return s.tierLimits(ctx, account.Tier)
+}
+
+func (s *LimitService) tierLimits(ctx context.Context, tier models.AccountTier) (models.TransactionLimits, error) {
+	if tier == "" {
+		tier = models.TierStandard
+	}
+	limits, err := s.limitRepo.Get(ctx, models.TierLimitScope(tier))
+	if !errors.Is(err, models.ErrLimitsNotFound) {
+		return limits, err
+	}
+	limits = models.DefaultTierLimits[tier]
+	limits.Scope = models.TierLimitScope(tier)
+	return limits, nil
+}
+
+func (s *LimitService) GetStatus(ctx context.Context, accountID uuid.UUID) (models.LimitStatus, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+	limits, err := s.EffectiveLimits(ctx, &account)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+	usage, err := s.usageRepo.GetLimitUsage(ctx, accountID.String(), models.NewLimitWindows(time.Now()))
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+	return models.LimitStatus{AccountID: accountID, Tier: account.Tier, Limits: limits, Usage: usage}, nil
+}
+
+func (s *LimitService) GetTierLimits(ctx context.Context, tier models.AccountTier) (models.TransactionLimits, error) {
+	if !tier.Valid() {
+		return models.TransactionLimits{}, fmt.Errorf("%w: unknown tier %q", models.ErrInvalidLimits, tier)
+	}
+	return s.tierLimits(ctx, tier)
+}
+
+func (s *LimitService) SetTierLimits(ctx context.Context, tier models.AccountTier, limits models.TransactionLimits) (*models.TransactionLimits, error) {
+	if !tier.Valid() {
+		return nil, fmt.Errorf("%w: unknown tier %q", models.ErrInvalidLimits, tier)
+	}
+	limits.Scope = models.TierLimitScope(tier)
+	return s.save(ctx, &limits)
+}
+
+func (s *LimitService) SetAccountLimits(ctx context.Context, accountID uuid.UUID, limits models.TransactionLimits) (*models.TransactionLimits, error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	limits.Scope = models.AccountLimitScope(accountID)
+	return s.save(ctx, &limits)
+}
+
+// ClearAccountLimits returns the account to its tier's limits.
+func (s *LimitService) ClearAccountLimits(ctx context.Context, accountID uuid.UUID) error {
+	return s.limitRepo.Delete(ctx, models.AccountLimitScope(accountID))
+}
+
+func (s *LimitService) save(ctx context.Context, limits *models.TransactionLimits) (*models.TransactionLimits, error) {
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.limitRepo.Save(ctx, limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitService_Check(t *testing.T) {
+	ctx := context.Background()
+	account := &models.Account{ID: uuid.New(), Tier: models.TierStandard, Currency: models.DefaultCurrency}
+	withdrawal := func(amount models.Money) *models.Transaction {
+		return &models.Transaction{Type: models.WITHDRAWL, Amount: amount, AccountID: account.ID.String()}
+	}
+	setup := func() (*LimitService, *mocks.MockLimitRepository, *mocks.MockTransactionRepository) {
+		mockLimitRepo := new(mocks.MockLimitRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		return NewLimitService(mockLimitRepo, mockTransactionRepo, new(mocks.MockAccountRepository)), mockLimitRepo, mockTransactionRepo
+	}
+
+	t.Run("Default Tier Limits", func(t *testing.T) {
+		service, mockLimitRepo, mockTransactionRepo := setup()
+
+		mockLimitRepo.On("Get", ctx, mock.Anything).Return(models.TransactionLimits{}, models.ErrLimitsNotFound)
+		mockTransactionRepo.On("GetLimitUsage", ctx, account.ID.String(), mock.Anything).
+			Return(models.LimitUsage{DailyAmount: models.NewMoney(1900)}, nil)
+
+		assert.NoError(t, service.Check(ctx, account, withdrawal(models.NewMoney(100))))
+
+		err := service.Check(ctx, account, withdrawal(models.NewMoney(101)))
+		var limitErr *models.LimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, models.LimitDailyAmount, limitErr.Code)
+	})
+
+	t.Run("Account Limits Replace Tier", func(t *testing.T) {
+		service, mockLimitRepo, mockTransactionRepo := setup()
+
+		mockLimitRepo.On("Get", ctx, models.AccountLimitScope(account.ID)).
+			Return(models.TransactionLimits{PerMinuteCount: 2}, nil)
+		mockTransactionRepo.On("GetLimitUsage", ctx, account.ID.String(), mock.Anything).
+			Return(models.LimitUsage{MinuteCount: 2}, nil)
+
+		err := service.Check(ctx, account, withdrawal(models.NewMoney(1)))
+		var limitErr *models.LimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, models.LimitVelocity, limitErr.Code)
+		mockLimitRepo.AssertNotCalled(t, "Get", ctx, models.TierLimitScope(models.TierStandard))
+	})
+
+	t.Run("Deposits Are Not Limited", func(t *testing.T) {
+		service, mockLimitRepo, mockTransactionRepo := setup()
+
+		err := service.Check(ctx, account, &models.Transaction{Type: models.DEPOSIT, Amount: models.NewMoney(1e6)})
+		assert.NoError(t, err)
+		mockLimitRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		mockTransactionRepo.AssertNotCalled(t, "GetLimitUsage", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLimitService_SetTierLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockLimitRepo := new(mocks.MockLimitRepository)
+		service := NewLimitService(mockLimitRepo, new(mocks.MockTransactionRepository), new(mocks.MockAccountRepository))
+
+		mockLimitRepo.On("Save", ctx, mock.MatchedBy(func(limits *models.TransactionLimits) bool {
+			return limits.Scope == "tier:PREMIUM" && limits.DailyCount == 5
+		})).Return(nil)
+
+		_, err := service.SetTierLimits(ctx, models.TierPremium, models.TransactionLimits{DailyCount: 5})
+		require.NoError(t, err)
+		mockLimitRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Tier", func(t *testing.T) {
+		service := NewLimitService(new(mocks.MockLimitRepository), new(mocks.MockTransactionRepository), new(mocks.MockAccountRepository))
+
+		_, err := service.SetTierLimits(ctx, "GOLD", models.TransactionLimits{})
+		assert.ErrorIs(t, err, models.ErrInvalidLimits)
+	})
+
+	t.Run("Negative Cap", func(t *testing.T) {
+		service := NewLimitService(new(mocks.MockLimitRepository), new(mocks.MockTransactionRepository), new(mocks.MockAccountRepository))
+
+		_, err := service.SetTierLimits(ctx, models.TierStandard, models.TransactionLimits{DailyCount: -1})
+		assert.ErrorIs(t, err, models.ErrInvalidLimits)
+	})
+}
+
+// withoutLimits returns a limit service with the default tier limits and no
+// usage, which the transactions in these tests stay well within.
+func withoutLimits(mockTransactionRepo *mocks.MockTransactionRepository) *LimitService {
+	return withLimitUsage(mockTransactionRepo, models.LimitUsage{})
+}
+
+func withLimitUsage(mockTransactionRepo *mocks.MockTransactionRepository, usage models.LimitUsage) *LimitService {
+	mockLimitRepo := new(mocks.MockLimitRepository)
+	mockLimitRepo.On("Get", mock.Anything, mock.Anything).Return(models.TransactionLimits{}, models.ErrLimitsNotFound)
+	mockTransactionRepo.On("GetLimitUsage", mock.Anything, mock.Anything, mock.Anything).Return(usage, nil).Maybe()
+	return NewLimitService(mockLimitRepo, mockTransactionRepo, nil)
+}
@@ -33,12 +33,7 @@ func (m *MockAccountService) Create(ctx context.Context, account *models.Account
 	return args.Error(0)
 }
 
-func (m *MockAccountService) Update(ctx context.Context, id uuid.UUID, updates models.AccountUpdate) error {
-	args := m.Called(ctx, id, updates)
-	return args.Error(0)
-}
-
-func (m *MockAccountService) Delete(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockAccountService) Update(ctx context.Context, id uuid.UUID, version int64, updates models.AccountUpdate) error {
+	args := m.Called(ctx, id, version, updates)
 	return args.Error(0)
 }
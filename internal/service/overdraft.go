@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type OverdraftService struct {
+	overdraftRepo OverdraftRepository
+}
+
+type OverdraftRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models.Account, error)
+	SetOverdraftLimit(ctx context.Context, change *models.OverdraftLimitChange) error
+	GetOverdraftLimitChanges(ctx context.Context, id uuid.UUID) ([]models.OverdraftLimitChange, error)
+}
+
+func NewOverdraftService(overdraftRepo OverdraftRepository) *OverdraftService {
+	return &OverdraftService{overdraftRepo: overdraftRepo}
+}
+
+// SetLimit sets the account's overdraft limit on behalf of an operator and
+// returns the audit record of the change.
+func (s *OverdraftService) SetLimit(ctx context.Context, accountID uuid.UUID, request models.OverdraftLimitUpdate, operatorID string) (*models.OverdraftLimitChange, error) {
+	if operatorID == "" {
+		return nil, fmt.Errorf("%w: an operator is required", models.ErrInvalidOverdraftLimit)
+	}
+	if request.Reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", models.ErrInvalidOverdraftLimit)
+	}
+	if request.Limit < 0 {
+		return nil, fmt.Errorf("%w: must not be negative", models.ErrInvalidOverdraftLimit)
+	}
+
+	account, err := s.overdraftRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !request.Limit.HasPrecision(account.Currency.Decimals()) {
+		return nil, fmt.Errorf("%w: more decimal places than %s allows", models.ErrInvalidOverdraftLimit, account.Currency)
+	}
+
+	change := &models.OverdraftLimitChange{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		NewLimit:   request.Limit,
+		Reason:     request.Reason,
+		OperatorID: operatorID,
+	}
+	if err := s.overdraftRepo.SetOverdraftLimit(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *OverdraftService) GetUsage(ctx context.Context, accountID uuid.UUID) (models.OverdraftUsage, error) {
+	account, err := s.overdraftRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return models.OverdraftUsage{}, err
+	}
+	return models.NewOverdraftUsage(&account), nil
+}
+
+func (s *OverdraftService) GetLimitChanges(ctx context.Context, accountID uuid.UUID) ([]models.OverdraftLimitChange, error) {
+	return s.overdraftRepo.GetOverdraftLimitChanges(ctx, accountID)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdraftService_SetLimit(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	request := models.OverdraftLimitUpdate{Limit: models.NewMoney(500), Reason: "approved credit line"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewOverdraftService(mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency}, nil)
+		mockAccountRepo.On("SetOverdraftLimit", ctx, mock.MatchedBy(func(change *models.OverdraftLimitChange) bool {
+			return change.AccountID == accountID && change.NewLimit == request.Limit && change.OperatorID == "ops-1"
+		})).Return(nil)
+
+		change, err := service.SetLimit(ctx, accountID, request, "ops-1")
+		require.NoError(t, err)
+		assert.Equal(t, request.Reason, change.Reason)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing Operator", func(t *testing.T) {
+		service := NewOverdraftService(new(mocks.MockAccountRepository))
+
+		_, err := service.SetLimit(ctx, accountID, request, "")
+		assert.ErrorIs(t, err, models.ErrInvalidOverdraftLimit)
+	})
+
+	t.Run("Negative Limit", func(t *testing.T) {
+		service := NewOverdraftService(new(mocks.MockAccountRepository))
+
+		_, err := service.SetLimit(ctx, accountID, models.OverdraftLimitUpdate{Limit: -1, Reason: "typo"}, "ops-1")
+		assert.ErrorIs(t, err, models.ErrInvalidOverdraftLimit)
+	})
+
+	t.Run("Too Precise For Currency", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewOverdraftService(mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: "JPY"}, nil)
+
+		_, err := service.SetLimit(ctx, accountID, models.OverdraftLimitUpdate{Limit: models.Money(15), Reason: "approved"}, "ops-1")
+		assert.ErrorIs(t, err, models.ErrInvalidOverdraftLimit)
+		mockAccountRepo.AssertNotCalled(t, "SetOverdraftLimit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Account Not Found", func(t *testing.T) {
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewOverdraftService(mockAccountRepo)
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{}, models.ErrAccountNotFound)
+
+		_, err := service.SetLimit(ctx, accountID, request, "ops-1")
+		assert.ErrorIs(t, err, models.ErrAccountNotFound)
+	})
+}
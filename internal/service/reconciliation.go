@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+// DefaultReconciliationReports is how many reports are listed by default.
+const DefaultReconciliationReports = 50
+
+type ReconciliationService struct {
+	reconciliationRepo ReconciliationRepository
+	accountRepo        AccountRepository
+	transactionRepo    ReconciliationTransactionRepository
+	ledgerRepo         ReconciliationLedgerRepository
+	transactions       TransactionCreator
+}
+
+type ReconciliationRepository interface {
+	CreateReport(ctx context.Context, report *models.ReconciliationReport) error
+	GetReport(ctx context.Context, id uuid.UUID) (models.ReconciliationReport, error)
+	GetReports(ctx context.Context, limit int) ([]models.ReconciliationReport, error)
+	RecordCase(ctx context.Context, reportID uuid.UUID, discrepancy *models.Discrepancy, now time.Time) (models.InvestigationCase, error)
+	GetCase(ctx context.Context, id uuid.UUID) (models.InvestigationCase, error)
+	GetCases(ctx context.Context, status models.CaseStatus) ([]models.InvestigationCase, error)
+	UpdateCase(ctx context.Context, id uuid.UUID, change func(*models.InvestigationCase) error) (models.InvestigationCase, error)
+}
+
+type ReconciliationTransactionRepository interface {
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+	GetSettled(ctx context.Context, accountID string, from, until time.Time) ([]models.Transaction, error)
+	IsProcessing(ctx context.Context, accountID string) (bool, error)
+}
+
+type ReconciliationLedgerRepository interface {
+	GetJournal(ctx context.Context, id string) (models.Journal, error)
+	GetJournalIDs(ctx context.Context, ledgerAccount string) ([]string, error)
+	GetBalanceAt(ctx context.Context, ledgerAccount string, at time.Time) (models.Money, error)
+}
+
+func NewReconciliationService(reconciliationRepo ReconciliationRepository, accountRepo AccountRepository, transactionRepo ReconciliationTransactionRepository, ledgerRepo ReconciliationLedgerRepository, transactions TransactionCreator) *ReconciliationService {
+	return &ReconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		ledgerRepo:         ledgerRepo,
+		transactions:       transactions,
+	}
+}
+
+// Reconcile checks every account's stored balance against the balance it was
+// opened with plus its successful transactions, and stores and returns a
+// report of the accounts that do not match. Each of them is corrected with a
+// RECONCILIATION adjustment back to the balance its history adds up to, unless
+// its stored and ledger balances disagree, which an adjustment cannot fix.
+// With openCases, each of them gets an investigation case for an operator to
+// fix by hand instead.
+func (s *ReconciliationService) Reconcile(ctx context.Context, openCases bool, now time.Time) (models.ReconciliationReport, error) {
+	report := models.ReconciliationReport{
+		ID:            uuid.New(),
+		StartedAt:     now,
+		OpenCases:     openCases,
+		Discrepancies: []models.Discrepancy{},
+	}
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return models.ReconciliationReport{}, err
+	}
+
+	for i := range accounts {
+		account := &accounts[i]
+		discrepancy, checked, err := s.check(ctx, account, now)
+		if err != nil {
+			return models.ReconciliationReport{}, fmt.Errorf("failed to reconcile account %s: %w", account.ID, err)
+		}
+		if !checked {
+			report.AccountsSkipped++
+			continue
+		}
+		report.AccountsChecked++
+		if discrepancy == nil {
+			continue
+		}
+		if openCases {
+			investigation, err := s.reconciliationRepo.RecordCase(ctx, report.ID, discrepancy, now)
+			if err != nil {
+				return models.ReconciliationReport{}, fmt.Errorf("failed to open a case for account %s: %w", account.ID, err)
+			}
+			discrepancy.CaseID = &investigation.ID
+		} else if discrepancy.Correctable() && account.Status != models.AccountClosed {
+			correction := discrepancy.Correction(report.ID, account.UpdatedAt, now)
+			if err := s.correct(ctx, correction); err != nil {
+				return models.ReconciliationReport{}, fmt.Errorf("failed to correct account %s: %w", account.ID, err)
+			}
+			discrepancy.AdjustmentID = correction.ID
+		}
+		report.Discrepancies = append(report.Discrepancies, *discrepancy)
+	}
+
+	report.FinishedAt = time.Now()
+	if err := s.reconciliationRepo.CreateReport(ctx, &report); err != nil {
+		return models.ReconciliationReport{}, err
+	}
+	return report, nil
+}
+
+// correct creates the correction unless a run that checked the same balance
+// already has.
+func (s *ReconciliationService) correct(ctx context.Context, correction *models.Transaction) error {
+	_, err := s.transactions.GetByID(ctx, correction.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, models.ErrTransactionNotFound) {
+		return err
+	}
+	if err := s.transactions.Create(ctx, correction); err != nil {
+		// A concurrent run may have created it meanwhile.
+		if _, getErr := s.transactions.GetByID(ctx, correction.ID); getErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ReconciliationService) GetReport(ctx context.Context, id uuid.UUID) (models.ReconciliationReport, error) {
+	return s.reconciliationRepo.GetReport(ctx, id)
+}
+
+func (s *ReconciliationService) GetReports(ctx context.Context) ([]models.ReconciliationReport, error) {
+	return s.reconciliationRepo.GetReports(ctx, DefaultReconciliationReports)
+}
+
+func (s *ReconciliationService) GetCase(ctx context.Context, id uuid.UUID) (models.InvestigationCase, error) {
+	return s.reconciliationRepo.GetCase(ctx, id)
+}
+
+// GetCases returns the investigation cases with the given status, or every
+// case if status is empty.
+func (s *ReconciliationService) GetCases(ctx context.Context, status models.CaseStatus) ([]models.InvestigationCase, error) {
+	if status != "" && status != models.CaseOpen && status != models.CaseResolved {
+		return nil, fmt.Errorf("%w: unknown case status %q", models.ErrInvalidCaseResolution, status)
+	}
+	return s.reconciliationRepo.GetCases(ctx, status)
+}
+
+// ResolveCase closes a case once an operator has fixed the account by hand.
+func (s *ReconciliationService) ResolveCase(ctx context.Context, id uuid.UUID, resolution models.CaseResolution, now time.Time) (models.InvestigationCase, error) {
+	return s.reconciliationRepo.UpdateCase(ctx, id, func(investigation *models.InvestigationCase) error {
+		return investigation.Resolve(resolution, now)
+	})
+}
+
+// check works out the account's expected balance and returns a discrepancy if
+// its stored or ledger balance differs. It reports false if the account could
+// not be checked because it has transactions in flight or changed meanwhile.
+func (s *ReconciliationService) check(ctx context.Context, account *models.Account, now time.Time) (*models.Discrepancy, bool, error) {
+	processing, err := s.transactionRepo.IsProcessing(ctx, account.ID.String())
+	if err != nil || processing {
+		return nil, false, err
+	}
+
+	expected, err := openedWith(ctx, s.ledgerRepo, account.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	settled, err := settledTransactions(ctx, s.transactionRepo, account.ID, time.Time{}, now)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, tx := range settled {
+		if !models.IsReconciliationAdjustment(tx.Transaction) {
+			expected += tx.Change
+		}
+	}
+	ledgerAccount := models.CustomerLedgerAccount(account.ID)
+	ledger, err := s.ledgerRepo.GetBalanceAt(ctx, ledgerAccount, now)
+	if err != nil {
+		return nil, false, err
+	}
+	if expected == account.Balance && ledger == account.Balance {
+		return nil, true, nil
+	}
+
+	// A transaction processed while the account was being checked is not a
+	// discrepancy; the next run will check it.
+	current, err := s.accountRepo.GetByID(ctx, account.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if current.Balance != account.Balance || !current.UpdatedAt.Equal(account.UpdatedAt) {
+		return nil, false, nil
+	}
+
+	involved, err := s.involved(ctx, account.ID, settled)
+	if err != nil {
+		return nil, false, err
+	}
+	return &models.Discrepancy{
+		AccountID:    account.ID,
+		Currency:     account.Currency,
+		Expected:     expected,
+		Actual:       account.Balance,
+		Ledger:       ledger,
+		Difference:   account.Balance - expected,
+		Transactions: involved,
+	}, true, nil
+}
+
+// involved lists the transactions the transaction store and the ledger
+// disagree about for the account: journals posted to it for transactions that
+// did not succeed, and successful transactions that never posted. Each one's
+// amount is what it changed, or should have changed, the balance by.
+func (s *ReconciliationService) involved(ctx context.Context, accountID uuid.UUID, settled []models.SettledTransaction) ([]models.DiscrepancyTransaction, error) {
+	ledgerAccount := models.CustomerLedgerAccount(accountID)
+	journalIDs, err := s.ledgerRepo.GetJournalIDs(ctx, ledgerAccount)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(journalIDs)
+	posted := make(map[string]bool, len(journalIDs))
+	for _, id := range journalIDs {
+		posted[id] = true
+	}
+	succeeded := make(map[string]bool, len(settled))
+	for _, tx := range settled {
+		succeeded[tx.Transaction.ID] = true
+	}
+
+	involved := []models.DiscrepancyTransaction{}
+	for _, id := range journalIDs {
+		if succeeded[id] || id == models.OpeningJournalID(accountID) {
+			continue
+		}
+		journal, err := s.ledgerRepo.GetJournal(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		entry := models.DiscrepancyTransaction{ID: id, Amount: journal.NetChange(ledgerAccount), Issue: models.IssuePostedNoTransaction}
+		tx, err := s.transactionRepo.GetByID(ctx, id)
+		if err == nil {
+			entry.Type, entry.Status, entry.Issue = tx.Type, tx.Status, models.IssuePostedNotSucceeded
+		} else if !errors.Is(err, models.ErrTransactionNotFound) {
+			return nil, err
+		}
+		involved = append(involved, entry)
+	}
+	for _, tx := range settled {
+		if posted[tx.Transaction.ID] {
+			continue
+		}
+		involved = append(involved, models.DiscrepancyTransaction{
+			ID:     tx.Transaction.ID,
+			Type:   tx.Transaction.Type,
+			Status: tx.Transaction.Status,
+			Amount: tx.Change,
+			Issue:  models.IssueSucceededNotPosted,
+		})
+	}
+	return involved, nil
+}
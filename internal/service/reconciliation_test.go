@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	queue_mocks "github.com/RajVerma97/golang-banking-ledger/pkg/queue/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	updatedAt := now.Add(-time.Hour)
+
+	balanced := models.Account{ID: uuid.New(), Balance: models.NewMoney(100), Currency: models.DefaultCurrency, UpdatedAt: updatedAt}
+	broken := models.Account{ID: uuid.New(), Balance: models.NewMoney(150), Currency: models.DefaultCurrency, UpdatedAt: updatedAt}
+	busy := models.Account{ID: uuid.New(), Balance: models.NewMoney(70), Currency: models.DefaultCurrency, UpdatedAt: updatedAt}
+
+	deposit := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(40), Currency: models.DefaultCurrency, AccountID: broken.ID.String(), Status: models.SUCCESS, ProcessedAt: now.Add(-2 * time.Hour)}
+	unposted := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(5), Currency: models.DefaultCurrency, AccountID: broken.ID.String(), Status: models.SUCCESS, ProcessedAt: now.Add(-time.Hour)}
+	failed := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(10), Currency: models.DefaultCurrency, AccountID: broken.ID.String(), Status: models.FAILED}
+	failedJournal, err := models.NewTransactionJournal(&failed)
+	require.NoError(t, err)
+
+	setup := func() (*ReconciliationService, *mocks.MockReconciliationRepository, *mocks.MockTransactionRepository) {
+		mockReconciliationRepo := new(mocks.MockReconciliationRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{balanced, broken, busy}, nil)
+		mockAccountRepo.On("GetByID", ctx, broken.ID).Return(broken, nil)
+
+		mockTransactionRepo.On("IsProcessing", ctx, balanced.ID.String()).Return(false, nil)
+		mockTransactionRepo.On("IsProcessing", ctx, broken.ID.String()).Return(false, nil)
+		mockTransactionRepo.On("IsProcessing", ctx, busy.ID.String()).Return(true, nil)
+
+		mockLedgerRepo.On("GetJournal", ctx, models.OpeningJournalID(balanced.ID)).Return(models.NewOpeningJournal(&balanced), nil)
+		mockTransactionRepo.On("GetSettled", ctx, balanced.ID.String(), time.Time{}, now).Return([]models.Transaction{}, nil)
+		mockLedgerRepo.On("GetBalanceAt", ctx, models.CustomerLedgerAccount(balanced.ID), now).Return(balanced.Balance, nil)
+
+		opening := models.NewOpeningJournal(&models.Account{ID: broken.ID, Balance: models.NewMoney(100), Currency: models.DefaultCurrency})
+		mockLedgerRepo.On("GetJournal", ctx, opening.ID).Return(opening, nil)
+		mockTransactionRepo.On("GetSettled", ctx, broken.ID.String(), time.Time{}, now).Return([]models.Transaction{deposit, unposted}, nil)
+		mockLedgerRepo.On("GetBalanceAt", ctx, models.CustomerLedgerAccount(broken.ID), now).Return(models.NewMoney(150), nil)
+		mockLedgerRepo.On("GetJournalIDs", ctx, models.CustomerLedgerAccount(broken.ID)).Return([]string{opening.ID, deposit.ID, failed.ID}, nil)
+		mockLedgerRepo.On("GetJournal", ctx, failed.ID).Return(failedJournal, nil)
+		mockTransactionRepo.On("GetByID", ctx, failed.ID).Return(&failed, nil)
+
+		mockReconciliationRepo.On("CreateReport", ctx, mock.AnythingOfType("*models.ReconciliationReport")).Return(nil)
+		transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+		return NewReconciliationService(mockReconciliationRepo, mockAccountRepo, mockTransactionRepo, mockLedgerRepo, transactions), mockReconciliationRepo, mockTransactionRepo
+	}
+
+	correctionID := models.ReconciliationAdjustmentID(broken.ID, updatedAt)
+
+	t.Run("Corrects Discrepancies", func(t *testing.T) {
+		service, mockReconciliationRepo, mockTransactionRepo := setup()
+		mockTransactionRepo.On("GetByID", ctx, correctionID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		var correction *models.Transaction
+		mockTransactionRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Run(func(args mock.Arguments) {
+			correction = args.Get(1).(*models.Transaction)
+		}).Return(nil)
+
+		report, err := service.Reconcile(ctx, false, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.AccountsChecked)
+		assert.Equal(t, 1, report.AccountsSkipped)
+		require.Len(t, report.Discrepancies, 1)
+
+		discrepancy := report.Discrepancies[0]
+		assert.Equal(t, broken.ID, discrepancy.AccountID)
+		assert.Equal(t, models.NewMoney(145), discrepancy.Expected)
+		assert.Equal(t, models.NewMoney(150), discrepancy.Actual)
+		assert.Equal(t, models.NewMoney(150), discrepancy.Ledger)
+		assert.Equal(t, models.NewMoney(5), discrepancy.Difference)
+		assert.Nil(t, discrepancy.CaseID)
+		require.NotNil(t, correction)
+		assert.Equal(t, correctionID, correction.ID)
+		assert.Equal(t, correction.ID, discrepancy.AdjustmentID)
+		assert.Equal(t, models.ADJUSTMENT, correction.Type)
+		assert.Equal(t, models.PENDING, correction.Status)
+		assert.Equal(t, broken.ID.String(), correction.AccountID)
+		assert.Equal(t, models.NewMoney(5), correction.Amount)
+		assert.Equal(t, models.DEBIT, correction.Adjustment.Direction)
+		assert.Equal(t, models.AdjustmentReconciliation, correction.Adjustment.ReasonCode)
+		assert.Equal(t, models.ReconciliationOperatorID, correction.Adjustment.OperatorID)
+		assert.NotNil(t, correction.Outbox)
+		mockTransactionRepo.AssertNumberOfCalls(t, "Create", 1)
+		assert.Equal(t, []models.DiscrepancyTransaction{
+			{ID: failed.ID, Type: models.DEPOSIT, Status: models.FAILED, Amount: models.NewMoney(10), Issue: models.IssuePostedNotSucceeded},
+			{ID: unposted.ID, Type: models.DEPOSIT, Status: models.SUCCESS, Amount: models.NewMoney(5), Issue: models.IssueSucceededNotPosted},
+		}, discrepancy.Transactions)
+		mockReconciliationRepo.AssertNotCalled(t, "RecordCase", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockReconciliationRepo.AssertCalled(t, "CreateReport", ctx, &report)
+	})
+
+	t.Run("Correction Already Posted", func(t *testing.T) {
+		// A concurrent run checked the same balance and corrected it first.
+		service, _, mockTransactionRepo := setup()
+		mockTransactionRepo.On("GetByID", ctx, correctionID).Return(&models.Transaction{ID: correctionID, Type: models.ADJUSTMENT}, nil)
+
+		report, err := service.Reconcile(ctx, false, now)
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		assert.Equal(t, correctionID, report.Discrepancies[0].AdjustmentID)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Opens Investigation Cases", func(t *testing.T) {
+		service, mockReconciliationRepo, mockTransactionRepo := setup()
+		caseID := uuid.New()
+		mockReconciliationRepo.On("RecordCase", ctx, mock.AnythingOfType("uuid.UUID"), mock.MatchedBy(func(discrepancy *models.Discrepancy) bool {
+			return discrepancy.AccountID == broken.ID
+		}), now).Return(models.InvestigationCase{ID: caseID, AccountID: broken.ID, Status: models.CaseOpen}, nil)
+
+		report, err := service.Reconcile(ctx, true, now)
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		assert.Equal(t, &caseID, report.Discrepancies[0].CaseID)
+		assert.Empty(t, report.Discrepancies[0].AdjustmentID)
+		mockReconciliationRepo.AssertNumberOfCalls(t, "RecordCase", 1)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReconciliationService_Reconcile_Corrections(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	account := models.Account{ID: uuid.New(), Balance: models.NewMoney(95), Currency: models.DefaultCurrency, UpdatedAt: now.Add(-time.Hour)}
+	opening := models.NewOpeningJournal(&models.Account{ID: account.ID, Balance: models.NewMoney(90), Currency: models.DefaultCurrency})
+
+	setup := func(settled []models.Transaction, ledger models.Money) (*ReconciliationService, *mocks.MockTransactionRepository) {
+		mockReconciliationRepo := new(mocks.MockReconciliationRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{account}, nil)
+		mockAccountRepo.On("GetByID", ctx, account.ID).Return(account, nil)
+		mockTransactionRepo.On("IsProcessing", ctx, account.ID.String()).Return(false, nil)
+		mockLedgerRepo.On("GetJournal", ctx, opening.ID).Return(opening, nil)
+		mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), time.Time{}, now).Return(settled, nil)
+		mockLedgerRepo.On("GetBalanceAt", ctx, models.CustomerLedgerAccount(account.ID), now).Return(ledger, nil)
+		mockLedgerRepo.On("GetJournalIDs", ctx, models.CustomerLedgerAccount(account.ID)).Return([]string{opening.ID}, nil)
+		mockReconciliationRepo.On("CreateReport", ctx, mock.AnythingOfType("*models.ReconciliationReport")).Return(nil)
+		transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+		return NewReconciliationService(mockReconciliationRepo, mockAccountRepo, mockTransactionRepo, mockLedgerRepo, transactions), mockTransactionRepo
+	}
+
+	t.Run("Corrected Balance Is In Balance", func(t *testing.T) {
+		// A deposit that never reached either balance, and the adjustment a
+		// previous run posted for it.
+		unposted := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(5), Currency: models.DefaultCurrency, AccountID: account.ID.String(), Status: models.SUCCESS, ProcessedAt: now.Add(-3 * time.Hour)}
+		discrepancy := models.Discrepancy{AccountID: account.ID, Currency: account.Currency, Difference: models.NewMoney(-5)}
+		adjustment := *discrepancy.Correction(uuid.New(), now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+		adjustment.Status = models.SUCCESS
+		adjustment.ProcessedAt = now.Add(-2 * time.Hour)
+		service, mockTransactionRepo := setup([]models.Transaction{unposted, adjustment}, account.Balance)
+
+		report, err := service.Reconcile(ctx, false, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.AccountsChecked)
+		assert.Empty(t, report.Discrepancies)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Stored And Ledger Balances Disagree", func(t *testing.T) {
+		service, mockTransactionRepo := setup([]models.Transaction{}, models.NewMoney(100))
+
+		report, err := service.Reconcile(ctx, false, now)
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		assert.Empty(t, report.Discrepancies[0].AdjustmentID)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReconciliationService_Reconcile_AccountChanged(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	account := models.Account{ID: uuid.New(), Balance: models.NewMoney(100), Currency: models.DefaultCurrency, UpdatedAt: now.Add(-time.Hour)}
+	changed := account
+	changed.Balance = models.NewMoney(120)
+	changed.UpdatedAt = now
+
+	mockReconciliationRepo := new(mocks.MockReconciliationRepository)
+	mockAccountRepo := new(mocks.MockAccountRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{account}, nil)
+	mockAccountRepo.On("GetByID", ctx, account.ID).Return(changed, nil)
+	mockTransactionRepo.On("IsProcessing", ctx, account.ID.String()).Return(false, nil)
+	mockLedgerRepo.On("GetJournal", ctx, models.OpeningJournalID(account.ID)).Return(models.NewOpeningJournal(&account), nil)
+	mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), time.Time{}, now).Return([]models.Transaction{}, nil)
+	mockLedgerRepo.On("GetBalanceAt", ctx, models.CustomerLedgerAccount(account.ID), now).Return(models.NewMoney(120), nil)
+	mockReconciliationRepo.On("CreateReport", ctx, mock.AnythingOfType("*models.ReconciliationReport")).Return(nil)
+	service := NewReconciliationService(mockReconciliationRepo, mockAccountRepo, mockTransactionRepo, mockLedgerRepo, nil)
+
+	report, err := service.Reconcile(ctx, true, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.AccountsChecked)
+	assert.Equal(t, 1, report.AccountsSkipped)
+	assert.Empty(t, report.Discrepancies)
+	mockLedgerRepo.AssertNotCalled(t, "GetJournalIDs", mock.Anything, mock.Anything)
+}
+
+func TestReconciliationService_ResolveCase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	resolution := models.CaseResolution{Resolution: "posted the missing deposit", OperatorID: "ops-1"}
+
+	t.Run("Resolves Open Case", func(t *testing.T) {
+		mockReconciliationRepo := new(mocks.MockReconciliationRepository)
+		mockReconciliationRepo.On("UpdateCase", ctx, id).Return(models.InvestigationCase{ID: id, Status: models.CaseOpen}, nil)
+		service := NewReconciliationService(mockReconciliationRepo, nil, nil, nil, nil)
+
+		investigation, err := service.ResolveCase(ctx, id, resolution, now)
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseResolved, investigation.Status)
+		assert.Equal(t, "ops-1", investigation.ResolvedBy)
+	})
+
+	t.Run("Already Resolved", func(t *testing.T) {
+		mockReconciliationRepo := new(mocks.MockReconciliationRepository)
+		mockReconciliationRepo.On("UpdateCase", ctx, id).Return(models.InvestigationCase{ID: id, Status: models.CaseResolved}, nil)
+		service := NewReconciliationService(mockReconciliationRepo, nil, nil, nil, nil)
+
+		_, err := service.ResolveCase(ctx, id, resolution, now)
+		assert.ErrorIs(t, err, models.ErrCaseResolved)
+	})
+}
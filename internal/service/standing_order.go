@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+type StandingOrderService struct {
+	orderRepo    StandingOrderRepository
+	accountRepo  AccountRepository
+	transactions TransactionCreator
+	maxFailures  int
+}
+
+type StandingOrderRepository interface {
+	Create(ctx context.Context, order *models.StandingOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.StandingOrder, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.StandingOrder, error)
+	Update(ctx context.Context, id uuid.UUID, change func(*models.StandingOrder) error) (models.StandingOrder, error)
+	GetDue(ctx context.Context, now time.Time) ([]models.StandingOrder, error)
+	RecordRun(ctx context.Context, order *models.StandingOrder, scheduledFor time.Time, run *models.StandingOrderRun) error
+	GetPendingRuns(ctx context.Context) ([]models.StandingOrderRun, error)
+	SettleRun(ctx context.Context, run *models.StandingOrderRun, maxFailures int) error
+	GetRuns(ctx context.Context, orderID uuid.UUID) ([]models.StandingOrderRun, error)
+}
+
+func NewStandingOrderService(orderRepo StandingOrderRepository, accountRepo AccountRepository, transactions TransactionCreator, maxFailures int) *StandingOrderService {
+	return &StandingOrderService{
+		orderRepo:    orderRepo,
+		accountRepo:  accountRepo,
+		transactions: transactions,
+		maxFailures:  maxFailures,
+	}
+}
+
+func (s *StandingOrderService) Create(ctx context.Context, request models.StandingOrderCreate) (models.StandingOrder, error) {
+	order, err := models.NewStandingOrder(request)
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, order.AccountID)
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+	if err := account.CheckTransaction(order.Transaction(order.NextRunAt)); err != nil {
+		return models.StandingOrder{}, err
+	}
+	if order.Currency == "" {
+		order.Currency = account.Currency
+	}
+	if order.Currency != account.Currency {
+		return models.StandingOrder{}, fmt.Errorf("%w: currency %s does not match account currency %s", models.ErrInvalidStandingOrder, order.Currency, account.Currency)
+	}
+	if !order.Amount.HasPrecision(order.Currency.Decimals()) {
+		return models.StandingOrder{}, fmt.Errorf("%w: the amount has more decimal places than %s allows", models.ErrInvalidStandingOrder, order.Currency)
+	}
+
+	if order.DestinationAccountID != nil {
+		destination, err := s.accountRepo.GetByID(ctx, *order.DestinationAccountID)
+		if err != nil {
+			return models.StandingOrder{}, fmt.Errorf("%w: destination account: %v", models.ErrInvalidStandingOrder, err)
+		}
+		if err := destination.CheckIncoming(); err != nil {
+			return models.StandingOrder{}, fmt.Errorf("destination %w", err)
+		}
+	}
+
+	if err := s.orderRepo.Create(ctx, &order); err != nil {
+		return models.StandingOrder{}, err
+	}
+	return order, nil
+}
+
+func (s *StandingOrderService) GetByID(ctx context.Context, id uuid.UUID) (models.StandingOrder, error) {
+	return s.orderRepo.GetByID(ctx, id)
+}
+
+func (s *StandingOrderService) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.StandingOrder, error) {
+	return s.orderRepo.GetByAccountID(ctx, accountID)
+}
+
+func (s *StandingOrderService) GetRuns(ctx context.Context, id uuid.UUID) ([]models.StandingOrderRun, error) {
+	if _, err := s.orderRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.orderRepo.GetRuns(ctx, id)
+}
+
+func (s *StandingOrderService) Update(ctx context.Context, id uuid.UUID, update models.StandingOrderUpdate) (models.StandingOrder, error) {
+	return s.orderRepo.Update(ctx, id, func(order *models.StandingOrder) error {
+		if update.Amount != nil && !update.Amount.HasPrecision(order.Currency.Decimals()) {
+			return fmt.Errorf("%w: the amount has more decimal places than %s allows", models.ErrInvalidStandingOrder, order.Currency)
+		}
+		return order.Apply(update, time.Now())
+	})
+}
+
+// Cancel stops a standing order for good. The order and its runs are kept.
+func (s *StandingOrderService) Cancel(ctx context.Context, id uuid.UUID) (models.StandingOrder, error) {
+	return s.orderRepo.Update(ctx, id, func(order *models.StandingOrder) error {
+		if order.Status == models.StandingOrderCompleted || order.Status == models.StandingOrderCancelled {
+			return models.ErrStandingOrderEnded
+		}
+		order.Status = models.StandingOrderCancelled
+		return nil
+	})
+}
+
+// RunDue settles the runs whose transactions the worker has finished with,
+// then runs each active standing order that is due, and returns how many it
+// ran. An order that missed several occurrences catches up one per call.
+func (s *StandingOrderService) RunDue(ctx context.Context) (int, error) {
+	if err := s.settleRuns(ctx); err != nil {
+		return 0, err
+	}
+
+	orders, err := s.orderRepo.GetDue(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	ran := 0
+	for i := range orders {
+		err := s.run(ctx, &orders[i])
+		if errors.Is(err, models.ErrStandingOrderChanged) {
+			continue
+		}
+		if err != nil {
+			return ran, fmt.Errorf("failed to run standing order %s: %w", orders[i].ID, err)
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// run claims the order's next occurrence by recording its run, then creates
+// the run's transaction. The claim fails if the order was run, paused or
+// changed since it was read, so nothing is paid for an occurrence that is not
+// recorded, and the transaction's ID is derived from the order and the
+// occurrence, so it is created at most once.
+func (s *StandingOrderService) run(ctx context.Context, order *models.StandingOrder) error {
+	scheduledFor := order.NextRunAt
+	tx := order.Transaction(scheduledFor)
+	run := &models.StandingOrderRun{
+		ID:              uuid.New(),
+		StandingOrderID: order.ID,
+		ScheduledFor:    scheduledFor,
+		TransactionID:   tx.ID,
+		Status:          models.StandingOrderRunPending,
+	}
+
+	order.Advance()
+	if err := s.orderRepo.RecordRun(ctx, order, scheduledFor, run); err != nil {
+		return err
+	}
+	return s.createTransaction(ctx, tx, run)
+}
+
+// createTransaction creates the transaction of a claimed run, unless it has
+// been created already. A transaction that cannot be created fails the run.
+func (s *StandingOrderService) createTransaction(ctx context.Context, tx *models.Transaction, run *models.StandingOrderRun) error {
+	_, err := s.transactions.GetByID(ctx, tx.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, models.ErrTransactionNotFound) {
+		return err
+	}
+
+	now := time.Now()
+	tx.CreatedAt = now
+	tx.UpdatedAt = now
+	if err := s.transactions.Create(ctx, tx); err != nil {
+		// Another attempt at the same run may have created it meanwhile.
+		if _, getErr := s.transactions.GetByID(ctx, tx.ID); getErr == nil {
+			return nil
+		}
+		log.Printf("Standing order %s failed to create its transaction: %v", run.StandingOrderID, err)
+		run.TransactionID = ""
+		run.Status = models.StandingOrderRunFailed
+		run.Error = err.Error()
+		return s.orderRepo.SettleRun(ctx, run, s.maxFailures)
+	}
+	return nil
+}
+
+func (s *StandingOrderService) settleRuns(ctx context.Context) error {
+	runs, err := s.orderRepo.GetPendingRuns(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range runs {
+		run := &runs[i]
+		tx, err := s.transactions.GetByID(ctx, run.TransactionID)
+		if errors.Is(err, models.ErrTransactionNotFound) {
+			// The run was claimed but stopped before creating its transaction.
+			if err := s.resumeRun(ctx, run); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to look up transaction %s of standing order run %s: %v", run.TransactionID, run.ID, err)
+			continue
+		}
+		switch tx.Status {
+		case models.SUCCESS:
+			run.Status = models.StandingOrderRunSuccess
+		case models.FAILED:
+			run.Status = models.StandingOrderRunFailed
+			run.Error = "transaction failed"
+		default:
+			continue
+		}
+		if err := s.orderRepo.SettleRun(ctx, run, s.maxFailures); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StandingOrderService) resumeRun(ctx context.Context, run *models.StandingOrderRun) error {
+	order, err := s.orderRepo.GetByID(ctx, run.StandingOrderID)
+	if err != nil {
+		return err
+	}
+	return s.createTransaction(ctx, order.Transaction(run.ScheduledFor), run)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	queue_mocks "github.com/RajVerma97/golang-banking-ledger/pkg/queue/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandingOrderService_Create(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	request := models.StandingOrderCreate{
+		AccountID: accountID.String(),
+		Type:      models.WITHDRAWL,
+		Amount:    models.NewMoney(25),
+		Frequency: models.FrequencyWeekly,
+		StartAt:   time.Now().Add(time.Hour),
+	}
+
+	setup := func(status models.AccountStatus) (*StandingOrderService, *mocks.MockStandingOrderRepository) {
+		mockOrderRepo := new(mocks.MockStandingOrderRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{
+			ID:       accountID,
+			Currency: models.DefaultCurrency,
+			Status:   status,
+		}, nil)
+		return NewStandingOrderService(mockOrderRepo, mockAccountRepo, nil, 3), mockOrderRepo
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mockOrderRepo := setup(models.AccountActive)
+		mockOrderRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		order, err := service.Create(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultCurrency, order.Currency)
+		assert.Equal(t, models.StandingOrderActive, order.Status)
+		assert.Equal(t, request.StartAt, order.NextRunAt)
+	})
+
+	t.Run("Frozen Account", func(t *testing.T) {
+		service, mockOrderRepo := setup(models.AccountFrozen)
+
+		_, err := service.Create(ctx, request)
+		assert.ErrorIs(t, err, models.ErrAccountFrozen)
+		mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Currency Mismatch", func(t *testing.T) {
+		service, _ := setup(models.AccountActive)
+		euros := request
+		euros.Currency = "EUR"
+
+		_, err := service.Create(ctx, euros)
+		assert.ErrorIs(t, err, models.ErrInvalidStandingOrder)
+	})
+}
+
+func TestStandingOrderService_RunDue(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	due := time.Now().Add(-time.Minute)
+	order := func() models.StandingOrder {
+		return models.StandingOrder{
+			ID:        uuid.New(),
+			AccountID: accountID,
+			Type:      models.WITHDRAWL,
+			Amount:    models.NewMoney(25),
+			Currency:  models.DefaultCurrency,
+			Frequency: models.FrequencyWeekly,
+			StartAt:   due,
+			NextRunAt: due,
+			Status:    models.StandingOrderActive,
+		}
+	}
+
+	setup := func(status models.AccountStatus, usage models.LimitUsage) (*StandingOrderService, *mocks.MockStandingOrderRepository, *mocks.MockTransactionRepository) {
+		mockOrderRepo := new(mocks.MockStandingOrderRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{
+			ID:       accountID,
+			Currency: models.DefaultCurrency,
+			Status:   status,
+		}, nil)
+		transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withLimitUsage(mockTransactionRepo, usage))
+		mockOrderRepo.On("GetPendingRuns", ctx).Return([]models.StandingOrderRun{}, nil)
+		return NewStandingOrderService(mockOrderRepo, mockAccountRepo, transactions, 2), mockOrderRepo, mockTransactionRepo
+	}
+
+	t.Run("Creates The Transaction", func(t *testing.T) {
+		service, mockOrderRepo, mockTransactionRepo := setup(models.AccountActive, models.LimitUsage{})
+		o := order()
+		txID := o.RunTransactionID(due)
+		mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{o}, nil)
+		claim := mockOrderRepo.On("RecordRun", ctx, mock.MatchedBy(func(advanced *models.StandingOrder) bool {
+			return advanced.RunCount == 1 && advanced.NextRunAt.Equal(due.AddDate(0, 0, 7))
+		}), due, mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+			return run.Status == models.StandingOrderRunPending && run.TransactionID == txID
+		})).Return(nil)
+		mockTransactionRepo.On("GetByID", ctx, txID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound).NotBefore(claim)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.ID == txID && tx.Outbox != nil
+		})).Return(nil).NotBefore(claim)
+
+		ran, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ran)
+		mockOrderRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Transaction Already Created", func(t *testing.T) {
+		service, mockOrderRepo, mockTransactionRepo := setup(models.AccountActive, models.LimitUsage{})
+		o := order()
+		txID := o.RunTransactionID(due)
+		mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{o}, nil)
+		mockOrderRepo.On("RecordRun", ctx, mock.Anything, due, mock.Anything).Return(nil)
+		mockTransactionRepo.On("GetByID", ctx, txID).Return(&models.Transaction{ID: txID, Status: models.PENDING}, nil)
+
+		_, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Creation Fails The Run", func(t *testing.T) {
+		service, mockOrderRepo, mockTransactionRepo := setup(models.AccountFrozen, models.LimitUsage{})
+		o := order()
+		o.ConsecutiveFailures = 1
+		mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{o}, nil)
+		mockOrderRepo.On("RecordRun", ctx, mock.Anything, due, mock.Anything).Return(nil)
+		mockTransactionRepo.On("GetByID", ctx, mock.Anything).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockOrderRepo.On("SettleRun", ctx, mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+			return run.Status == models.StandingOrderRunFailed && run.TransactionID == "" && run.Error != ""
+		}), 2).Return(nil)
+
+		_, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Limit Reached Fails The Run", func(t *testing.T) {
+		limits := models.DefaultTierLimits[models.TierStandard]
+		service, mockOrderRepo, mockTransactionRepo := setup(models.AccountActive, models.LimitUsage{DailyCount: 1, DailyAmount: limits.DailyAmount})
+		mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{order()}, nil)
+		mockOrderRepo.On("RecordRun", ctx, mock.Anything, due, mock.Anything).Return(nil)
+		mockTransactionRepo.On("GetByID", ctx, mock.Anything).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockOrderRepo.On("SettleRun", ctx, mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+			return run.Status == models.StandingOrderRunFailed && strings.Contains(run.Error, string(models.LimitDailyAmount))
+		}), 2).Return(nil)
+
+		_, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		mockOrderRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Order Changed Meanwhile", func(t *testing.T) {
+		service, mockOrderRepo, mockTransactionRepo := setup(models.AccountActive, models.LimitUsage{})
+		mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{order()}, nil)
+		mockOrderRepo.On("RecordRun", ctx, mock.Anything, due, mock.Anything).Return(models.ErrStandingOrderChanged)
+
+		ran, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, ran)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStandingOrderService_SettleRuns(t *testing.T) {
+	ctx := context.Background()
+	mockOrderRepo := new(mocks.MockStandingOrderRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockAccountRepo := new(mocks.MockAccountRepository)
+	transactions := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+	service := NewStandingOrderService(mockOrderRepo, mockAccountRepo, transactions, 3)
+
+	accountID := uuid.New()
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency, Status: models.AccountActive}, nil)
+	order := models.StandingOrder{ID: uuid.New(), AccountID: accountID, Type: models.DEPOSIT, Amount: models.NewMoney(25), Currency: models.DefaultCurrency}
+	scheduledFor := time.Now().Add(-time.Hour)
+	unclaimedID := order.RunTransactionID(scheduledFor)
+
+	runs := []models.StandingOrderRun{
+		{ID: uuid.New(), TransactionID: "succeeded", Status: models.StandingOrderRunPending},
+		{ID: uuid.New(), TransactionID: "failed", Status: models.StandingOrderRunPending},
+		{ID: uuid.New(), TransactionID: "processing", Status: models.StandingOrderRunPending},
+		{ID: uuid.New(), TransactionID: "unreachable", Status: models.StandingOrderRunPending},
+		{ID: uuid.New(), StandingOrderID: order.ID, ScheduledFor: scheduledFor, TransactionID: unclaimedID, Status: models.StandingOrderRunPending},
+	}
+	mockOrderRepo.On("GetPendingRuns", ctx).Return(runs, nil)
+	mockOrderRepo.On("GetDue", ctx, mock.Anything).Return([]models.StandingOrder{}, nil)
+	mockTransactionRepo.On("GetByID", ctx, "succeeded").Return(&models.Transaction{Status: models.SUCCESS}, nil)
+	mockTransactionRepo.On("GetByID", ctx, "failed").Return(&models.Transaction{Status: models.FAILED}, nil)
+	mockTransactionRepo.On("GetByID", ctx, "processing").Return(&models.Transaction{Status: models.PENDING}, nil)
+	mockTransactionRepo.On("GetByID", ctx, "unreachable").Return((*models.Transaction)(nil), errors.New("connection reset"))
+	// The last run was claimed but its transaction was never created.
+	mockTransactionRepo.On("GetByID", ctx, unclaimedID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+	mockOrderRepo.On("GetByID", ctx, order.ID).Return(order, nil)
+	mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+		return tx.ID == unclaimedID && tx.Amount == order.Amount
+	})).Return(nil).Once()
+	mockOrderRepo.On("SettleRun", ctx, mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+		return run.TransactionID == "succeeded" && run.Status == models.StandingOrderRunSuccess
+	}), 3).Return(nil).Once()
+	mockOrderRepo.On("SettleRun", ctx, mock.MatchedBy(func(run *models.StandingOrderRun) bool {
+		return run.TransactionID == "failed" && run.Status == models.StandingOrderRunFailed
+	}), 3).Return(nil).Once()
+
+	_, err := service.RunDue(ctx)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
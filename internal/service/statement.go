@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/google/uuid"
+)
+
+// statementDelay is how long after a month ends its statements wait, so that
+// transactions being processed at midnight are recorded before they are built.
+const statementDelay = time.Minute
+
+type StatementService struct {
+	statementRepo   StatementRepository
+	transactionRepo SettledTransactionRepository
+	accountRepo     AccountRepository
+	journalRepo     JournalRepository
+}
+
+type StatementRepository interface {
+	Create(ctx context.Context, statement *models.Statement) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.Statement, error)
+	GetByPeriod(ctx context.Context, accountID uuid.UUID, period string) (models.Statement, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]models.Statement, error)
+	GetDocument(ctx context.Context, statementID uuid.UUID, format models.StatementFormat) (models.StatementDocument, error)
+}
+
+type SettledTransactionRepository interface {
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+	GetSettled(ctx context.Context, accountID string, from, until time.Time) ([]models.Transaction, error)
+}
+
+type JournalRepository interface {
+	GetJournal(ctx context.Context, id string) (models.Journal, error)
+}
+
+func NewStatementService(statementRepo StatementRepository, transactionRepo SettledTransactionRepository, accountRepo AccountRepository, journalRepo JournalRepository) *StatementService {
+	return &StatementService{
+		statementRepo:   statementRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		journalRepo:     journalRepo,
+	}
+}
+
+// GetStatements returns the account's statements, latest first, without their
+// lines.
+func (s *StatementService) GetStatements(ctx context.Context, accountID uuid.UUID) ([]models.Statement, error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.statementRepo.GetByAccountID(ctx, accountID)
+}
+
+// GetDocument returns one of the account's statements in the given format,
+// exactly as it was rendered when the statement was generated.
+func (s *StatementService) GetDocument(ctx context.Context, accountID, statementID uuid.UUID, format models.StatementFormat) (models.Statement, models.StatementDocument, error) {
+	statement, err := s.statementRepo.GetByID(ctx, statementID)
+	if err != nil {
+		return models.Statement{}, models.StatementDocument{}, err
+	}
+	if statement.AccountID != accountID {
+		return models.Statement{}, models.StatementDocument{}, models.ErrStatementNotFound
+	}
+	document, err := s.statementRepo.GetDocument(ctx, statementID, format)
+	if err != nil {
+		return models.Statement{}, models.StatementDocument{}, err
+	}
+	return statement, document, nil
+}
+
+// Generate returns the account's statement for a month given as YYYY-MM,
+// building and storing it first if it has not been generated yet. The month
+// must have ended.
+func (s *StatementService) Generate(ctx context.Context, accountID uuid.UUID, period string, now time.Time) (models.Statement, error) {
+	start, end, err := models.StatementPeriod(period)
+	if err != nil {
+		return models.Statement{}, err
+	}
+	if now.Before(end.Add(statementDelay)) {
+		return models.Statement{}, fmt.Errorf("%w: %s has not ended", models.ErrInvalidStatementPeriod, period)
+	}
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return models.Statement{}, err
+	}
+	if !account.CreatedAt.Before(end) {
+		return models.Statement{}, fmt.Errorf("%w: the account was opened after %s", models.ErrInvalidStatementPeriod, period)
+	}
+
+	statement, err := s.statementRepo.GetByPeriod(ctx, accountID, period)
+	if err == nil || !errors.Is(err, models.ErrStatementNotFound) {
+		return statement, err
+	}
+
+	opening, err := s.openingBalance(ctx, &account, start)
+	if err != nil {
+		return models.Statement{}, err
+	}
+	entries, err := settledTransactions(ctx, s.transactionRepo, accountID, start, end)
+	if err != nil {
+		return models.Statement{}, err
+	}
+
+	statement = models.NewStatement(&account, period, start, end, opening, entries, now)
+	statement.ID = uuid.New()
+	if statement.Documents, err = statement.RenderDocuments(); err != nil {
+		return models.Statement{}, fmt.Errorf("failed to render statement: %w", err)
+	}
+	created, err := s.statementRepo.Create(ctx, &statement)
+	if err != nil {
+		return models.Statement{}, err
+	}
+	if !created {
+		// Generated concurrently; the statement stored first is the one kept.
+		return s.statementRepo.GetByPeriod(ctx, accountID, period)
+	}
+	return statement, nil
+}
+
+// GenerateDue generates the statement of every account for the last month
+// that has ended by now, and returns how many it generated. Accounts closed
+// before that month get none.
+func (s *StatementService) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	last := now.Add(-statementDelay).UTC()
+	period := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0).Format("2006-01")
+	start, end, err := models.StatementPeriod(period)
+	if err != nil {
+		return 0, err
+	}
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for i := range accounts {
+		account := &accounts[i]
+		if !account.CreatedAt.Before(end) || account.Status == models.AccountClosed && account.UpdatedAt.Before(start) {
+			continue
+		}
+		_, err := s.statementRepo.GetByPeriod(ctx, account.ID, period)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, models.ErrStatementNotFound) {
+			return generated, err
+		}
+		if _, err := s.Generate(ctx, account.ID, period, now); err != nil {
+			log.Printf("Failed to generate statement %s for account %s: %v", period, account.ID, err)
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// openingBalance works out the account's balance before start: the closing
+// balance of the previous month's statement if there is one, otherwise the
+// balance it was opened with plus every transaction processed before start.
+func (s *StatementService) openingBalance(ctx context.Context, account *models.Account, start time.Time) (models.Money, error) {
+	previous, err := s.statementRepo.GetByPeriod(ctx, account.ID, start.AddDate(0, -1, 0).Format("2006-01"))
+	if err == nil {
+		return previous.ClosingBalance, nil
+	}
+	if !errors.Is(err, models.ErrStatementNotFound) {
+		return 0, err
+	}
+
+	balance, err := openedWith(ctx, s.journalRepo, account.ID)
+	if err != nil {
+		return 0, err
+	}
+	earlier, err := settledTransactions(ctx, s.transactionRepo, account.ID, time.Time{}, start)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range earlier {
+		balance += entry.Change
+	}
+	return balance, nil
+}
+
+// openedWith returns the balance the account was opened with, from its opening
+// journal.
+func openedWith(ctx context.Context, journalRepo JournalRepository, accountID uuid.UUID) (models.Money, error) {
+	journal, err := journalRepo.GetJournal(ctx, models.OpeningJournalID(accountID))
+	if errors.Is(err, models.ErrJournalNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return journal.NetChange(models.CustomerLedgerAccount(accountID)), nil
+}
+
+// settledTransactions returns the account's successful transactions processed
+// at or after from and before until, with what each changed its balance by.
+func settledTransactions(ctx context.Context, transactionRepo SettledTransactionRepository, accountID uuid.UUID, from, until time.Time) ([]models.SettledTransaction, error) {
+	transactions, err := transactionRepo.GetSettled(ctx, accountID.String(), from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.SettledTransaction, 0, len(transactions))
+	for i := range transactions {
+		tx := &transactions[i]
+		withCurrency(tx)
+
+		var original *models.Transaction
+		if tx.Type == models.REVERSAL {
+			if original, err = transactionRepo.GetByID(ctx, tx.ReversalOf); err != nil {
+				return nil, fmt.Errorf("failed to fetch transaction %s reversed by %s: %w", tx.ReversalOf, tx.ID, err)
+			}
+			withCurrency(original)
+		}
+		change, err := models.TransactionChange(tx, original, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", tx.ID, err)
+		}
+		entries = append(entries, models.SettledTransaction{Transaction: tx, Change: change})
+	}
+	return entries, nil
+}
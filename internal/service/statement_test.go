@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementService_Generate(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	now := end.Add(time.Hour)
+	account := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: start.AddDate(0, -2, 0)}
+	other := uuid.NewString()
+
+	deposit := models.Transaction{ID: uuid.NewString(), Type: models.DEPOSIT, Amount: models.NewMoney(20), AccountID: account.ID.String(), Status: models.SUCCESS, ProcessedAt: start.Add(-time.Hour)}
+	incoming := models.Transaction{ID: uuid.NewString(), Type: models.TRANSFER, Amount: models.NewMoney(30), Currency: models.DefaultCurrency, AccountID: other, DestinationAccountID: account.ID.String(), Status: models.SUCCESS, ProcessedAt: start.Add(time.Hour)}
+	reversal := models.Transaction{ID: uuid.NewString(), Type: models.REVERSAL, Amount: models.NewMoney(30), Currency: models.DefaultCurrency, AccountID: other, ReversalOf: incoming.ID, Status: models.SUCCESS, ProcessedAt: start.Add(2 * time.Hour)}
+	fee := models.Transaction{ID: uuid.NewString(), Type: models.FEE, Amount: models.NewMoney(2), Currency: models.DefaultCurrency, AccountID: account.ID.String(), Status: models.SUCCESS, ProcessedAt: start.Add(3 * time.Hour), Fee: &models.FeeCharge{Period: "2025-02"}}
+
+	setup := func() (*StatementService, *mocks.MockStatementRepository, *mocks.MockTransactionRepository, *mocks.MockLedgerRepository) {
+		mockStatementRepo := new(mocks.MockStatementRepository)
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockLedgerRepo := new(mocks.MockLedgerRepository)
+		mockAccountRepo.On("GetByID", ctx, account.ID).Return(account, nil)
+		return NewStatementService(mockStatementRepo, mockTransactionRepo, mockAccountRepo, mockLedgerRepo), mockStatementRepo, mockTransactionRepo, mockLedgerRepo
+	}
+
+	t.Run("First Statement", func(t *testing.T) {
+		service, mockStatementRepo, mockTransactionRepo, mockLedgerRepo := setup()
+		mockStatementRepo.On("GetByPeriod", ctx, account.ID, "2025-03").Return(models.Statement{}, models.ErrStatementNotFound).Once()
+		mockStatementRepo.On("GetByPeriod", ctx, account.ID, "2025-02").Return(models.Statement{}, models.ErrStatementNotFound)
+		opening := models.NewOpeningJournal(&models.Account{ID: account.ID, Balance: models.NewMoney(100), Currency: models.DefaultCurrency})
+		mockLedgerRepo.On("GetJournal", ctx, models.OpeningJournalID(account.ID)).Return(opening, nil)
+		mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), time.Time{}, start).Return([]models.Transaction{deposit}, nil)
+		mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), start, end).Return([]models.Transaction{incoming, reversal, fee}, nil)
+		mockTransactionRepo.On("GetByID", ctx, incoming.ID).Return(&incoming, nil)
+		mockStatementRepo.On("Create", ctx, mock.AnythingOfType("*models.Statement")).Return(true, nil)
+
+		statement, err := service.Generate(ctx, account.ID, "2025-03", now)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, statement.ID)
+		assert.Equal(t, models.NewMoney(120), statement.OpeningBalance)
+		assert.Equal(t, models.NewMoney(118), statement.ClosingBalance)
+		assert.Equal(t, models.NewMoney(2), statement.TotalFees)
+		require.Len(t, statement.Lines, 3)
+		assert.Equal(t, []models.Money{models.NewMoney(150), models.NewMoney(120), models.NewMoney(118)},
+			[]models.Money{statement.Lines[0].Balance, statement.Lines[1].Balance, statement.Lines[2].Balance})
+		assert.Len(t, statement.Documents, 2)
+		mockStatementRepo.AssertExpectations(t)
+	})
+
+	t.Run("Continues From The Previous Statement", func(t *testing.T) {
+		service, mockStatementRepo, mockTransactionRepo, mockLedgerRepo := setup()
+		mockStatementRepo.On("GetByPeriod", ctx, account.ID, "2025-03").Return(models.Statement{}, models.ErrStatementNotFound).Once()
+		mockStatementRepo.On("GetByPeriod", ctx, account.ID, "2025-02").Return(models.Statement{ClosingBalance: models.NewMoney(80)}, nil)
+		mockTransactionRepo.On("GetSettled", ctx, account.ID.String(), start, end).Return([]models.Transaction{fee}, nil)
+		mockStatementRepo.On("Create", ctx, mock.AnythingOfType("*models.Statement")).Return(true, nil)
+
+		statement, err := service.Generate(ctx, account.ID, "2025-03", now)
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(80), statement.OpeningBalance)
+		assert.Equal(t, models.NewMoney(78), statement.ClosingBalance)
+		mockLedgerRepo.AssertNotCalled(t, "GetJournal", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Returns The Stored Statement", func(t *testing.T) {
+		service, mockStatementRepo, mockTransactionRepo, _ := setup()
+		stored := models.Statement{ID: uuid.New(), AccountID: account.ID, Period: "2025-03"}
+		mockStatementRepo.On("GetByPeriod", ctx, account.ID, "2025-03").Return(stored, nil)
+
+		statement, err := service.Generate(ctx, account.ID, "2025-03", now)
+		require.NoError(t, err)
+		assert.Equal(t, stored.ID, statement.ID)
+		mockTransactionRepo.AssertNotCalled(t, "GetSettled", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockStatementRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Month Not Ended", func(t *testing.T) {
+		service, _, _, _ := setup()
+
+		_, err := service.Generate(ctx, account.ID, "2025-03", end.Add(-time.Hour))
+		assert.ErrorIs(t, err, models.ErrInvalidStatementPeriod)
+	})
+
+	t.Run("Before The Account Was Opened", func(t *testing.T) {
+		service, _, _, _ := setup()
+
+		_, err := service.Generate(ctx, account.ID, "2024-12", now)
+		assert.ErrorIs(t, err, models.ErrInvalidStatementPeriod)
+	})
+}
+
+func TestStatementService_GenerateDue(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	now := end.Add(time.Hour)
+
+	due := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: start.AddDate(0, 0, 10)}
+	done := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: start.AddDate(0, -1, 0)}
+	opened := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, CreatedAt: end.Add(time.Minute)}
+	closed := models.Account{ID: uuid.New(), Currency: models.DefaultCurrency, Status: models.AccountClosed, CreatedAt: start.AddDate(-1, 0, 0), UpdatedAt: start.AddDate(0, -1, 0)}
+
+	mockStatementRepo := new(mocks.MockStatementRepository)
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockAccountRepo := new(mocks.MockAccountRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockAccountRepo.On("GetAll", ctx).Return(models.Accounts{due, done, opened, closed}, nil)
+	mockAccountRepo.On("GetByID", ctx, due.ID).Return(due, nil)
+	mockStatementRepo.On("GetByPeriod", ctx, done.ID, "2025-03").Return(models.Statement{}, nil)
+	mockStatementRepo.On("GetByPeriod", ctx, due.ID, mock.Anything).Return(models.Statement{}, models.ErrStatementNotFound)
+	mockLedgerRepo.On("GetJournal", ctx, models.OpeningJournalID(due.ID)).Return(models.Journal{}, models.ErrJournalNotFound)
+	mockTransactionRepo.On("GetSettled", ctx, due.ID.String(), mock.Anything, mock.Anything).Return([]models.Transaction{}, nil)
+	mockStatementRepo.On("Create", ctx, mock.AnythingOfType("*models.Statement")).Return(true, nil)
+
+	service := NewStatementService(mockStatementRepo, mockTransactionRepo, mockAccountRepo, mockLedgerRepo)
+	generated, err := service.GenerateDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, generated)
+	mockStatementRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestStatementService_GetDocument(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	statement := models.Statement{ID: uuid.New(), AccountID: accountID, Period: "2025-03"}
+	document := models.StatementDocument{StatementID: statement.ID, Format: models.StatementCSV, Content: []byte("csv")}
+
+	mockStatementRepo := new(mocks.MockStatementRepository)
+	mockStatementRepo.On("GetByID", ctx, statement.ID).Return(statement, nil)
+	mockStatementRepo.On("GetDocument", ctx, statement.ID, models.StatementCSV).Return(document, nil)
+	service := NewStatementService(mockStatementRepo, new(mocks.MockTransactionRepository), new(mocks.MockAccountRepository), new(mocks.MockLedgerRepository))
+
+	_, got, err := service.GetDocument(ctx, accountID, statement.ID, models.StatementCSV)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("csv"), got.Content)
+
+	_, _, err = service.GetDocument(ctx, uuid.New(), statement.ID, models.StatementCSV)
+	assert.ErrorIs(t, err, models.ErrStatementNotFound)
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/RajVerma97/golang-banking-ledger/pkg/queue"
@@ -12,54 +14,351 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+var (
+	ErrConversionFailed         = errors.New("currency conversion failed")
+	ErrTransactionNotReversible = errors.New("transaction cannot be reversed")
+	ErrAlreadyReversed          = errors.New("transaction has already been reversed")
+)
+
+// outboxLease is how long a claimed outbox entry is left to its relay before
+// another attempt may pick it up; maxOutboxBackoff caps the retry delay.
+const (
+	outboxLease      = 30 * time.Second
+	maxOutboxBackoff = 5 * time.Minute
+)
+
 type TransactionService struct {
 	transactionRepo   TransactionRepository
 	accountRepo       AccountRepository
 	rabbitMQPublisher queue.Publisher
+	fxRateRepo        FXRateRepository
+	limits            LimitChecker
+}
+
+// LimitChecker rejects a transaction that would take its account over one of
+// its limits. LimitService implements it.
+type LimitChecker interface {
+	Check(ctx context.Context, account *models.Account, tx *models.Transaction) error
 }
 
 type TransactionRepository interface {
 	Create(ctx context.Context, tx *models.Transaction) error
 	GetByID(ctx context.Context, id string) (*models.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID string) ([]models.Transaction, error)
+	ClaimOutbox(ctx context.Context, now time.Time, lease time.Duration) (*models.Transaction, error)
+	MarkOutboxSent(ctx context.Context, id string, sentAt time.Time) error
+	MarkOutboxFailed(ctx context.Context, id string, nextAttemptAt time.Time, reason string) error
+	ClaimReversal(ctx context.Context, id, current, reversalID string) (bool, error)
+	ReleaseReversal(ctx context.Context, id, reversalID string) error
+	GetScheduled(ctx context.Context, accountID string) ([]models.Transaction, error)
+	ReleaseScheduled(ctx context.Context, now time.Time) (int, error)
+	CancelScheduled(ctx context.Context, id string, now time.Time) (*models.Transaction, error)
 }
 
-func NewTransactionService(transactionRepo TransactionRepository, accountRepo AccountRepository, rabbitMQPublisher queue.Publisher) *TransactionService {
+// TransactionCreator creates the transactions that jobs such as standing
+// orders and interest posting make, and looks up how they ended.
+// TransactionService implements it, so they are checked and queued like any
+// other transaction.
+type TransactionCreator interface {
+	Create(ctx context.Context, tx *models.Transaction) error
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+}
+
+func NewTransactionService(transactionRepo TransactionRepository, accountRepo AccountRepository, rabbitMQPublisher queue.Publisher, fxRateRepo FXRateRepository, limits LimitChecker) *TransactionService {
 	return &TransactionService{
 		transactionRepo:   transactionRepo,
 		accountRepo:       accountRepo,
 		rabbitMQPublisher: rabbitMQPublisher,
+		fxRateRepo:        fxRateRepo,
+		limits:            limits,
 	}
 }
 
 
 func (ts *TransactionService) Create(ctx context.Context, tx *models.Transaction) error {
-	
+	if tx.Type == models.REVERSAL {
+		return errors.New("reversals are created from the transaction they reverse")
+	}
+	if tx.Type == models.CAPTURE {
+		return errors.New("captures are created from the authorization they settle")
+	}
+	if tx.Type == models.CLOSURE {
+		return errors.New("closures are created by closing the account")
+	}
+	tx.Closure = nil
+	if tx.Type != models.DEPOSIT {
+		tx.Interest = nil
+	}
+	tx.ReversalOf = ""
+	tx.ReversedBy = ""
+	tx.ProcessedAt = time.Time{}
+	tx.HoldID = ""
+	tx.FeeTransactionID = ""
+	if tx.Type == models.FEE {
+		if tx.Fee == nil {
+			return errors.New("fee details are required")
+		}
+	} else {
+		tx.Fee = nil
+	}
+
 	accountID, err := uuid.Parse(tx.AccountID)
 	if err != nil {
 		return fmt.Errorf("invalid account ID: %w", err)
 	}
 
 	
-	_, err = ts.accountRepo.GetByID(ctx, accountID)
+	account, err := ts.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return fmt.Errorf("account verification failed: %w", err)
 	}
+	if err := account.CheckTransaction(tx); err != nil {
+		return err
+	}
 
-	
-	err = ts.transactionRepo.Create(ctx, tx)
+	if tx.Currency == "" {
+		tx.Currency = account.Currency
+	}
+	if tx.Currency != account.Currency {
+		return fmt.Errorf("transaction currency %s does not match account currency %s", tx.Currency, account.Currency)
+	}
+
+	if tx.Type == models.TRANSFER {
+		destinationID, err := uuid.Parse(tx.DestinationAccountID)
+		if err != nil {
+			return fmt.Errorf("invalid destination account ID: %w", err)
+		}
+		if destinationID == accountID {
+			return fmt.Errorf("source and destination accounts must differ")
+		}
+		destination, err := ts.accountRepo.GetByID(ctx, destinationID)
+		if err != nil {
+			return fmt.Errorf("destination account verification failed: %w", err)
+		}
+		if err := destination.CheckIncoming(); err != nil {
+			return fmt.Errorf("destination %w", err)
+		}
+		tx.FX = nil
+		if destination.Currency != account.Currency {
+			if err := ts.convert(ctx, tx, destination.Currency); err != nil {
+				return fmt.Errorf("%w: %v", ErrConversionFailed, err)
+			}
+		}
+	}
+
+	if tx.Type == models.ADJUSTMENT {
+		if tx.Adjustment == nil {
+			return errors.New("adjustment details are required")
+		}
+		// Only reconciliation creates corrections; the handler rejects
+		// them from operators with Validate.
+		validate := tx.Adjustment.Validate
+		if models.IsReconciliationAdjustment(tx) {
+			validate = tx.Adjustment.ValidateCorrection
+		}
+		if err := validate(); err != nil {
+			return err
+		}
+	} else {
+		tx.Adjustment = nil
+	}
+
+	if tx.Type == models.AUTHORIZATION {
+		if tx.HoldExpiresAt == nil {
+			expiresAt := time.Now().Add(models.DefaultHoldDuration)
+			tx.HoldExpiresAt = &expiresAt
+		}
+	} else {
+		tx.HoldExpiresAt = nil
+	}
+
+	now := time.Now()
+	if err := tx.ValidateSchedule(now); err != nil {
+		return err
+	}
+	// Checked here rather than by the caller so that transactions created by
+	// jobs, such as standing orders, count against the limits too.
+	if err := ts.limits.Check(ctx, &account, tx); err != nil {
+		return err
+	}
+	if tx.IsScheduled() {
+		// ReleaseScheduled queues the event once the transaction is due.
+		tx.Status = models.SCHEDULED
+		tx.Outbox = nil
+		return ts.transactionRepo.Create(ctx, tx)
+	}
+
+	// The event is queued in the same write as the transaction and published
+	// by RelayOutbox, so a crash cannot leave a transaction nobody processes.
+	tx.Outbox = models.NewOutbox(now)
+	return ts.transactionRepo.Create(ctx, tx)
+}
+
+// ReleaseScheduled hands every scheduled transaction that is due to the
+// outbox relay and returns how many there were.
+func (ts *TransactionService) ReleaseScheduled(ctx context.Context) (int, error) {
+	return ts.transactionRepo.ReleaseScheduled(ctx, time.Now())
+}
+
+// CancelScheduled cancels a scheduled transaction before it runs.
+func (ts *TransactionService) CancelScheduled(ctx context.Context, id string) (*models.Transaction, error) {
+	tx, err := ts.transactionRepo.CancelScheduled(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	withCurrency(tx)
+	return tx, nil
+}
+
+func (ts *TransactionService) GetScheduled(ctx context.Context, accountID string) ([]models.Transaction, error) {
+	return ts.transactionRepo.GetScheduled(ctx, accountID)
+}
+
+// Reverse creates a REVERSAL that undoes a successful transaction once the
+// worker posts it. The original is linked to the reversal first, with a
+// conditional update, so two concurrent requests cannot both reverse it.
+func (ts *TransactionService) Reverse(ctx context.Context, id string) (*models.Transaction, error) {
+	original, err := ts.transactionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original.Type == models.REVERSAL {
+		return nil, fmt.Errorf("%w: a reversal cannot itself be reversed", ErrTransactionNotReversible)
+	}
+	if original.Type == models.CLOSURE {
+		return nil, fmt.Errorf("%w: a closed account cannot be reopened", ErrTransactionNotReversible)
+	}
+	if original.Type == models.AUTHORIZATION {
+		return nil, fmt.Errorf("%w: an authorization moves no money; void its hold instead", ErrTransactionNotReversible)
+	}
+	if original.Status != models.SUCCESS {
+		return nil, fmt.Errorf("%w: transaction is %s", ErrTransactionNotReversible, original.Status)
+	}
+	withCurrency(original)
+
+	current := original.ReversedBy
+	if current != "" {
+		_, err := ts.transactionRepo.GetByID(ctx, current)
+		if err == nil {
+			return nil, ErrAlreadyReversed
+		}
+		if !errors.Is(err, models.ErrTransactionNotFound) {
+			return nil, err
+		}
+		// The link was left by a request that failed before storing its
+		// reversal, so this request takes it over.
+	}
+
+	now := time.Now()
+	reversal := &models.Transaction{
+		ID:         uuid.New().String(),
+		Type:       models.REVERSAL,
+		Amount:     original.Amount,
+		Currency:   original.Currency,
+		AccountID:  original.AccountID,
+		Status:     models.PENDING,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ReversalOf: original.ID,
+		Outbox:     models.NewOutbox(now),
+	}
+
+	claimed, err := ts.transactionRepo.ClaimReversal(ctx, original.ID, current, reversal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrAlreadyReversed
+	}
+
+	if err := ts.transactionRepo.Create(ctx, reversal); err != nil {
+		if releaseErr := ts.transactionRepo.ReleaseReversal(ctx, original.ID, reversal.ID); releaseErr != nil {
+			log.Printf("Failed to release reversal claim on transaction %s: %v", original.ID, releaseErr)
+		}
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// RelayOutbox publishes every transaction event that is due and returns how
+// many were sent. Delivery is at least once: an event published just before a
+// crash is published again, and the worker ignores transactions it has already
+// processed. A failed publish is retried later with exponential backoff.
+func (ts *TransactionService) RelayOutbox(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		tx, err := ts.transactionRepo.ClaimOutbox(ctx, time.Now(), outboxLease)
+		if err != nil || tx == nil {
+			return sent, err
+		}
+
+		if err := ts.PublishTransactionEvent(ctx, tx); err != nil {
+			retryAt := time.Now().Add(outboxBackoff(tx.Outbox.Attempts))
+			if markErr := ts.transactionRepo.MarkOutboxFailed(ctx, tx.ID, retryAt, err.Error()); markErr != nil {
+				log.Printf("Failed to record outbox failure for transaction %s: %v", tx.ID, markErr)
+			}
+			return sent, fmt.Errorf("failed to publish transaction %s: %w", tx.ID, err)
+		}
+		if err := ts.transactionRepo.MarkOutboxSent(ctx, tx.ID, time.Now()); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	if attempts > 9 {
+		return maxOutboxBackoff
+	}
+	backoff := time.Second << attempts
+	if backoff > maxOutboxBackoff {
+		return maxOutboxBackoff
+	}
+	return backoff
+}
+// convert prices a cross-currency transfer at the rate in effect now and
+// records the conversion on the transaction, so the worker applies exactly
+// the amounts the customer was quoted.
+func (ts *TransactionService) convert(ctx context.Context, tx *models.Transaction, target models.Currency) error {
+	rate, err := ts.fxRateRepo.GetEffective(ctx, tx.Currency, target, time.Now())
 	if err != nil {
 		return err
 	}
 
-	return ts.PublishTransactionEvent(ctx, tx)
+	conversion := rate.Convert(tx.Amount)
+	if conversion.TargetAmount <= 0 {
+		return fmt.Errorf("amount %s %s is too small to convert", tx.Amount, tx.Currency)
+	}
+	tx.FX = &conversion
+	return nil
 }
+
 func (ts *TransactionService) GetByID(ctx context.Context, id string) (*models.Transaction, error) {
-	return ts.transactionRepo.GetByID(ctx, id)
+	tx, err := ts.transactionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	withCurrency(tx)
+	return tx, nil
 }
 
 func (ts *TransactionService) GetByAccountID(ctx context.Context, accountID string) ([]models.Transaction, error) {
-	return ts.transactionRepo.GetByAccountID(ctx, accountID)
+	transactions, err := ts.transactionRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transactions {
+		withCurrency(&transactions[i])
+	}
+	return transactions, nil
+}
+
+// withCurrency fills in the currency of transactions recorded before the
+// ledger tracked currencies, all of which were in the default currency.
+func withCurrency(tx *models.Transaction) {
+	if tx.Currency == "" {
+		tx.Currency = models.DefaultCurrency
+	}
 }
 func (ts *TransactionService) PublishTransactionEvent(ctx context.Context, transaction *models.Transaction) error {
 	body, err := json.Marshal(transaction)
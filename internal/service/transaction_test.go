@@ -2,14 +2,18 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
 	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
 	queue_mocks "github.com/RajVerma97/golang-banking-ledger/pkg/queue/mocks"
 	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransactionService_Create(t *testing.T) {
@@ -19,29 +23,626 @@ func TestTransactionService_Create(t *testing.T) {
 	mockAccountRepo := new(mocks.MockAccountRepository)
 	mockPublisher := new(queue_mocks.MockPublisher)
 
-	service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher)
+	service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
 
 	ctx := context.Background()
 	tx := &models.Transaction{
 		ID:        uuid.New().String(),
 		Type:      models.DEPOSIT,
-		Amount:    100.0,
+		Amount:    models.NewMoney(100),
 		AccountID: accountID.String(),
 		Status:    models.PENDING,
 	}
 
 	mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{
 		ID:      accountID,
-		Balance: 500.0,
+		Balance: models.NewMoney(500),
 	}, nil)
 
 	mockTransactionRepo.On("Create", ctx, tx).Return(nil)
-	mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(nil)
 
 	err := service.Create(ctx, tx)
 
 	assert.NoError(t, err)
+	require.NotNil(t, tx.Outbox)
+	assert.Equal(t, models.OutboxPending, tx.Outbox.Status)
 	mockTransactionRepo.AssertExpectations(t)
 	mockAccountRepo.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransactionService_CreateClearsServerFields(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	mockAccountRepo := new(mocks.MockAccountRepository)
+	service := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+	mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Balance: models.NewMoney(500)}, nil)
+	mockTransactionRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+
+	tx := &models.Transaction{
+		ID:          uuid.New().String(),
+		Type:        models.DEPOSIT,
+		Amount:      models.NewMoney(100),
+		AccountID:   accountID.String(),
+		Status:      models.PENDING,
+		ReversalOf:  uuid.New().String(),
+		ReversedBy:  uuid.New().String(),
+		ProcessedAt: time.Now(),
+	}
+	require.NoError(t, service.Create(ctx, tx))
+	assert.Empty(t, tx.ReversalOf)
+	assert.Empty(t, tx.ReversedBy)
+	assert.True(t, tx.ProcessedAt.IsZero())
+}
+
+func TestTransactionService_CreateAccountStatus(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	create := func(status models.AccountStatus, txType models.TransactionType) (*mocks.MockTransactionRepository, error) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{
+			ID:       accountID,
+			Balance:  models.NewMoney(500),
+			Currency: models.DefaultCurrency,
+			Status:   status,
+		}, nil)
+		mockTransactionRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		return mockTransactionRepo, service.Create(ctx, &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      txType,
+			Amount:    models.NewMoney(100),
+			AccountID: accountID.String(),
+			Status:    models.PENDING,
+		})
+	}
+
+	t.Run("Frozen Account Rejects Withdrawal", func(t *testing.T) {
+		mockTransactionRepo, err := create(models.AccountFrozen, models.WITHDRAWL)
+		assert.ErrorIs(t, err, models.ErrAccountFrozen)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Frozen Account Accepts Deposit", func(t *testing.T) {
+		_, err := create(models.AccountFrozen, models.DEPOSIT)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Closed Account Rejects Deposit", func(t *testing.T) {
+		_, err := create(models.AccountClosed, models.DEPOSIT)
+		assert.ErrorIs(t, err, models.ErrAccountClosed)
+	})
+}
+
+func TestTransactionService_CreateScheduled(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	setup := func() (*TransactionService, *mocks.MockTransactionRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{
+			ID:       accountID,
+			Currency: models.DefaultCurrency,
+			Status:   models.AccountActive,
+		}, nil)
+		return NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo)), mockTransactionRepo
+	}
+	scheduled := func(executeAt time.Time) *models.Transaction {
+		return &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.WITHDRAWL,
+			Amount:    models.NewMoney(100),
+			AccountID: accountID.String(),
+			Status:    models.PENDING,
+			ExecuteAt: &executeAt,
+		}
+	}
+
+	t.Run("Stored As Scheduled Without An Event", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		tx := scheduled(time.Now().Add(48 * time.Hour))
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+
+		require.NoError(t, service.Create(ctx, tx))
+		assert.Equal(t, models.SCHEDULED, tx.Status)
+		assert.Nil(t, tx.Outbox)
+	})
+
+	t.Run("Execution Time In The Past", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+
+		err := service.Create(ctx, scheduled(time.Now().Add(-time.Minute)))
+		assert.ErrorIs(t, err, models.ErrInvalidSchedule)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransactionService_CancelScheduled(t *testing.T) {
+	ctx := context.Background()
+	mockTransactionRepo := new(mocks.MockTransactionRepository)
+	service := NewTransactionService(mockTransactionRepo, new(mocks.MockAccountRepository), new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+	cancelled := &models.Transaction{ID: uuid.New().String(), Type: models.DEPOSIT, Status: models.CANCELLED}
+	mockTransactionRepo.On("CancelScheduled", ctx, cancelled.ID, mock.Anything).Return(cancelled, nil)
+	mockTransactionRepo.On("CancelScheduled", ctx, "released", mock.Anything).Return(nil, models.ErrTransactionNotScheduled)
+
+	tx, err := service.CancelScheduled(ctx, cancelled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CANCELLED, tx.Status)
+	assert.Equal(t, models.DefaultCurrency, tx.Currency)
+
+	_, err = service.CancelScheduled(ctx, "released")
+	assert.ErrorIs(t, err, models.ErrTransactionNotScheduled)
+}
+
+func TestTransactionService_CreateCurrency(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	t.Run("Defaults To Account Currency", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.DEPOSIT,
+			Amount:    models.NewMoney(10),
+			AccountID: accountID.String(),
+		}
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: "EUR"}, nil)
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(nil)
+
+		require.NoError(t, service.Create(ctx, tx))
+		assert.Equal(t, models.Currency("EUR"), tx.Currency)
+	})
+
+	t.Run("Currency Mismatch", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.DEPOSIT,
+			Amount:    models.NewMoney(10),
+			AccountID: accountID.String(),
+			Currency:  "GBP",
+		}
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: "EUR"}, nil)
+
+		err := service.Create(ctx, tx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match account currency")
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("History Shows Currency", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		service := NewTransactionService(mockTransactionRepo, new(mocks.MockAccountRepository), new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		mockTransactionRepo.On("GetByAccountID", ctx, accountID.String()).Return([]models.Transaction{
+			{ID: "legacy", AccountID: accountID.String()},
+			{ID: "current", AccountID: accountID.String(), Currency: "EUR"},
+		}, nil)
+
+		txs, err := service.GetByAccountID(ctx, accountID.String())
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultCurrency, txs[0].Currency)
+		assert.Equal(t, models.Currency("EUR"), txs[1].Currency)
+	})
+}
+
+func TestTransactionService_CreateTransfer(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	destinationID := uuid.New()
+
+	t.Run("Destination Not Found", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 models.TRANSFER,
+			Amount:               models.NewMoney(50),
+			AccountID:            sourceID.String(),
+			DestinationAccountID: destinationID.String(),
+		}
+
+		mockAccountRepo.On("GetByID", ctx, sourceID).Return(models.Account{ID: sourceID}, nil)
+		mockAccountRepo.On("GetByID", ctx, destinationID).Return(models.Account{}, assert.AnError)
+
+		err := service.Create(ctx, tx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "destination account verification failed")
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Same Account", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 models.TRANSFER,
+			Amount:               models.NewMoney(50),
+			AccountID:            sourceID.String(),
+			DestinationAccountID: sourceID.String(),
+		}
+
+		mockAccountRepo.On("GetByID", ctx, sourceID).Return(models.Account{ID: sourceID}, nil)
+
+		err := service.Create(ctx, tx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must differ")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 models.TRANSFER,
+			Amount:               models.NewMoney(50),
+			AccountID:            sourceID.String(),
+			DestinationAccountID: destinationID.String(),
+		}
+
+		mockAccountRepo.On("GetByID", ctx, sourceID).Return(models.Account{ID: sourceID}, nil)
+		mockAccountRepo.On("GetByID", ctx, destinationID).Return(models.Account{ID: destinationID}, nil)
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(nil)
+
+		err := service.Create(ctx, tx)
+		assert.NoError(t, err)
+		mockAccountRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+}
+
+func TestTransactionService_CreateConvertedTransfer(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	destinationID := uuid.New()
+
+	newTransfer := func() *models.Transaction {
+		return &models.Transaction{
+			ID:                   uuid.New().String(),
+			Type:                 models.TRANSFER,
+			Amount:               models.NewMoney(100),
+			AccountID:            sourceID.String(),
+			DestinationAccountID: destinationID.String(),
+		}
+	}
+
+	t.Run("Records Conversion", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		mockFXRateRepo := new(mocks.MockFXRateRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, mockFXRateRepo, withoutLimits(mockTransactionRepo))
+
+		rate := models.FXRate{ID: uuid.New(), BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: models.Rate(90000000), SpreadBps: 100}
+		tx := newTransfer()
+
+		mockAccountRepo.On("GetByID", ctx, sourceID).Return(models.Account{ID: sourceID, Currency: "USD"}, nil)
+		mockAccountRepo.On("GetByID", ctx, destinationID).Return(models.Account{ID: destinationID, Currency: "EUR"}, nil)
+		mockFXRateRepo.On("GetEffective", ctx, models.Currency("USD"), models.Currency("EUR"), mock.Anything).Return(rate, nil)
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(nil)
+
+		require.NoError(t, service.Create(ctx, tx))
+		require.NotNil(t, tx.FX)
+		assert.Equal(t, rate.ID, tx.FX.RateID)
+		assert.Equal(t, models.NewMoney(100), tx.FX.SourceAmount)
+		assert.Equal(t, models.MustParseMoney("89.10"), tx.FX.TargetAmount)
+		assert.Equal(t, models.MustParseMoney("0.90"), tx.FX.SpreadAmount)
+	})
+
+	t.Run("No Rate", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		mockFXRateRepo := new(mocks.MockFXRateRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, mockPublisher, mockFXRateRepo, withoutLimits(mockTransactionRepo))
+
+		tx := newTransfer()
+
+		mockAccountRepo.On("GetByID", ctx, sourceID).Return(models.Account{ID: sourceID, Currency: "USD"}, nil)
+		mockAccountRepo.On("GetByID", ctx, destinationID).Return(models.Account{ID: destinationID, Currency: "EUR"}, nil)
+		mockFXRateRepo.On("GetEffective", ctx, models.Currency("USD"), models.Currency("EUR"), mock.Anything).
+			Return(models.FXRate{}, assert.AnError)
+
+		err := service.Create(ctx, tx)
+		assert.ErrorIs(t, err, ErrConversionFailed)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransactionService_CreateAdjustment(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	t.Run("Missing Justification", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.ADJUSTMENT,
+			Amount:    models.NewMoney(20),
+			AccountID: accountID.String(),
+			Adjustment: &models.Adjustment{
+				Direction:  models.CREDIT,
+				ReasonCode: models.AdjustmentGoodwill,
+				OperatorID: "ops-17",
+			},
+		}
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency}, nil)
+
+		err := service.Create(ctx, tx)
+		assert.ErrorContains(t, err, "justification")
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.ADJUSTMENT,
+			Amount:    models.NewMoney(20),
+			AccountID: accountID.String(),
+			Adjustment: &models.Adjustment{
+				Direction:     models.DEBIT,
+				ReasonCode:    models.AdjustmentBankError,
+				Justification: "interest credited twice in May",
+				OperatorID:    "ops-17",
+			},
+		}
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency}, nil)
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+
+		require.NoError(t, service.Create(ctx, tx))
+		assert.Equal(t, "ops-17", tx.Adjustment.OperatorID)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Details Dropped From Other Types", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockAccountRepo := new(mocks.MockAccountRepository)
+		service := NewTransactionService(mockTransactionRepo, mockAccountRepo, new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := &models.Transaction{
+			ID:         uuid.New().String(),
+			Type:       models.DEPOSIT,
+			Amount:     models.NewMoney(20),
+			AccountID:  accountID.String(),
+			Adjustment: &models.Adjustment{Direction: models.CREDIT},
+		}
+
+		mockAccountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency}, nil)
+		mockTransactionRepo.On("Create", ctx, tx).Return(nil)
+
+		require.NoError(t, service.Create(ctx, tx))
+		assert.Nil(t, tx.Adjustment)
+	})
+}
+
+func TestTransactionService_Reverse(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	successful := func() *models.Transaction {
+		return &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.DEPOSIT,
+			Amount:    models.NewMoney(40),
+			Currency:  models.DefaultCurrency,
+			AccountID: accountID.String(),
+			Status:    models.SUCCESS,
+		}
+	}
+	setup := func() (*TransactionService, *mocks.MockTransactionRepository) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		service := NewTransactionService(mockTransactionRepo, new(mocks.MockAccountRepository), new(queue_mocks.MockPublisher), new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+		return service, mockTransactionRepo
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+		mockTransactionRepo.On("ClaimReversal", ctx, original.ID, "", mock.Anything).Return(true, nil)
+		mockTransactionRepo.On("Create", ctx, mock.MatchedBy(func(tx *models.Transaction) bool {
+			return tx.Type == models.REVERSAL && tx.ReversalOf == original.ID && tx.Outbox != nil
+		})).Return(nil)
+
+		reversal, err := service.Reverse(ctx, original.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PENDING, reversal.Status)
+		assert.Equal(t, original.Amount, reversal.Amount)
+		assert.Equal(t, original.AccountID, reversal.AccountID)
+		mockTransactionRepo.AssertCalled(t, "ClaimReversal", ctx, original.ID, "", reversal.ID)
+	})
+
+	t.Run("Not Successful", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.Status = models.PENDING
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrTransactionNotReversible)
+	})
+
+	t.Run("Closure", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.Type = models.CLOSURE
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrTransactionNotReversible)
+	})
+
+	t.Run("Authorization", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.Type = models.AUTHORIZATION
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrTransactionNotReversible)
+		mockTransactionRepo.AssertNotCalled(t, "ClaimReversal", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reversal Of Reversal", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.Type = models.REVERSAL
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrTransactionNotReversible)
+	})
+
+	t.Run("Already Reversed", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.ReversedBy = uuid.New().String()
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+		mockTransactionRepo.On("GetByID", ctx, original.ReversedBy).Return(&models.Transaction{ID: original.ReversedBy}, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrAlreadyReversed)
+		mockTransactionRepo.AssertNotCalled(t, "ClaimReversal", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Concurrent Reversal Wins", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+		mockTransactionRepo.On("ClaimReversal", ctx, original.ID, "", mock.Anything).Return(false, nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, ErrAlreadyReversed)
+		mockTransactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Takes Over Link To Missing Reversal", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+		original.ReversedBy = uuid.New().String()
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+		mockTransactionRepo.On("GetByID", ctx, original.ReversedBy).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		mockTransactionRepo.On("ClaimReversal", ctx, original.ID, original.ReversedBy, mock.Anything).Return(true, nil)
+		mockTransactionRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Failed Create Releases Claim", func(t *testing.T) {
+		service, mockTransactionRepo := setup()
+		original := successful()
+
+		mockTransactionRepo.On("GetByID", ctx, original.ID).Return(original, nil)
+		mockTransactionRepo.On("ClaimReversal", ctx, original.ID, "", mock.Anything).Return(true, nil)
+		mockTransactionRepo.On("Create", ctx, mock.Anything).Return(assert.AnError)
+		mockTransactionRepo.On("ReleaseReversal", ctx, original.ID, mock.Anything).Return(nil)
+
+		_, err := service.Reverse(ctx, original.ID)
+		assert.ErrorIs(t, err, assert.AnError)
+		mockTransactionRepo.AssertExpectations(t)
+	})
+}
+
+func TestTransactionService_RelayOutbox(t *testing.T) {
+	ctx := context.Background()
+
+	pending := func() *models.Transaction {
+		outbox := models.NewOutbox(time.Now())
+		outbox.Attempts = 1
+		return &models.Transaction{ID: uuid.New().String(), Type: models.DEPOSIT, Outbox: outbox}
+	}
+
+	t.Run("Publishes Due Events", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, new(mocks.MockAccountRepository), mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		first, second := pending(), pending()
+		mockTransactionRepo.On("ClaimOutbox", ctx, mock.Anything, outboxLease).Return(first, nil).Once()
+		mockTransactionRepo.On("ClaimOutbox", ctx, mock.Anything, outboxLease).Return(second, nil).Once()
+		mockTransactionRepo.On("ClaimOutbox", ctx, mock.Anything, outboxLease).Return(nil, nil).Once()
+		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.MatchedBy(func(msg amqp.Publishing) bool {
+			return !strings.Contains(string(msg.Body), "outbox")
+		})).Return(nil).Twice()
+		mockTransactionRepo.On("MarkOutboxSent", ctx, first.ID, mock.Anything).Return(nil)
+		mockTransactionRepo.On("MarkOutboxSent", ctx, second.ID, mock.Anything).Return(nil)
+
+		sent, err := service.RelayOutbox(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		mockTransactionRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("Failed Publish Is Retried Later", func(t *testing.T) {
+		mockTransactionRepo := new(mocks.MockTransactionRepository)
+		mockPublisher := new(queue_mocks.MockPublisher)
+		service := NewTransactionService(mockTransactionRepo, new(mocks.MockAccountRepository), mockPublisher, new(mocks.MockFXRateRepository), withoutLimits(mockTransactionRepo))
+
+		tx := pending()
+		mockTransactionRepo.On("ClaimOutbox", ctx, mock.Anything, outboxLease).Return(tx, nil).Once()
+		mockPublisher.On("Publish", "", "transaction_queue", false, false, mock.Anything).Return(assert.AnError)
+		mockTransactionRepo.On("MarkOutboxFailed", ctx, tx.ID, mock.MatchedBy(func(retryAt time.Time) bool {
+			return retryAt.After(time.Now())
+		}), assert.AnError.Error()).Return(nil)
+
+		sent, err := service.RelayOutbox(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 0, sent)
+		mockTransactionRepo.AssertExpectations(t)
+		mockTransactionRepo.AssertNotCalled(t, "MarkOutboxSent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		assert.Equal(t, 2*time.Second, outboxBackoff(1))
+		assert.Equal(t, 16*time.Second, outboxBackoff(4))
+		assert.Equal(t, maxOutboxBackoff, outboxBackoff(20))
+	})
 }
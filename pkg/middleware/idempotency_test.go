@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	repo "github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const body = `{"amount": 10}`
+
+	setup := func(store *repo.MockIdempotencyRepository) (*gin.Engine, *int) {
+		calls := 0
+		r := gin.New()
+		r.POST("/transaction", Idempotency(store, time.Hour), func(c *gin.Context) {
+			calls++
+			c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+		})
+		return r, &calls
+	}
+
+	send := func(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/transaction", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	stored := func(body string) *models.IdempotencyKey {
+		req := httptest.NewRequest(http.MethodPost, "/transaction", nil)
+		return &models.IdempotencyKey{
+			Key:          "key-1",
+			Fingerprint:  fingerprint(req, []byte(body)),
+			Completed:    true,
+			StatusCode:   http.StatusCreated,
+			ResponseBody: []byte(`{"id":"tx-1"}`),
+		}
+	}
+
+	t.Run("No Key", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r, calls := setup(store)
+
+		w := send(r, "", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, *calls)
+		store.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+	})
+
+	t.Run("First Request Stores Response", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r, calls := setup(store)
+
+		store.On("Reserve", mock.Anything, mock.MatchedBy(func(k *models.IdempotencyKey) bool {
+			return k.Key == "key-1" && k.ExpiresAt.After(time.Now())
+		})).Return(nil, true, nil)
+		store.On("Complete", mock.Anything, "key-1", http.StatusCreated, []byte(`{"id":"tx-1"}`)).Return(nil)
+
+		w := send(r, "key-1", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, *calls)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("Replay Returns Stored Response", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r, calls := setup(store)
+
+		store.On("Reserve", mock.Anything, mock.Anything).Return(stored(body), false, nil)
+
+		w := send(r, "key-1", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, `{"id":"tx-1"}`, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("Different Request Conflicts", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r, calls := setup(store)
+
+		store.On("Reserve", mock.Anything, mock.Anything).Return(stored(body), false, nil)
+
+		w := send(r, "key-1", `{"amount": 20}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "different request")
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("Request In Progress Conflicts", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r, calls := setup(store)
+
+		pending := stored(body)
+		pending.Completed = false
+		store.On("Reserve", mock.Anything, mock.Anything).Return(pending, false, nil)
+
+		w := send(r, "key-1", body)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "in progress")
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("Server Error Releases Key", func(t *testing.T) {
+		store := new(repo.MockIdempotencyRepository)
+		r := gin.New()
+		r.POST("/transaction", Idempotency(store, time.Hour), func(c *gin.Context) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create transaction"})
+		})
+
+		store.On("Reserve", mock.Anything, mock.Anything).Return(nil, true, nil)
+		store.On("Release", mock.Anything, "key-1").Return(nil)
+
+		w := send(r, "key-1", body)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		store.AssertExpectations(t)
+		store.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
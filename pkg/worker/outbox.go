@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+)
+
+// OutboxRelay delivers queued transaction events to RabbitMQ. Events live in
+// the transactions collection, so the relay keeps no state of its own and can
+// be stopped and restarted at any point.
+type OutboxRelay struct {
+	transactionService *service.TransactionService
+	interval           time.Duration
+}
+
+func NewOutboxRelay(transactionService *service.TransactionService, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		transactionService: transactionService,
+		interval:           interval,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := r.transactionService.RelayOutbox(ctx)
+		if err != nil {
+			log.Printf("Outbox relay: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Outbox relay published %d transaction events", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/service"
+)
+
+// Scheduler releases scheduled transactions when they fall due and runs the
+// standing orders that are due. A released transaction becomes PENDING with a
+// pending outbox event, and the outbox relay publishes it to
+// transaction_queue.
+type Scheduler struct {
+	transactionService   *service.TransactionService
+	standingOrderService *service.StandingOrderService
+	interval             time.Duration
+}
+
+func NewScheduler(transactionService *service.TransactionService, standingOrderService *service.StandingOrderService, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		transactionService:   transactionService,
+		standingOrderService: standingOrderService,
+		interval:             interval,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := s.transactionService.ReleaseScheduled(ctx)
+		if err != nil {
+			log.Printf("Scheduler: %v", err)
+		}
+		if released > 0 {
+			log.Printf("Scheduler released %d scheduled transactions", released)
+		}
+
+		ran, err := s.standingOrderService.RunDue(ctx)
+		if err != nil {
+			log.Printf("Scheduler: %v", err)
+		}
+		if ran > 0 {
+			log.Printf("Scheduler ran %d standing orders", ran)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
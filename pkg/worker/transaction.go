@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/RajVerma97/golang-banking-ledger/internal/models"
-	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mongodb"
 	"github.com/RajVerma97/golang-banking-ledger/internal/repository/postgres"
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -17,17 +16,61 @@ import (
 
 type Worker struct {
 	rabbitMQChannel *amqp.Channel
-	accountRepo     *postgres.AccountRepository
-	transactionRepo *mongodb.TransactionRepository
+	accountRepo     AccountRepository
+	transactionRepo TransactionRepository
+	ledgerRepo      LedgerRepository
+	holdRepo        HoldRepository
+	feeRepo         FeeRuleRepository
+	interestRepo    InterestRepository
+}
+
+type AccountRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models.Account, error)
+	Close(ctx context.Context, journal *models.Journal, change *models.AccountStatusChange) error
+}
+
+type TransactionRepository interface {
+	Create(ctx context.Context, tx *models.Transaction) error
+	GetByID(ctx context.Context, id string) (*models.Transaction, error)
+	Update(ctx context.Context, id string, tx *models.Transaction) error
+	ReleaseReversal(ctx context.Context, id, reversalID string) error
+	HasOtherPending(ctx context.Context, accountID, transactionID string) (bool, error)
+}
+
+type LedgerRepository interface {
+	PostJournal(ctx context.Context, journal *models.Journal) error
+	PostJournals(ctx context.Context, journals ...*models.Journal) error
+}
+
+type HoldRepository interface {
+	Place(ctx context.Context, hold *models.Hold) error
+	Capture(ctx context.Context, holdID string, journal *models.Journal) error
+	ReleaseCaptureClaim(ctx context.Context, holdID, captureTransactionID string) error
+}
+
+type FeeRuleRepository interface {
+	GetActive(ctx context.Context, kind models.FeeKind) ([]models.FeeRule, error)
+}
+
+type InterestRepository interface {
+	ReleasePosting(ctx context.Context, accountID uuid.UUID, periodEnd time.Time, transactionID string) error
 }
 
 func NewTransactionWorker(rabbitMQChannel *amqp.Channel,
-	accountRepo *postgres.AccountRepository,
-	transactionRepo *mongodb.TransactionRepository) *Worker {
+	accountRepo AccountRepository,
+	transactionRepo TransactionRepository,
+	ledgerRepo LedgerRepository,
+	holdRepo HoldRepository,
+	feeRepo FeeRuleRepository,
+	interestRepo InterestRepository) *Worker {
 	return &Worker{
 		rabbitMQChannel: rabbitMQChannel,
 		accountRepo:     accountRepo,
 		transactionRepo: transactionRepo,
+		ledgerRepo:      ledgerRepo,
+		holdRepo:        holdRepo,
+		feeRepo:         feeRepo,
+		interestRepo:    interestRepo,
 	}
 }
 
@@ -86,7 +129,21 @@ func (w *Worker) handleTransaction(tx *models.Transaction) error {
 		log.Printf("Account not found: %s", accountID)
 		tx.Status = models.FAILED
 		w.updateTransaction(ctx, tx)
-		return errors.New("account not found")
+		return models.ErrAccountNotFound
+	}
+
+	if err := w.checkStatus(ctx, tx, &account); err != nil {
+		log.Printf("Status check failed for transaction %s: %v", tx.ID, err)
+		tx.Status = models.FAILED
+		w.updateTransaction(ctx, tx)
+		return err
+	}
+
+	if err := w.checkCurrency(ctx, tx, &account); err != nil {
+		log.Printf("Currency check failed for transaction %s: %v", tx.ID, err)
+		tx.Status = models.FAILED
+		w.updateTransaction(ctx, tx)
+		return err
 	}
 
 	if err := w.processTransactionLogic(tx, &account); err != nil {
@@ -101,38 +158,287 @@ func (w *Worker) handleTransaction(tx *models.Transaction) error {
 
 	return nil
 }
+// checkStatus rejects a transaction that the status of an account it moves
+// money between does not allow. The status is checked again here because it
+// may have changed since the transaction was accepted.
+func (w *Worker) checkStatus(ctx context.Context, tx *models.Transaction, account *models.Account) error {
+	// A closure redelivered after it closed the account is left for Close to
+	// recognise by its journal.
+	redelivered := tx.Type == models.CLOSURE && account.Status == models.AccountClosed
+	if err := account.CheckTransaction(tx); err != nil && !redelivered {
+		return err
+	}
+
+	// A reversal of a transfer takes the money back from its destination, and a
+	// closure pays the balance out to its payout account.
+	counterparty := tx.DestinationAccountID
+	if tx.Type == models.CLOSURE && tx.Closure != nil {
+		counterparty = tx.Closure.PayoutAccountID
+	}
+	if tx.Type == models.REVERSAL {
+		original, err := w.transactionRepo.GetByID(ctx, tx.ReversalOf)
+		if err != nil {
+			return fmt.Errorf("original transaction %s: %w", tx.ReversalOf, err)
+		}
+		counterparty = original.DestinationAccountID
+	}
+	if counterparty == "" {
+		return nil
+	}
+	destinationID, err := uuid.Parse(counterparty)
+	if err != nil {
+		return fmt.Errorf("invalid destination account ID: %w", err)
+	}
+	destination, err := w.accountRepo.GetByID(ctx, destinationID)
+	if err != nil {
+		return errors.New("destination account not found")
+	}
+	if err := destination.CheckIncoming(); err != nil {
+		return fmt.Errorf("destination %w", err)
+	}
+	return nil
+}
+
+// checkCurrency rejects a transaction whose currency differs from the accounts
+// it moves money between. A converted transfer must credit the destination in
+// the currency it was converted to.
+func (w *Worker) checkCurrency(ctx context.Context, tx *models.Transaction, account *models.Account) error {
+	if tx.Currency == "" {
+		tx.Currency = models.DefaultCurrency
+	}
+	if tx.Currency != account.Currency {
+		return fmt.Errorf("transaction currency %s does not match account currency %s", tx.Currency, account.Currency)
+	}
+
+	destinationAccountID := tx.DestinationAccountID
+	if tx.Type == models.CLOSURE && tx.Closure != nil && tx.Closure.PayoutAccountID != "" {
+		destinationAccountID = tx.Closure.PayoutAccountID
+	} else if tx.Type != models.TRANSFER {
+		return nil
+	}
+	destinationID, err := uuid.Parse(destinationAccountID)
+	if err != nil {
+		return fmt.Errorf("invalid destination account ID: %w", err)
+	}
+	destination, err := w.accountRepo.GetByID(ctx, destinationID)
+	if err != nil {
+		return errors.New("destination account not found")
+	}
+	targetCurrency := tx.Currency
+	if tx.FX != nil {
+		targetCurrency = tx.FX.TargetCurrency
+	}
+	if destination.Currency != targetCurrency {
+		return fmt.Errorf("transaction currency %s does not match destination account currency %s", targetCurrency, destination.Currency)
+	}
+	return nil
+}
+
 func (w *Worker) processTransactionLogic(tx *models.Transaction, account *models.Account) error {
+	if tx.Type == models.AUTHORIZATION {
+		return w.placeHold(tx, account)
+	}
 
+	journal, err := w.buildJournal(tx)
+	if err != nil {
+		log.Printf("Unable to build journal for transaction %s: %v", tx.ID, err)
+		return err
+	}
+
+	change := journal.NetChange(models.CustomerLedgerAccount(account.ID))
+
+	// The funds check happens inside PostJournal, against the balance at the
+	// moment it changes, not the balance read when processing started. A
+	// capture posts together with settling its hold, a closure together with
+	// closing its account, and anything else together with its fee.
 	switch tx.Type {
-	case models.DEPOSIT:
-		account.Balance += tx.Amount
-	case models.WITHDRAWL:
-		if account.Balance < tx.Amount {
-			log.Printf("Insufficient funds: current balance %f, withdrawal amount %f", account.Balance, tx.Amount)
-			return errors.New("insufficient funds")
-		}
-		account.Balance -= tx.Amount
+	case models.CAPTURE:
+		err = w.holdRepo.Capture(context.Background(), tx.HoldID, &journal)
+	case models.CLOSURE:
+		err = w.closeAccount(tx, account, &journal)
 	default:
-		log.Printf("Unknown transaction type: %s", tx.Type)
-		return errors.New("invalid transaction type")
+		err = w.postWithFee(tx, account, &journal)
+	}
+	if errors.Is(err, postgres.ErrJournalAlreadyPosted) {
+		log.Printf("Journal for transaction %s was already posted", tx.ID)
+		return nil
+	}
+	if errors.Is(err, postgres.ErrInsufficientFunds) {
+		log.Printf("Insufficient funds: account %s cannot be debited %s", account.ID, tx.Amount)
+		return err
+	}
+	if err != nil {
+		log.Printf("Failed to post journal: %v", err)
+		return fmt.Errorf("failed to post journal: %w", err)
+	}
+
+	log.Printf("Successfully posted journal %s, balance of account %s changed by %s", journal.ID, account.ID, change)
+	return nil
+}
+
+// closeAccount pays out a closure's balance and closes its account. A hold
+// placed or a transaction accepted since the closure was requested would be
+// stranded on a closed account, so the closure fails instead; Close checks the
+// holds again under the account's lock.
+func (w *Worker) closeAccount(tx *models.Transaction, account *models.Account, journal *models.Journal) error {
+	ctx := context.Background()
+	if account.Status != models.AccountClosed {
+		if account.HeldBalance != 0 {
+			return models.ErrPendingTransactions
+		}
+		pending, err := w.transactionRepo.HasOtherPending(ctx, account.ID.String(), tx.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check pending transactions: %w", err)
+		}
+		if pending {
+			return models.ErrPendingTransactions
+		}
+	}
+	return w.accountRepo.Close(ctx, journal, &models.AccountStatusChange{
+		ID:         uuid.New(),
+		AccountID:  account.ID,
+		Reason:     tx.Closure.Reason,
+		OperatorID: tx.Closure.OperatorID,
+	})
+}
+
+// postWithFee posts the transaction's journal together with that of the fee
+// the fee rules charge for it, if any, so that neither is posted without the
+// other.
+func (w *Worker) postWithFee(tx *models.Transaction, account *models.Account, journal *models.Journal) error {
+	ctx := context.Background()
+	fee, err := w.prepareFee(ctx, tx, account)
+	if err != nil {
+		return err
+	}
+	if fee == nil {
+		return w.ledgerRepo.PostJournal(ctx, journal)
+	}
+
+	feeJournal, err := models.NewTransactionJournal(fee)
+	if err != nil {
+		return fmt.Errorf("unable to build journal for fee %s: %w", fee.ID, err)
+	}
+	err = w.ledgerRepo.PostJournals(ctx, journal, &feeJournal)
+	// A fee already settled by an earlier attempt is final and is not
+	// updated again, since that would break its hash chain links.
+	if fee.Status == models.PENDING {
+		fee.Status = models.SUCCESS
+		if err != nil && !errors.Is(err, postgres.ErrJournalAlreadyPosted) {
+			fee.Status = models.FAILED
+		}
+		w.updateTransaction(ctx, fee)
+	}
+	if err == nil {
+		log.Printf("Charged account %s a fee of %s for transaction %s", account.ID, fee.Amount, tx.ID)
+	}
+	return err
+}
+
+// prepareFee returns the FEE transaction charged for tx, or nil if no fee
+// rule matches it. The fee is created on the first attempt to process tx; a
+// redelivery finds it there, so the amount charged does not change even if the
+// rules have changed since.
+func (w *Worker) prepareFee(ctx context.Context, tx *models.Transaction, account *models.Account) (*models.Transaction, error) {
+	if !tx.Type.Feeable() || tx.Interest != nil {
+		return nil, nil
+	}
+
+	feeID := models.TransactionFeeID(tx.ID)
+	fee, err := w.transactionRepo.GetByID(ctx, feeID)
+	if err == nil {
+		tx.FeeTransactionID = fee.ID
+		return fee, nil
+	}
+	if !errors.Is(err, models.ErrTransactionNotFound) {
+		return nil, fmt.Errorf("failed to look up fee %s: %w", feeID, err)
 	}
 
-	updates := models.AccountUpdate{
-		Balance: &account.Balance,
+	rules, err := w.feeRepo.GetActive(ctx, models.FeeKindTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fee rules: %w", err)
+	}
+	rule := models.TransactionFeeRule(rules, tx, account)
+	if rule == nil {
+		return nil, nil
+	}
+	amount := rule.Compute(tx.Amount)
+	if amount <= 0 {
+		return nil, nil
 	}
 
-	if err := w.accountRepo.Update(context.Background(), account.ID, updates); err != nil {
-		log.Printf("Failed to update account balance: %v", err)
-		return fmt.Errorf("failed to update account balance: %w", err)
+	fee = models.NewFeeTransaction(feeID, account, amount, models.FeeCharge{RuleID: rule.ID, TransactionID: tx.ID}, time.Now())
+	if err := w.transactionRepo.Create(ctx, fee); err != nil {
+		return nil, fmt.Errorf("failed to create fee: %w", err)
 	}
+	tx.FeeTransactionID = fee.ID
+	return fee, nil
+}
 
-	log.Printf("Successfully updated balance to %f for account %s", account.Balance, account.ID)
+// placeHold reserves an authorization's amount on its account. No money moves
+// until the hold is captured.
+func (w *Worker) placeHold(tx *models.Transaction, account *models.Account) error {
+	hold, err := models.NewHold(tx, time.Now())
+	if err != nil {
+		return err
+	}
+	err = w.holdRepo.Place(context.Background(), &hold)
+	if errors.Is(err, postgres.ErrInsufficientFunds) {
+		log.Printf("Insufficient funds: account %s cannot hold %s", account.ID, tx.Amount)
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to place hold: %w", err)
+	}
+
+	log.Printf("Placed hold %s of %s on account %s until %s", hold.ID, hold.Amount, account.ID, hold.ExpiresAt)
 	return nil
 }
 
+func (w *Worker) buildJournal(tx *models.Transaction) (models.Journal, error) {
+	if tx.Type != models.REVERSAL {
+		return models.NewTransactionJournal(tx)
+	}
+
+	original, err := w.transactionRepo.GetByID(context.Background(), tx.ReversalOf)
+	if err != nil {
+		return models.Journal{}, fmt.Errorf("original transaction %s: %w", tx.ReversalOf, err)
+	}
+	if original.ReversedBy != tx.ID {
+		return models.Journal{}, fmt.Errorf("transaction %s is not linked to reversal %s", original.ID, tx.ID)
+	}
+	if original.Currency == "" {
+		original.Currency = models.DefaultCurrency
+	}
+	return models.NewReversalJournal(tx, original)
+}
+
 func (w *Worker) updateTransaction(ctx context.Context, tx *models.Transaction) {
 	tx.ProcessedAt = time.Now()
 	if err := w.transactionRepo.Update(ctx, tx.ID, tx); err != nil {
 		log.Printf("Failed to update transaction ledger: %v", err)
 	}
+
+	// A failed reversal frees the original so it can be reversed again.
+	if tx.Type == models.REVERSAL && tx.Status == models.FAILED {
+		if err := w.transactionRepo.ReleaseReversal(ctx, tx.ReversalOf, tx.ID); err != nil {
+			log.Printf("Failed to release reversal of transaction %s: %v", tx.ReversalOf, err)
+		}
+	}
+	// Likewise a failed capture leaves its hold active for another capture.
+	if tx.Type == models.CAPTURE && tx.Status == models.FAILED {
+		if err := w.holdRepo.ReleaseCaptureClaim(ctx, tx.HoldID, tx.ID); err != nil {
+			log.Printf("Failed to release capture claim on hold %s: %v", tx.HoldID, err)
+		}
+	}
+	// And a failed interest deposit leaves its accruals to be posted again.
+	if tx.Interest != nil && tx.Status == models.FAILED {
+		accountID, err := uuid.Parse(tx.AccountID)
+		if err == nil {
+			err = w.interestRepo.ReleasePosting(ctx, accountID, tx.Interest.PeriodEnd, tx.ID)
+		}
+		if err != nil {
+			log.Printf("Failed to release interest accruals of transaction %s: %v", tx.ID, err)
+		}
+	}
 }
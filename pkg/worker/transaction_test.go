@@ -0,0 +1,275 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RajVerma97/golang-banking-ledger/internal/models"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/mocks"
+	"github.com/RajVerma97/golang-banking-ledger/internal/repository/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type workerMocks struct {
+	accountRepo     *mocks.MockAccountRepository
+	transactionRepo *mocks.MockTransactionRepository
+	ledgerRepo      *mocks.MockLedgerRepository
+	holdRepo        *mocks.MockHoldRepository
+	feeRepo         *mocks.MockFeeRuleRepository
+	interestRepo    *mocks.MockInterestRepository
+}
+
+func newWorker() (*Worker, *workerMocks) {
+	m := &workerMocks{
+		accountRepo:     new(mocks.MockAccountRepository),
+		transactionRepo: new(mocks.MockTransactionRepository),
+		ledgerRepo:      new(mocks.MockLedgerRepository),
+		holdRepo:        new(mocks.MockHoldRepository),
+		feeRepo:         new(mocks.MockFeeRuleRepository),
+		interestRepo:    new(mocks.MockInterestRepository),
+	}
+	return NewTransactionWorker(nil, m.accountRepo, m.transactionRepo, m.ledgerRepo, m.holdRepo, m.feeRepo, m.interestRepo), m
+}
+
+// expectStatus expects the transaction to be updated to status.
+func (m *workerMocks) expectStatus(id string, status models.TransactionStatus) {
+	m.transactionRepo.On("Update", mock.Anything, id, mock.MatchedBy(func(tx *models.Transaction) bool {
+		return tx.Status == status
+	})).Return(nil).Once()
+}
+
+func TestWorker_Closure(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	payoutID := uuid.New()
+
+	account := func(balance, held int64) models.Account {
+		return models.Account{
+			ID:          accountID,
+			Balance:     models.NewMoney(balance),
+			HeldBalance: models.NewMoney(held),
+			Currency:    models.DefaultCurrency,
+			Status:      models.AccountActive,
+		}
+	}
+	payout := models.Account{ID: payoutID, Currency: models.DefaultCurrency, Status: models.AccountActive}
+	closure := func() *models.Transaction {
+		return &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.CLOSURE,
+			Amount:    models.NewMoney(120),
+			Currency:  models.DefaultCurrency,
+			AccountID: accountID.String(),
+			Closure:   &models.AccountClosure{PayoutAccountID: payoutID.String(), Reason: "customer request", OperatorID: "ops-1"},
+			Status:    models.PENDING,
+		}
+	}
+
+	t.Run("Pays Out And Closes", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := closure()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account(120, 0), nil)
+		m.accountRepo.On("GetByID", ctx, payoutID).Return(payout, nil)
+		m.transactionRepo.On("HasOtherPending", ctx, accountID.String(), tx.ID).Return(false, nil)
+		m.accountRepo.On("Close", ctx, mock.MatchedBy(func(journal *models.Journal) bool {
+			return journal.NetChange(models.CustomerLedgerAccount(accountID)) == models.NewMoney(-120) &&
+				journal.NetChange(models.CustomerLedgerAccount(payoutID)) == models.NewMoney(120)
+		}), mock.MatchedBy(func(change *models.AccountStatusChange) bool {
+			return change.AccountID == accountID && change.Reason == "customer request" && change.OperatorID == "ops-1"
+		})).Return(nil)
+		m.expectStatus(tx.ID, models.SUCCESS)
+
+		require.NoError(t, worker.handleTransaction(tx))
+		m.accountRepo.AssertExpectations(t)
+		m.transactionRepo.AssertExpectations(t)
+		m.ledgerRepo.AssertNotCalled(t, "PostJournal", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Held Funds", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := closure()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account(120, 20), nil)
+		m.accountRepo.On("GetByID", ctx, payoutID).Return(payout, nil)
+		m.expectStatus(tx.ID, models.FAILED)
+
+		err := worker.handleTransaction(tx)
+		assert.ErrorIs(t, err, models.ErrPendingTransactions)
+		m.accountRepo.AssertNotCalled(t, "Close", mock.Anything, mock.Anything, mock.Anything)
+		m.transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Pending Transactions", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := closure()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account(120, 0), nil)
+		m.accountRepo.On("GetByID", ctx, payoutID).Return(payout, nil)
+		m.transactionRepo.On("HasOtherPending", ctx, accountID.String(), tx.ID).Return(true, nil)
+		m.expectStatus(tx.ID, models.FAILED)
+
+		err := worker.handleTransaction(tx)
+		assert.ErrorIs(t, err, models.ErrPendingTransactions)
+		m.accountRepo.AssertNotCalled(t, "Close", mock.Anything, mock.Anything, mock.Anything)
+		m.transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Hold Placed While Closing", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := closure()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account(120, 0), nil)
+		m.accountRepo.On("GetByID", ctx, payoutID).Return(payout, nil)
+		m.transactionRepo.On("HasOtherPending", ctx, accountID.String(), tx.ID).Return(false, nil)
+		m.accountRepo.On("Close", ctx, mock.Anything, mock.Anything).Return(models.ErrClosureBalanceChanged)
+		m.expectStatus(tx.ID, models.FAILED)
+
+		err := worker.handleTransaction(tx)
+		assert.ErrorIs(t, err, models.ErrClosureBalanceChanged)
+		m.transactionRepo.AssertExpectations(t)
+	})
+}
+
+func TestWorker_TransactionFee(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	account := models.Account{ID: accountID, Balance: models.NewMoney(100), Currency: models.DefaultCurrency, Status: models.AccountActive}
+	rule := models.FeeRule{
+		ID:              uuid.New(),
+		Kind:            models.FeeKindTransaction,
+		Method:          models.FeeFlat,
+		TransactionType: models.WITHDRAWL,
+		Currency:        models.DefaultCurrency,
+		FlatAmount:      models.NewMoney(2),
+		Active:          true,
+	}
+	withdrawal := func() *models.Transaction {
+		return &models.Transaction{
+			ID:        uuid.New().String(),
+			Type:      models.WITHDRAWL,
+			Amount:    models.NewMoney(50),
+			Currency:  models.DefaultCurrency,
+			AccountID: accountID.String(),
+			Status:    models.PENDING,
+		}
+	}
+	// withFee matches the withdrawal's journal posted together with its fee's.
+	withFee := mock.MatchedBy(func(journals []*models.Journal) bool {
+		customer := models.CustomerLedgerAccount(accountID)
+		return len(journals) == 2 &&
+			journals[0].NetChange(customer) == models.NewMoney(-50) &&
+			journals[1].NetChange(customer) == models.NewMoney(-2)
+	})
+	isFee := func(id string, status models.TransactionStatus) interface{} {
+		return mock.MatchedBy(func(fee *models.Transaction) bool {
+			return fee.ID == id && fee.Type == models.FEE && fee.Status == status
+		})
+	}
+
+	t.Run("Posts The Fee With Its Transaction", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := withdrawal()
+		feeID := models.TransactionFeeID(tx.ID)
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+		m.transactionRepo.On("GetByID", ctx, feeID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		m.feeRepo.On("GetActive", ctx, models.FeeKindTransaction).Return([]models.FeeRule{rule}, nil)
+		m.transactionRepo.On("Create", ctx, mock.MatchedBy(func(fee *models.Transaction) bool {
+			return fee.ID == feeID && fee.Amount == models.NewMoney(2) && fee.Fee.TransactionID == tx.ID
+		})).Return(nil)
+		m.ledgerRepo.On("PostJournals", ctx, withFee).Return(nil)
+		m.transactionRepo.On("Update", mock.Anything, feeID, isFee(feeID, models.SUCCESS)).Return(nil).Once()
+		m.expectStatus(tx.ID, models.SUCCESS)
+
+		require.NoError(t, worker.handleTransaction(tx))
+		assert.Equal(t, feeID, tx.FeeTransactionID)
+		m.ledgerRepo.AssertNotCalled(t, "PostJournal", mock.Anything, mock.Anything)
+		m.ledgerRepo.AssertExpectations(t)
+		m.transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Retry Reuses The Fee", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := withdrawal()
+		feeID := models.TransactionFeeID(tx.ID)
+		fee := models.NewFeeTransaction(feeID, &account, models.NewMoney(2), models.FeeCharge{RuleID: rule.ID, TransactionID: tx.ID}, time.Now())
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+		m.transactionRepo.On("GetByID", ctx, feeID).Return(fee, nil)
+		// The first attempt posted both journals before it stopped.
+		m.ledgerRepo.On("PostJournals", ctx, withFee).Return(postgres.ErrJournalAlreadyPosted)
+		m.transactionRepo.On("Update", mock.Anything, feeID, isFee(feeID, models.SUCCESS)).Return(nil).Once()
+		m.expectStatus(tx.ID, models.SUCCESS)
+
+		require.NoError(t, worker.handleTransaction(tx))
+		m.feeRepo.AssertNotCalled(t, "GetActive", mock.Anything, mock.Anything)
+		m.transactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fee Would Overdraw The Account", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := withdrawal()
+		feeID := models.TransactionFeeID(tx.ID)
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(account, nil)
+		m.transactionRepo.On("GetByID", ctx, feeID).Return((*models.Transaction)(nil), models.ErrTransactionNotFound)
+		m.feeRepo.On("GetActive", ctx, models.FeeKindTransaction).Return([]models.FeeRule{rule}, nil)
+		m.transactionRepo.On("Create", ctx, mock.Anything).Return(nil)
+		m.ledgerRepo.On("PostJournals", ctx, withFee).Return(postgres.ErrInsufficientFunds)
+		m.transactionRepo.On("Update", mock.Anything, feeID, isFee(feeID, models.FAILED)).Return(nil).Once()
+		m.expectStatus(tx.ID, models.FAILED)
+
+		err := worker.handleTransaction(tx)
+		assert.ErrorIs(t, err, postgres.ErrInsufficientFunds)
+		m.ledgerRepo.AssertNotCalled(t, "PostJournal", mock.Anything, mock.Anything)
+		m.transactionRepo.AssertExpectations(t)
+	})
+}
+
+func TestWorker_InterestDeposit(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	periodEnd := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	deposit := func() *models.Transaction {
+		return &models.Transaction{
+			ID:        models.InterestPostingID(accountID, periodEnd, 0),
+			Type:      models.DEPOSIT,
+			Amount:    models.NewMoney(2),
+			Currency:  models.DefaultCurrency,
+			AccountID: accountID.String(),
+			Status:    models.PENDING,
+			Interest:  &models.InterestPosting{PeriodEnd: periodEnd, Accruals: 31},
+		}
+	}
+
+	t.Run("Settles", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := deposit()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency, Status: models.AccountActive}, nil)
+		m.ledgerRepo.On("PostJournal", ctx, mock.Anything).Return(nil)
+		m.expectStatus(tx.ID, models.SUCCESS)
+
+		require.NoError(t, worker.handleTransaction(tx))
+		m.interestRepo.AssertNotCalled(t, "ReleasePosting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure Releases The Accruals", func(t *testing.T) {
+		worker, m := newWorker()
+		tx := deposit()
+		m.transactionRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+		m.accountRepo.On("GetByID", ctx, accountID).Return(models.Account{ID: accountID, Currency: models.DefaultCurrency, Status: models.AccountClosed}, nil)
+		m.expectStatus(tx.ID, models.FAILED)
+		m.interestRepo.On("ReleasePosting", ctx, accountID, periodEnd, tx.ID).Return(nil)
+
+		assert.Error(t, worker.handleTransaction(tx))
+		m.ledgerRepo.AssertNotCalled(t, "PostJournal", mock.Anything, mock.Anything)
+		m.interestRepo.AssertExpectations(t)
+	})
+}